@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"math"
 	"math/rand"
 	"net/http"
@@ -113,6 +114,20 @@ type Account struct {
 	// and if it falls between 0 and that value, message tracing will be triggered.
 	traceDest         string
 	traceDestSampling int
+	// If set, override the server's StreamMaxBufferedMsgs/Size for this account's streams.
+	jsMaxBufferedMsgs int
+	jsMaxBufferedSize int64
+	// If set, overrides the server's DisableShortFirstPing for client connections.
+	disableShortFirstPing *bool
+	// If set, client connections need to support message headers.
+	requireHeaderSupport bool
+	// If set, consumers with a delivery subject can not be created for this account.
+	noPushConsumers bool
+	// If set, service export latency results can only be sent to subjects matching one of these.
+	latencyAllow []string
+	// Last time the account was looked up, in unix nanoseconds, used to
+	// evict the least recently used accounts when max_active_accounts is set.
+	lastUsed atomic.Int64
 	// Guarantee that only one goroutine can be running either checkJetStreamMigrate
 	// or clearObserverState at a given time for this account to prevent interleaving.
 	jscmMu sync.Mutex
@@ -130,6 +145,8 @@ type limits struct {
 	mconns         int32
 	mleafs         int32
 	disallowBearer bool
+	mpend          int64         // Max pending bytes for client connections, 0 means the server's MaxPending applies.
+	wdl            time.Duration // Write deadline for client connections, 0 means the server's WriteDeadline applies.
 }
 
 // Used to track remote clients and leafnodes per remote server.
@@ -269,11 +286,27 @@ type importMap struct {
 	rrMap    map[string][]*serviceRespEntry
 }
 
+// importExportSubjects returns the local subjects of the exports and imports of the account.
+func (a *Account) importExportSubjects() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	subjects := slices.Collect(maps.Keys(a.exports.streams))
+	subjects = slices.AppendSeq(subjects, maps.Keys(a.exports.services))
+	for _, si := range a.imports.streams {
+		if si.to != _EMPTY_ {
+			subjects = append(subjects, si.to)
+		} else {
+			subjects = append(subjects, si.from)
+		}
+	}
+	return slices.AppendSeq(subjects, maps.Keys(a.imports.services))
+}
+
 // NewAccount creates a new unlimited account with the given name.
 func NewAccount(name string) *Account {
 	a := &Account{
 		Name:     name,
-		limits:   limits{-1, -1, -1, -1, false},
+		limits:   limits{-1, -1, -1, -1, false, 0, 0},
 		eventIds: nuid.New(),
 	}
 	return a
@@ -306,6 +339,10 @@ func (a *Account) shallowCopy(na *Account) {
 	na.Nkey = a.Nkey
 	na.Issuer = a.Issuer
 	na.traceDest, na.traceDestSampling = a.traceDest, a.traceDestSampling
+	na.latencyAllow = a.latencyAllow
+	na.disableShortFirstPing = a.disableShortFirstPing
+	na.requireHeaderSupport = a.requireHeaderSupport
+	na.noPushConsumers = a.noPushConsumers
 	na.nrgAccount = a.nrgAccount
 
 	if a.imports.streams != nil {
@@ -353,6 +390,7 @@ func (a *Account) shallowCopy(na *Account) {
 
 	// JetStream
 	na.jsLimits = a.jsLimits
+	na.jsMaxBufferedMsgs, na.jsMaxBufferedSize = a.jsMaxBufferedMsgs, a.jsMaxBufferedSize
 	// Server config account limits.
 	na.limits = a.limits
 }
@@ -842,6 +880,14 @@ func (a *Account) RemoveMapping(src string) bool {
 	return false
 }
 
+// pushConsumersAllowed returns whether consumers with a delivery subject
+// can be created for this account.
+func (a *Account) pushConsumersAllowed() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return !a.noPushConsumers
+}
+
 // Indicates we have mapping entries.
 func (a *Account) hasMappings() bool {
 	if a == nil {
@@ -949,6 +995,24 @@ func (a *Account) Interest(subject string) int {
 	return nms
 }
 
+// hasExternalInterest returns true if there are subscriptions in the account other
+// than the ones of its internal client for service imports, e.g. from routes,
+// gateways or leafnodes.
+// Lock should be held.
+func (a *Account) hasExternalInterest() bool {
+	if a.sl == nil || a.sl.Count() == 0 {
+		return false
+	}
+	var subs []*subscription
+	a.sl.All(&subs)
+	for _, sub := range subs {
+		if sub.client == nil || sub.client != a.ic {
+			return true
+		}
+	}
+	return false
+}
+
 // addClient keeps our accounting of local active clients or leafnodes updated.
 // Returns previous total.
 func (a *Account) addClient(c *client) int {
@@ -1171,6 +1235,21 @@ func (a *Account) TrackServiceExport(service, results string) error {
 	return a.TrackServiceExportWithSampling(service, results, DEFAULT_SERVICE_LATENCY_SAMPLING)
 }
 
+// Checks the latency results subject against the account's allow list, if any.
+func (a *Account) latencySubjectAllowed(results string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.latencyAllow) == 0 {
+		return true
+	}
+	for _, allow := range a.latencyAllow {
+		if subjectIsSubsetMatch(results, allow) {
+			return true
+		}
+	}
+	return false
+}
+
 // TrackServiceExportWithSampling will enable latency tracking of the named service for the given
 // sampling rate (1-100). Results will be published in this account to the given results subject.
 func (a *Account) TrackServiceExportWithSampling(service, results string, sampling int) error {
@@ -1190,6 +1269,9 @@ func (a *Account) TrackServiceExportWithSampling(service, results string, sampli
 	if a.IsExportService(results) {
 		return ErrBadPublishSubject
 	}
+	if !a.latencySubjectAllowed(results) {
+		return ErrLatencySubjectNotAllowed
+	}
 
 	if a.srv != nil && !a.srv.EventsEnabled() {
 		return ErrNoSysAccount
@@ -3196,9 +3278,33 @@ func (a *Account) expiredTimeout() {
 	}
 }
 
+// Called when the account JWT has expired. If the server is configured
+// with an expiration grace, the account is marked as expired so that new
+// connections are rejected, but existing clients are disconnected only
+// when the grace period elapses, unless the JWT is updated in the meantime.
+func (a *Account) jwtExpired() {
+	a.mu.Lock()
+	var grace time.Duration
+	s := a.srv
+	if s != nil {
+		grace = s.getOpts().AccountExpirationGrace
+	}
+	if grace <= 0 {
+		a.mu.Unlock()
+		a.expiredTimeout()
+		return
+	}
+	a.expired.Store(true)
+	a.clearExpirationTimer()
+	a.etmr = time.AfterFunc(grace, a.expiredTimeout)
+	a.mu.Unlock()
+	s.Warnf("Account %q JWT has expired, rejecting new connections and disconnecting existing clients in %v unless the JWT is updated",
+		a.Name, grace)
+}
+
 // Sets the expiration timer for an account JWT that has it set.
 func (a *Account) setExpirationTimer(d time.Duration) {
-	a.etmr = time.AfterFunc(d, a.expiredTimeout)
+	a.etmr = time.AfterFunc(d, a.jwtExpired)
 }
 
 // Lock should be held
@@ -4180,6 +4286,7 @@ type DirAccResolver struct {
 	*Server
 	syncInterval time.Duration
 	fetchTimeout time.Duration
+	limitPct     int64 // If set, the limit was derived from this percentage of disk.
 }
 
 func (dr *DirAccResolver) IsTrackingUpdate() bool {
@@ -4564,6 +4671,7 @@ func (dr *DirAccResolver) Start(s *Server) error {
 		}
 	})
 	s.Noticef("Managing all jwt in exclusive directory %s", dr.directory)
+	dr.resolveLimit(s)
 	return nil
 }
 
@@ -4599,6 +4707,31 @@ func FetchTimeout(to time.Duration) DirResOption {
 	}
 }
 
+// records the percentage of disk the limit was derived from, for logging
+func limitFromDiskPercent(pct int64) DirResOption {
+	return func(r *DirAccResolver) error {
+		r.limitPct = pct
+		return nil
+	}
+}
+
+// Returns the number of jwt that fit in the given percentage of the filesystem
+// hosting dir, with each jwt bounded by the maximum payload size.
+func dirResolverLimitFromDisk(dir string, pct int64) int64 {
+	return max(diskSize(dir)/100*pct/MAX_PAYLOAD_SIZE, 1)
+}
+
+// Resolves the limit if it was configured as a percentage of disk. This is
+// done when the resolver starts, not when the configuration is parsed.
+// Lock should be held.
+func (dr *DirAccResolver) resolveLimit(s *Server) {
+	if dr.limitPct <= 0 || dr.expiration == nil {
+		return
+	}
+	dr.expiration.limit = dirResolverLimitFromDisk(dr.directory, dr.limitPct)
+	s.Noticef("Resolver limit of %d%% of disk resolved to %d jwt", dr.limitPct, dr.expiration.limit)
+}
+
 func (dr *DirAccResolver) apply(opts ...DirResOption) error {
 	for _, o := range opts {
 		if err := o(dr); err != nil {
@@ -4620,7 +4753,7 @@ func NewDirAccResolver(path string, limit int64, syncInterval time.Duration, del
 		return nil, err
 	}
 
-	res := &DirAccResolver{store, nil, syncInterval, DEFAULT_ACCOUNT_FETCH_TIMEOUT}
+	res := &DirAccResolver{store, nil, syncInterval, DEFAULT_ACCOUNT_FETCH_TIMEOUT, 0}
 	if err := res.apply(opts...); err != nil {
 		return nil, err
 	}
@@ -4706,7 +4839,7 @@ func NewCacheDirAccResolver(path string, limit int64, ttl time.Duration, opts ..
 	if err != nil {
 		return nil, err
 	}
-	res := &CacheDirAccResolver{DirAccResolver{store, nil, 0, DEFAULT_ACCOUNT_FETCH_TIMEOUT}, ttl}
+	res := &CacheDirAccResolver{DirAccResolver{store, nil, 0, DEFAULT_ACCOUNT_FETCH_TIMEOUT, 0}, ttl}
 	if err := res.apply(opts...); err != nil {
 		return nil, err
 	}
@@ -4803,6 +4936,7 @@ func (dr *CacheDirAccResolver) Start(s *Server) error {
 		return fmt.Errorf("error setting up list request handling: %v", err)
 	}
 	s.Noticef("Managing some jwt in exclusive directory %s", dr.directory)
+	dr.resolveLimit(s)
 	return nil
 }
 
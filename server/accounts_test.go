@@ -3537,6 +3537,137 @@ func TestAccountLimitsServerConfig(t *testing.T) {
 	require_Error(t, err)
 }
 
+func TestAccountLimitsMaxPending(t *testing.T) {
+	cf := createConfFile(t, []byte(`
+	port: -1
+	max_pending: 64MB
+	accounts {
+		A {
+			users = [{user: a, password: pwd}]
+			limits { max_pending: 1MB }
+		}
+		B {
+			users = [{user: b, password: pwd}]
+		}
+	}
+    `))
+
+	s, _ := RunServerWithConfig(cf)
+	defer s.Shutdown()
+
+	nca, err := nats.Connect(s.ClientURL(), nats.UserInfo("a", "pwd"))
+	require_NoError(t, err)
+	defer nca.Close()
+
+	ncb, err := nats.Connect(s.ClientURL(), nats.UserInfo("b", "pwd"))
+	require_NoError(t, err)
+	defer ncb.Close()
+
+	connz, err := s.Connz(&ConnzOptions{Username: true})
+	require_NoError(t, err)
+	require_Len(t, len(connz.Conns), 2)
+	for _, ci := range connz.Conns {
+		switch ci.Account {
+		case "A":
+			require_Equal(t, ci.MaxPending, 1024*1024)
+		case "B":
+			require_Equal(t, ci.MaxPending, 64*1024*1024)
+		default:
+			t.Fatalf("Unexpected account %q", ci.Account)
+		}
+	}
+
+	cf = createConfFile(t, []byte(`
+	accounts {
+		A {
+			limits { max_pending: -1 }
+		}
+	}
+    `))
+	_, err = ProcessConfigFile(cf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "max_pending must be positive")
+}
+
+func TestAccountQoSProfiles(t *testing.T) {
+	cf := createConfFile(t, []byte(`
+	port: -1
+	max_pending: 64MB
+	write_deadline: "10s"
+	accounts {
+		A {
+			users = [{user: a, password: pwd}]
+			qos_profile: interactive
+		}
+		B {
+			users = [{user: b, password: pwd}]
+			qos_profile: interactive
+			limits { max_pending: 2MB }
+		}
+		C {
+			users = [{user: c, password: pwd}]
+		}
+	}
+	qos_profiles {
+		interactive { max_pending: 1MB, write_deadline: "500ms" }
+	}
+    `))
+
+	s, _ := RunServerWithConfig(cf)
+	defer s.Shutdown()
+
+	for _, user := range []string{"a", "b", "c"} {
+		nc, err := nats.Connect(s.ClientURL(), nats.UserInfo(user, "pwd"))
+		require_NoError(t, err)
+		defer nc.Close()
+	}
+
+	s.mu.Lock()
+	clients := make([]*client, 0, len(s.clients))
+	for _, c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+	require_Len(t, len(clients), 3)
+
+	for _, c := range clients {
+		c.mu.Lock()
+		acc, mp, wdl := c.acc.Name, c.out.mp, c.out.wdl
+		c.mu.Unlock()
+		switch acc {
+		case "A":
+			require_Equal(t, mp, 1024*1024)
+			require_Equal(t, wdl, 500*time.Millisecond)
+		case "B":
+			// The account limits take precedence over the profile.
+			require_Equal(t, mp, 2*1024*1024)
+			require_Equal(t, wdl, 500*time.Millisecond)
+		case "C":
+			require_Equal(t, mp, 64*1024*1024)
+			require_Equal(t, wdl, 10*time.Second)
+		default:
+			t.Fatalf("Unexpected account %q", acc)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		conf string
+		err  string
+	}{
+		{"unknown profile", `accounts { A { qos_profile: bulk } }`, `Unknown qos_profile "bulk"`},
+		{"negative max pending", `qos_profiles { bulk { max_pending: -1 } }`, "max_pending must be positive"},
+		{"negative write deadline", `qos_profiles { bulk { write_deadline: "-1s" } }`, "write_deadline must be positive"},
+		{"unknown field", `qos_profiles { bulk { max_payload: 1 } }`, `Unknown field "max_payload"`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ProcessConfigFile(createConfFile(t, []byte(test.conf)))
+			require_Error(t, err)
+			require_Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
 // Connections being closed should be the newer ones in case of JWT limits.
 func TestAccountMaxConnectionsDisconnectsNewestFirst(t *testing.T) {
 	cf := createConfFile(t, []byte(`
@@ -69,6 +69,7 @@ type NkeyUser struct {
 	SigningKey             string              `json:"signing_key,omitempty"`
 	AllowedConnectionTypes map[string]struct{} `json:"connection_types,omitempty"`
 	ProxyRequired          bool                `json:"proxy_required,omitempty"`
+	MonitoringOnly         bool                `json:"monitoring_only,omitempty"`
 	defaultPerms           bool
 }
 
@@ -81,6 +82,7 @@ type User struct {
 	ConnectionDeadline     time.Time           `json:"connection_deadline,omitempty"`
 	AllowedConnectionTypes map[string]struct{} `json:"connection_types,omitempty"`
 	ProxyRequired          bool                `json:"proxy_required,omitempty"`
+	MonitoringOnly         bool                `json:"monitoring_only,omitempty"`
 }
 
 // clone performs a deep copy of the User struct, returning a new clone with
@@ -194,6 +196,64 @@ func (p *Permissions) clone() *Permissions {
 	return clone
 }
 
+// monitoringOnlySubjects are the only subjects a monitoring only user may publish to.
+// They allow the following read operations, anything else, including publishing
+// messages and creating, updating or deleting JetStream assets, is denied:
+//   - Server monitoring requests: IDZ, STATSZ, VARZ, SUBSZ, CONNZ, ROUTEZ, GATEWAYZ,
+//     LEAFZ, ACCOUNTZ, JSZ, HEALTHZ, EXPVARZ, IPQUEUESZ and RAFTZ, either directed
+//     to a server or pinging all of them. RELOAD, KICK, LDM and PROFILEZ are denied.
+//   - Account monitoring requests: SUBSZ, CONNZ, LEAFZ, JSZ, INFO, STATZ and CONNS.
+//   - User info requests.
+//   - JetStream account info, and stream and consumer info, names and list requests.
+var monitoringOnlySubjects = []string{
+	serverStatsPingReqSubj,
+	"$SYS.REQ.SERVER.*.IDZ",
+	"$SYS.REQ.SERVER.*.STATSZ",
+	"$SYS.REQ.SERVER.*.VARZ",
+	"$SYS.REQ.SERVER.*.SUBSZ",
+	"$SYS.REQ.SERVER.*.CONNZ",
+	"$SYS.REQ.SERVER.*.ROUTEZ",
+	"$SYS.REQ.SERVER.*.GATEWAYZ",
+	"$SYS.REQ.SERVER.*.LEAFZ",
+	"$SYS.REQ.SERVER.*.ACCOUNTZ",
+	"$SYS.REQ.SERVER.*.JSZ",
+	"$SYS.REQ.SERVER.*.HEALTHZ",
+	"$SYS.REQ.SERVER.*.EXPVARZ",
+	"$SYS.REQ.SERVER.*.IPQUEUESZ",
+	"$SYS.REQ.SERVER.*.RAFTZ",
+	"$SYS.REQ.ACCOUNT.*.SUBSZ",
+	"$SYS.REQ.ACCOUNT.*.CONNZ",
+	"$SYS.REQ.ACCOUNT.*.LEAFZ",
+	"$SYS.REQ.ACCOUNT.*.JSZ",
+	"$SYS.REQ.ACCOUNT.*.INFO",
+	"$SYS.REQ.ACCOUNT.*.STATZ",
+	"$SYS.REQ.ACCOUNT.*.CONNS",
+	userDirectInfoSubj,
+	JSApiAccountInfo,
+	JSApiStreamInfo,
+	JSApiStreams,
+	JSApiStreamList,
+	JSApiConsumerInfo,
+	JSApiConsumers,
+	JSApiConsumerList,
+}
+
+// monitoringOnlyPermissions returns the permissions of a monitoring only user,
+// publishing is restricted to monitoringOnlySubjects on top of perms.
+func monitoringOnlyPermissions(perms *Permissions) *Permissions {
+	mp := perms.clone()
+	if mp == nil {
+		mp = &Permissions{}
+	}
+	if mp.Publish == nil {
+		mp.Publish = &SubjectPermission{}
+	}
+	mp.Publish.Allow = slices.Clone(monitoringOnlySubjects)
+	// Responding to requests is publishing too.
+	mp.Response = nil
+	return mp
+}
+
 // checkAuthforWarnings will look for insecure settings and log concerns.
 // Lock is assumed held.
 func (s *Server) checkAuthforWarnings() {
@@ -221,6 +281,42 @@ func (s *Server) checkAuthforWarnings() {
 		// Warning about using plaintext passwords.
 		s.Warnf("Plaintext passwords detected, use nkeys or bcrypt")
 	}
+	// Users restricted to connection types that have no listener configured
+	// would not be able to connect, but those may be enabled on reload.
+	for _, u := range s.users {
+		for _, ct := range orphanedConnectionTypes(opts, u.AllowedConnectionTypes) {
+			s.Warnf("User %q allowed connection type %q has no corresponding listener configured", u.Username, ct)
+		}
+	}
+	for _, u := range s.nkeys {
+		for _, ct := range orphanedConnectionTypes(opts, u.AllowedConnectionTypes) {
+			s.Warnf("User %q allowed connection type %q has no corresponding listener configured", u.Nkey, ct)
+		}
+	}
+}
+
+// Returns the sorted allowed connection types for which the listener they
+// require is not configured.
+func orphanedConnectionTypes(o *Options, cts map[string]struct{}) []string {
+	var orphaned []string
+	for ct := range cts {
+		var port int
+		switch ct {
+		case jwt.ConnectionTypeWebsocket, jwt.ConnectionTypeLeafnodeWS, jwt.ConnectionTypeMqttWS:
+			port = o.Websocket.Port
+		case jwt.ConnectionTypeLeafnode:
+			port = o.LeafNode.Port
+		case jwt.ConnectionTypeMqtt:
+			port = o.MQTT.Port
+		default:
+			continue
+		}
+		if port == 0 {
+			orphaned = append(orphaned, ct)
+		}
+	}
+	slices.Sort(orphaned)
+	return orphaned
 }
 
 // If Users or Nkeys options have definitions without an account defined,
@@ -752,6 +848,8 @@ func (s *Server) processClientOrLeafAuthentication(c *client, opts *Options) (au
 		password      string
 		token         string
 		noAuthUser    string
+		noAuthPerms   *Permissions
+		anonymous     bool
 		pinnedAcounts map[string]struct{}
 	)
 	tlsMap := opts.TLSMap
@@ -787,13 +885,20 @@ func (s *Server) processClientOrLeafAuthentication(c *client, opts *Options) (au
 	} else {
 		tlsMap = opts.LeafNode.TLSMap
 	}
+	// Deriving the account from the client certificate is only done for the client listener.
+	var tlsAccMapping string
+	if tlsMap && c.kind == CLIENT && c.clientType() == NATS {
+		tlsAccMapping = opts.TLSAccountMapping
+	}
 
 	if !ao {
 		noAuthUser = opts.NoAuthUser
+		noAuthPerms = opts.NoAuthUserPermissions
 		// If a leaf connects using websocket, and websocket{} block has a no_auth_user
 		// use that one instead.
 		if c.kind == LEAF && c.isWebsocket() && opts.Websocket.NoAuthUser != _EMPTY_ {
 			noAuthUser = opts.Websocket.NoAuthUser
+			noAuthPerms = nil
 		}
 		username = opts.Username
 		password = opts.Password
@@ -851,6 +956,15 @@ func (s *Server) processClientOrLeafAuthentication(c *client, opts *Options) (au
 			c.Debugf("User JWT no longer valid: %+v", vr)
 			return false
 		}
+		// Reject JWTs issued too long ago, regardless of their expiration.
+		if maxAge := opts.MaxUserJWTAge; maxAge > 0 {
+			if issued := time.Unix(juc.IssuedAt, 0); time.Since(issued) > maxAge {
+				s.mu.Unlock()
+				c.Debugf("User JWT issued at %v is older than max age of %v", issued.UTC(), maxAge)
+				c.setAuthError(ErrAuthJWTTooOld)
+				return false
+			}
+		}
 		pinnedAcounts = opts.resolverPinnedAccounts
 	}
 
@@ -864,6 +978,7 @@ func (s *Server) processClientOrLeafAuthentication(c *client, opts *Options) (au
 				c.mu.Lock()
 				c.opts.Nkey = noAuthUser
 				c.mu.Unlock()
+				anonymous = true
 			}
 		}
 		if c.opts.Nkey != _EMPTY_ {
@@ -943,6 +1058,7 @@ func (s *Server) processClientOrLeafAuthentication(c *client, opts *Options) (au
 					c.opts.Username = u.Username
 					c.opts.Password = u.Password
 					c.mu.Unlock()
+					anonymous = true
 				}
 			}
 			if c.opts.Username != _EMPTY_ {
@@ -1155,6 +1271,12 @@ func (s *Server) processClientOrLeafAuthentication(c *client, opts *Options) (au
 				return false
 			}
 		}
+		// Anonymous connections may be restricted further than the user itself.
+		if anonymous && noAuthPerms != nil {
+			nu := *nkey
+			nu.Permissions = noAuthPerms
+			nkey = &nu
+		}
 		if err := c.RegisterNkeyUser(nkey); err != nil {
 			return false
 		}
@@ -1165,6 +1287,20 @@ func (s *Server) processClientOrLeafAuthentication(c *client, opts *Options) (au
 			return setProxyAuthError(ErrAuthProxyRequired)
 		}
 		ok = comparePasswords(user.Password, c.opts.Password)
+		// The account may be derived from the client certificate.
+		if ok && tlsAccMapping != _EMPTY_ {
+			var err error
+			if user, err = s.userWithTLSMappedAccount(c, user, tlsAccMapping); err != nil {
+				c.Errorf("TLS account mapping failed: %v", err)
+				return false
+			}
+		}
+		// Anonymous connections may be restricted further than the user itself.
+		if ok && anonymous && noAuthPerms != nil {
+			nu := *user
+			nu.Permissions = noAuthPerms
+			user = &nu
+		}
 		// If we are authorized, register the user which will properly setup any permissions
 		// for pub/sub authorizations.
 		if ok {
@@ -1274,6 +1410,55 @@ func getTLSAuthDCs(rdns *pkix.RDNSequence) string {
 
 type tlsMapAuthFn func(string, *ldap.DN, bool) (string, bool)
 
+// tlsAccountMappingFields are the client certificate subject attributes that
+// can be used with `tls { account_mapping { from: <attr> } }` to derive the
+// account of users authenticated with verify_and_map.
+var tlsAccountMappingFields = map[string]func(*pkix.Name) []string{
+	"CN": func(n *pkix.Name) []string {
+		if n.CommonName == _EMPTY_ {
+			return nil
+		}
+		return []string{n.CommonName}
+	},
+	"O":  func(n *pkix.Name) []string { return n.Organization },
+	"OU": func(n *pkix.Name) []string { return n.OrganizationalUnit },
+	"C":  func(n *pkix.Name) []string { return n.Country },
+	"L":  func(n *pkix.Name) []string { return n.Locality },
+	"ST": func(n *pkix.Name) []string { return n.Province },
+}
+
+// userWithTLSMappedAccount returns a copy of the user bound to the account named by
+// the given subject attribute of the client certificate. When the attribute has
+// multiple values, the first one naming a known account is used.
+//
+// The user itself is still the one mapped from the certificate by verify_and_map,
+// only its account is derived from the certificate. Users defined outside of any
+// account take the derived account, while users explicitly defined in an account
+// must map to that same account, otherwise this returns an error.
+func (s *Server) userWithTLSMappedAccount(c *client, user *User, from string) (*User, error) {
+	tlsState := c.GetTLSConnectionState()
+	if tlsState == nil || len(tlsState.PeerCertificates) == 0 {
+		return nil, fmt.Errorf("no peer certificate to map account from")
+	}
+	values := tlsAccountMappingFields[from](&tlsState.PeerCertificates[0].Subject)
+	var acc *Account
+	for _, v := range values {
+		if acc, _ = s.lookupAccount(v); acc != nil {
+			break
+		}
+	}
+	if acc == nil {
+		return nil, fmt.Errorf("no account found for certificate %s %q", from, values)
+	}
+	if ua := user.Account; ua != nil && ua != s.globalAccount() && ua.Name != acc.Name {
+		return nil, fmt.Errorf("certificate %s maps user %q to account %q, but it is defined in account %q",
+			from, user.Username, acc.Name, ua.Name)
+	}
+	nu := user.clone()
+	nu.Account = acc
+	return nu, nil
+}
+
 func checkClientTLSCertSubject(c *client, fn tlsMapAuthFn) bool {
 	tlsState := c.GetTLSConnectionState()
 	if tlsState == nil {
@@ -1748,6 +1933,8 @@ func getAuthErrClosedState(authErr error) ClosedState {
 		return ProxyNotTrusted
 	case ErrAuthProxyRequired:
 		return ProxyRequired
+	case ErrAuthJWTTooOld:
+		return UserJWTTooOld
 	default:
 		return AuthenticationViolation
 	}
@@ -166,6 +166,36 @@ func TestUserUnknownAllowedConnectionType(t *testing.T) {
 	}
 }
 
+func TestUserAllowedConnectionTypeWithoutListenerWarning(t *testing.T) {
+	o := DefaultOptions()
+	o.Websocket.Port = -1
+	o.Websocket.NoTLS = true
+	o.Users = []*User{{
+		Username: "user",
+		Password: "$2a$11$W2zko751KUvVy59mUTWmpOdWjpEm5qhcCZRd05GjI/sSOT.xtiHyG",
+		AllowedConnectionTypes: testCreateAllowedConnectionTypes([]string{
+			jwt.ConnectionTypeStandard, jwt.ConnectionTypeWebsocket, jwt.ConnectionTypeMqtt, jwt.ConnectionTypeLeafnode,
+		}),
+	}}
+	o.Nkeys = []*NkeyUser{{
+		Nkey:                   "UDXU4RCSJNZOIQHZNWXHXORDPRTGNJAHAHFRGZNEEJCPQTT2M7NLCNF4",
+		AllowedConnectionTypes: testCreateAllowedConnectionTypes([]string{jwt.ConnectionTypeMqttWS}),
+	}}
+	s, err := NewServer(o)
+	require_NoError(t, err)
+	l := &captureWarnLogger{warn: make(chan string, 10)}
+	s.SetLogger(l, false, false)
+
+	s.checkAuthforWarnings()
+	var warnings []string
+	for len(l.warn) > 0 {
+		warnings = append(warnings, <-l.warn)
+	}
+	require_Len(t, len(warnings), 2)
+	require_Equal(t, warnings[0], `User "user" allowed connection type "LEAFNODE" has no corresponding listener configured`)
+	require_Equal(t, warnings[1], `User "user" allowed connection type "MQTT" has no corresponding listener configured`)
+}
+
 func TestDNSAltNameMatching(t *testing.T) {
 	for idx, test := range []struct {
 		altName string
@@ -342,6 +372,167 @@ func TestNoAuthUserNkey(t *testing.T) {
 	require_Equal(t, userInfo.Account, "BAR")
 }
 
+func TestNoAuthUserPermissions(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		noAuthUser string
+	}{
+		{"user", "foo"},
+		{"nkey", "UBO2MQV67TQTVIRV3XFTEZOACM4WLOCMCDMAWN5QVN5PI2N6JHTVDRON"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			conf := createConfFile(t, []byte(fmt.Sprintf(`
+				listen: "127.0.0.1:-1"
+				accounts {
+					FOO { users [
+						{user: "foo", password: "pwd"}
+						{nkey: "UBO2MQV67TQTVIRV3XFTEZOACM4WLOCMCDMAWN5QVN5PI2N6JHTVDRON"}
+					] }
+				}
+				no_auth_user {
+					user: %q
+					permissions { publish { deny: ">" } }
+				}
+			`, test.noAuthUser)))
+			s, _ := RunServerWithConfig(conf)
+			defer s.Shutdown()
+
+			// The authenticated user keeps its own permissions.
+			nc := natsConnect(t, s.ClientURL(), nats.UserInfo("foo", "pwd"))
+			defer nc.Close()
+
+			// Anonymous connections are mapped to the user with narrower permissions.
+			errCh := make(chan error, 1)
+			anc := natsConnect(t, s.ClientURL(), nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+				errCh <- err
+			}))
+			defer anc.Close()
+
+			sub := natsSubSync(t, anc, "foo")
+			natsFlush(t, anc)
+			natsPub(t, nc, "foo", []byte("hello"))
+			natsNexMsg(t, sub, time.Second)
+
+			natsPub(t, anc, "foo", []byte("hello"))
+			select {
+			case err := <-errCh:
+				require_Contains(t, err.Error(), "Permissions Violation for Publish")
+			case <-time.After(time.Second):
+				t.Fatal("Expected a publish permissions violation")
+			}
+			if msg, err := sub.NextMsg(100 * time.Millisecond); err == nil {
+				t.Fatalf("Unexpected message: %+v", msg)
+			}
+		})
+	}
+}
+
+func TestNoAuthUserPermissionsUnknownUser(t *testing.T) {
+	conf := createConfFile(t, []byte(`
+		listen: "127.0.0.1:-1"
+		accounts {
+			FOO { users [{user: "foo", password: "pwd"}] }
+		}
+		no_auth_user {
+			user: "bar"
+			permissions { publish { deny: ">" } }
+		}
+	`))
+	_, err := ProcessConfigFile(conf)
+	require_NoError(t, err)
+	o := LoadConfig(conf)
+	_, err = NewServer(o)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), `no_auth_user: "bar" not present`)
+}
+
+func TestUserMonitoringOnly(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: "127.0.0.1:-1"
+		jetstream: {store_dir: %q}
+		system_account: SYS
+		accounts {
+			SYS { users [{user: "sys", password: "pwd", monitoring_only: true}] }
+			A {
+				jetstream: enabled
+				users [
+					{user: "a", password: "pwd"}
+					{user: "amon", password: "pwd", monitoring_only: true}
+				]
+			}
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	connect := func(user string) (*nats.Conn, chan error) {
+		t.Helper()
+		errCh := make(chan error, 10)
+		nc := natsConnect(t, s.ClientURL(), nats.UserInfo(user, "pwd"),
+			nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+				errCh <- err
+			}))
+		return nc, errCh
+	}
+	requireViolation := func(errCh chan error, subj string) {
+		t.Helper()
+		select {
+		case err := <-errCh:
+			require_Contains(t, err.Error(), "Permissions Violation for Publish", subj)
+		case <-time.After(time.Second):
+			t.Fatalf("Expected a publish permissions violation for %q", subj)
+		}
+	}
+
+	// System account reads are allowed, mutating requests are not.
+	nc, errCh := connect("sys")
+	defer nc.Close()
+	_, err := nc.Request(fmt.Sprintf(serverPingReqSubj, "VARZ"), nil, time.Second)
+	require_NoError(t, err)
+	_, err = nc.Request(fmt.Sprintf(serverDirectReqSubj, s.ID(), "CONNZ"), nil, time.Second)
+	require_NoError(t, err)
+	_, err = nc.Request(fmt.Sprintf(accDirectReqSubj, "A", "JSZ"), nil, time.Second)
+	require_NoError(t, err)
+	_, err = nc.Request(fmt.Sprintf(serverReloadReqSubj, s.ID()), nil, 250*time.Millisecond)
+	require_Error(t, err)
+	requireViolation(errCh, "RELOAD")
+
+	// JetStream reads are allowed, but not creating assets or publishing.
+	anc, js := jsClientConnect(t, s, nats.UserInfo("a", "pwd"))
+	defer anc.Close()
+	_, err = js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	mnc, errCh := connect("amon")
+	defer mnc.Close()
+	mjs, err := mnc.JetStream()
+	require_NoError(t, err)
+	_, err = mjs.AccountInfo()
+	require_NoError(t, err)
+	_, err = mjs.StreamInfo("TEST")
+	require_NoError(t, err)
+	_, err = mnc.Request(fmt.Sprintf(JSApiConsumerCreateT, "TEST"), []byte(`{"stream_name":"TEST","config":{}}`), 250*time.Millisecond)
+	require_Error(t, err)
+	requireViolation(errCh, "CONSUMER.CREATE")
+	_, err = mnc.Request(fmt.Sprintf(JSApiStreamDeleteT, "TEST"), nil, 250*time.Millisecond)
+	require_Error(t, err)
+	requireViolation(errCh, "STREAM.DELETE")
+	natsPub(t, mnc, "foo", []byte("hello"))
+	requireViolation(errCh, "foo")
+}
+
+func TestUserMonitoringOnlyNotBoolean(t *testing.T) {
+	conf := createConfFile(t, []byte(`
+		listen: "127.0.0.1:-1"
+		accounts {
+			A { users [{user: "a", password: "pwd", monitoring_only: "yes"}] }
+		}
+	`))
+	_, err := ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "Expected monitoring_only to be a boolean")
+}
+
 func TestUserConnectionDeadline(t *testing.T) {
 	clientAuth := &DummyAuth{
 		t:        t,
@@ -225,6 +225,9 @@ const (
 	Kicked
 	ProxyNotTrusted
 	ProxyRequired
+	HeaderSupportRequired
+	UserJWTTooOld
+	MaxLeafNodeAccountsExceeded
 )
 
 // Some flags passed to processMsgResults
@@ -271,6 +274,7 @@ type client struct {
 	mpay       int32
 	msubs      int32
 	mcl        int32
+	mpt        uint8
 	mu         sync.RWMutex
 	cid        uint64
 	start      time.Time
@@ -315,10 +319,12 @@ type client struct {
 
 	rref byte
 
-	trace bool
-	echo  bool
-	noIcb bool
-	iproc bool // In-Process connection, set at creation and immutable.
+	trace  bool
+	echo   bool
+	noIcb  bool
+	ldebug atomic.Bool // Debug logging enabled by the options of this connection's listener.
+	ltrace atomic.Bool // Trace logging enabled by the options of this connection's listener.
+	iproc  bool        // In-Process connection, set at creation and immutable.
 
 	tags    jwt.TagList
 	nameTag string
@@ -331,6 +337,9 @@ type client struct {
 	// if we want to report a different error, we can now set this field
 	// and `authViolation()` will use that one.
 	authErr error
+
+	// Last error sent to the client, reported in extended disconnect advisories.
+	lastErr string
 }
 
 type rrTracking struct {
@@ -355,6 +364,8 @@ type outbound struct {
 	sg  *sync.Cond         // To signal writeLoop that there is data to flush.
 	wdl time.Duration      // Snapshot of write deadline.
 	mp  int64              // Snapshot of max pending for client.
+	slt int64              // Snapshot of slow consumer log threshold for client.
+	slw bool               // Pending bytes are above the slow consumer log threshold.
 	lft time.Duration      // Last flush time for Write.
 	stc chan struct{}      // Stall chan we create to slow down producers on overrun, e.g. fan-in.
 	cw  *s2.Writer
@@ -703,10 +714,37 @@ func (c *client) setTraceLevel() {
 	if c.kind == SYSTEM && !(atomic.LoadInt32(&c.srv.logging.traceSysAcc) != 0) {
 		c.trace = false
 	} else {
-		c.trace = (atomic.LoadInt32(&c.srv.logging.trace) != 0)
+		c.trace = (atomic.LoadInt32(&c.srv.logging.trace) != 0) || c.ltrace.Load()
 	}
 }
 
+// Returns whether the options of the listener that this connection belongs
+// to enable debug and trace logging for its connections.
+func (c *client) listenerLogging(opts *Options) (debug, trace bool) {
+	switch c.kind {
+	case ROUTER:
+		return opts.Cluster.Debug, opts.Cluster.Trace
+	case GATEWAY:
+		return opts.Gateway.Debug, opts.Gateway.Trace
+	case LEAF:
+		return opts.LeafNode.Debug, opts.LeafNode.Trace
+	case CLIENT:
+		if c.isMqtt() {
+			return opts.MQTT.Debug, opts.MQTT.Trace
+		} else if c.isWebsocket() {
+			return opts.Websocket.Debug, opts.Websocket.Trace
+		}
+	}
+	return false, false
+}
+
+// Updates the debug and trace logging enabled by this connection's listener.
+func (c *client) setListenerLogging(opts *Options) {
+	debug, trace := c.listenerLogging(opts)
+	c.ldebug.Store(debug)
+	c.ltrace.Store(trace)
+}
+
 // Lock should be held
 func (c *client) initClient() {
 	s := c.srv
@@ -744,6 +782,7 @@ func (c *client) initClient() {
 		}
 	}
 	c.out.mp = opts.MaxPending
+	c.out.slt = opts.SlowConsumerLogThreshold
 	// Snapshot max control line since currently can not be changed on reload and we
 	// were checking it on each call to parse. If this changes and we allow MaxControlLine
 	// to be reloaded without restart, this code will need to change.
@@ -751,10 +790,13 @@ func (c *client) initClient() {
 	if c.mcl == 0 {
 		c.mcl = MAX_CONTROL_LINE_SIZE
 	}
+	// Same for the max number of tokens in published subjects.
+	c.mpt = opts.MaxPubSubTokens
 
 	c.subs = make(map[string]*subscription)
 	c.echo = true
 
+	c.setListenerLogging(opts)
 	c.setTraceLevel()
 
 	// This is a scratch buffer used for processMsg()
@@ -913,6 +955,16 @@ func (c *client) subsAtLimit() bool {
 	return c.msubs != jwt.NoLimit && len(c.subs) >= int(c.msubs)
 }
 
+// Returns the subscriptions limit for the configured max subscriptions.
+// In options, 0 means that the default applies, which is no limit, and
+// a negative value means explicitly unlimited.
+func maxSubsLimit(maxSubs int) int32 {
+	if maxSubs <= 0 {
+		return jwt.NoLimit
+	}
+	return clampInt64ToInt32(int64(maxSubs))
+}
+
 func minLimit(value *int32, limit int32) bool {
 	v := atomic.LoadInt32(value)
 	if v != jwt.NoLimit {
@@ -957,19 +1009,32 @@ func (c *client) applyAccountLimits() {
 	c.acc.mu.RLock()
 	minLimit(&c.mpay, c.acc.mpay)
 	minLimit(&c.msubs, c.acc.msubs)
+	mpend := c.acc.mpend
+	wdl := c.acc.wdl
 	c.acc.mu.RUnlock()
 
 	s := c.srv
 	opts := s.getOpts()
+	// The account may override the max pending bytes and the write deadline
+	// of its client connections.
+	if c.kind == CLIENT {
+		if mpend > 0 {
+			c.out.mp = mpend
+		} else {
+			c.out.mp = opts.MaxPending
+		}
+		if wdl > 0 {
+			c.out.wdl = wdl
+		} else {
+			c.out.wdl = opts.WriteDeadline
+		}
+	}
 	mPay := opts.MaxPayload
 	// options encode unlimited differently
 	if mPay == 0 {
 		mPay = jwt.NoLimit
 	}
-	mSubs := int32(opts.MaxSubs)
-	if mSubs == 0 {
-		mSubs = jwt.NoLimit
-	}
+	mSubs := maxSubsLimit(opts.MaxSubs)
 	wasUnlimited := atomic.LoadInt32(&c.mpay) == jwt.NoLimit
 	if minLimit(&c.mpay, mPay) && !wasUnlimited {
 		c.Debugf("Max Payload set to %d from server overrides account or user config", opts.MaxPayload)
@@ -1002,7 +1067,9 @@ func (c *client) RegisterUser(user *User) {
 	c.mu.Lock()
 
 	// Assign permissions.
-	if user.Permissions == nil {
+	if user.MonitoringOnly {
+		c.setPermissions(monitoringOnlyPermissions(user.Permissions))
+	} else if user.Permissions == nil {
 		// Reset perms to nil in case client previously had them.
 		c.perms = nil
 		c.mperms = nil
@@ -1040,7 +1107,9 @@ func (c *client) RegisterNkeyUser(user *NkeyUser) error {
 	c.mu.Lock()
 	c.user = user
 	// Assign permissions.
-	if user.Permissions == nil {
+	if user.MonitoringOnly {
+		c.setPermissions(monitoringOnlyPermissions(user.Permissions))
+	} else if user.Permissions == nil {
 		// Reset perms to nil in case client previously had them.
 		c.perms = nil
 		c.mperms = nil
@@ -1058,6 +1127,11 @@ func (c *client) updateDefaultPermissions(perms *Permissions) bool {
 	if c.user == nil || !c.user.defaultPerms {
 		return false
 	}
+	if c.user.MonitoringOnly {
+		c.user.Permissions = perms.clone()
+		c.setPermissions(monitoringOnlyPermissions(perms))
+		return true
+	}
 	if perms == nil {
 		c.user.Permissions = nil
 		c.perms = nil
@@ -1471,6 +1545,9 @@ func (c *client) readLoop(pre []byte) {
 			n, err = reader.Read(b)
 			// If we have any data we will try to parse and exit at the end.
 			if n == 0 && err != nil {
+				if c.kind == LEAF {
+					c.leafCheckFirstInfoTimeout(err)
+				}
 				c.closeConnection(closedStateForErr(err))
 				return
 			}
@@ -1876,6 +1953,10 @@ func (c *client) flushOutbound() bool {
 		close(c.out.stc)
 		c.out.stc = nil
 	}
+	// Re-arm the slow consumer warning once we are back under the threshold.
+	if c.out.slw && c.out.pb <= c.out.slt {
+		c.out.slw = false
+	}
 	// Check if the connection is recovering from being a slow consumer.
 	if !gotWriteTimeout && c.flags.isSet(isSlowConsumer) {
 		c.Noticef("Slow Consumer Recovered: Flush took %.3fs with %d chunks of %d total bytes.", time.Since(start).Seconds(), len(orig), attempted)
@@ -2346,12 +2427,25 @@ func (c *client) processConnect(arg []byte) error {
 			c.ncsUser.Store(c.getAuthUserLabel())
 		}
 		c.mu.Unlock()
+		var accFirstPing bool
 		if acc != nil {
 			acc.mu.RLock()
 			c.ncsAcc.Store(acc.traceLabel())
+			accFirstPing = acc.disableShortFirstPing != nil
 			acc.mu.RUnlock()
 		}
 
+		// The first ping was armed before the account was known, so re-arm
+		// it if the account overrides the short first ping setting.
+		if kind == CLIENT && accFirstPing {
+			c.mu.Lock()
+			if c.ping.out == 0 && !c.isClosed() {
+				c.clearPingTimer()
+				c.setFirstPingTimer()
+			}
+			c.mu.Unlock()
+		}
+
 		// Enable logging connection details and auth info for this client.
 		if c.kind == CLIENT && firstConnect && c.srv != nil {
 			var ncs string
@@ -2407,6 +2501,20 @@ func (c *client) processConnect(arg []byte) error {
 			c.closeConnection(NoRespondersRequiresHeaders)
 			return ErrNoRespondersRequiresHeaders
 		}
+		// Check that the client supports headers if its account requires them.
+		c.mu.Lock()
+		acc, headers := c.acc, c.opts.Headers
+		c.mu.Unlock()
+		if !headers && acc != nil {
+			acc.mu.RLock()
+			required := acc.requireHeaderSupport
+			acc.mu.RUnlock()
+			if required {
+				c.sendErr(ErrAccountRequiresHeaders.Error())
+				c.closeConnection(HeaderSupportRequired)
+				return ErrAccountRequiresHeaders
+			}
+		}
 		if verbose {
 			c.sendOK()
 		}
@@ -2556,6 +2664,17 @@ func (c *client) queueOutbound(data []byte) {
 		return
 	}
 
+	// Give an early warning when getting close to being a slow consumer.
+	if c.kind == CLIENT && c.out.slt > 0 && c.out.pb > c.out.slt && !c.out.slw {
+		c.out.slw = true
+		var accName string
+		if c.acc != nil {
+			accName = c.acc.Name
+		}
+		c.RateLimitWarnf("Slow Consumer Warning: Pending bytes above threshold of %d (max pending %d) for account %q",
+			c.out.slt, c.out.mp, accName)
+	}
+
 	// Check here if we should create a stall channel if we are falling behind.
 	// We do this here since if we wait for consumer's writeLoop it could be
 	// too late with large number of fan in producers.
@@ -2660,6 +2779,7 @@ func (c *client) generateClientInfoJSON(info Info, includeClientIP bool) []byte
 
 func (c *client) sendErr(err string) {
 	c.mu.Lock()
+	c.lastErr = err
 	if c.trace {
 		c.traceOutOp("-ERR", []byte(err))
 	}
@@ -2752,7 +2872,11 @@ func (c *client) processPong() {
 	// compression configuration is s2_auto, check if we should change
 	// the compression level.
 	if c.kind == ROUTER && needsCompression(c.route.compression) {
-		c.updateS2AutoCompressionLevel(&srv.getOpts().Cluster.Compression, &c.route.compression)
+		co := &srv.getOpts().Cluster.Compression
+		c.updateS2AutoCompressionLevel(co, &c.route.compression)
+		if co.Mode == CompressionS2Auto {
+			c.route.compressionRTT = c.rtt
+		}
 	} else if c.kind == LEAF && needsCompression(c.leaf.compression) {
 		var co *CompressionOpts
 		if r := c.leaf.remote; r != nil {
@@ -4309,6 +4433,12 @@ func (c *client) processInboundClientMsg(msg []byte) (bool, bool) {
 		return false, true
 	}
 
+	// Check if the subject has more tokens than allowed.
+	if c.kind == CLIENT && c.mpt > 0 && bytes.Count(c.pa.subject, []byte(tsep)) >= int(c.mpt) {
+		c.maxPubTokensViolation(c.pa.subject)
+		return false, true
+	}
+
 	// Now check for reserved replies. These are used for service imports.
 	if c.kind == CLIENT && len(c.pa.reply) > 0 && isReservedReply(c.pa.reply) {
 		c.replySubjectViolation(c.pa.reply)
@@ -5704,6 +5834,15 @@ func (c *client) maxTokensViolation(sub *subscription) {
 	c.Errorf(logTxt)
 }
 
+func (c *client) maxPubTokensViolation(subject []byte) {
+	errTxt := fmt.Sprintf("Permissions Violation for Publish to %q, too many tokens", subject)
+	if mt, _ := c.isMsgTraceEnabled(); mt != nil {
+		mt.setIngressError(errTxt)
+	}
+	c.sendErr(errTxt)
+	c.Errorf("Publish Violation Too Many Tokens - Subject %q", subject)
+}
+
 func (c *client) processPingTimer() {
 	c.mu.Lock()
 	c.ping.tmr = nil
@@ -6798,6 +6937,12 @@ func (c *client) Errorf(format string, v ...any) {
 }
 
 func (c *client) Debugf(format string, v ...any) {
+	if c.ldebug.Load() {
+		c.srv.executeLogCall(func(logger Logger, format string, v ...any) {
+			logger.Debugf(format, v...)
+		}, c.format(format), v...)
+		return
+	}
 	c.srv.Debugf(c.format(format), v...)
 }
 
@@ -6806,6 +6951,12 @@ func (c *client) Noticef(format string, v ...any) {
 }
 
 func (c *client) Tracef(format string, v ...any) {
+	if c.ltrace.Load() {
+		c.srv.executeLogCall(func(logger Logger, format string, v ...any) {
+			logger.Tracef(format, v...)
+		}, c.format(format), v...)
+		return
+	}
 	c.srv.Tracef(c.format(format), v...)
 }
 
@@ -6883,7 +7034,16 @@ func (c *client) setFirstPingTimer() {
 	if c.isWebsocket() && opts.Websocket.PingInterval > 0 {
 		d = opts.Websocket.PingInterval
 	}
-	if !opts.DisableShortFirstPing {
+	disableShortFirstPing := opts.DisableShortFirstPing
+	// Client connections inherit the server setting unless their account overrides it.
+	if c.kind == CLIENT && c.acc != nil {
+		c.acc.mu.RLock()
+		if c.acc.disableShortFirstPing != nil {
+			disableShortFirstPing = *c.acc.disableShortFirstPing
+		}
+		c.acc.mu.RUnlock()
+	}
+	if !disableShortFirstPing {
 		if c.kind != CLIENT {
 			if d > firstPingInterval {
 				d = firstPingInterval
@@ -257,6 +257,51 @@ func TestClientNoResponderSupport(t *testing.T) {
 	checkPayload(cr, []byte("NATS/1.0 503\r\nNats-Subject: foo\r\n\r\n\r\n"), t)
 }
 
+func TestClientAccountRequiresHeaderSupport(t *testing.T) {
+	conf := createConfFile(t, []byte(`
+		listen: 127.0.0.1:-1
+		accounts {
+			A { require_header_support: true, users = [ { user: "a", pass: "pwd" } ] }
+			B { users = [ { user: "b", pass: "pwd" } ] }
+		}
+	`))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	for _, test := range []struct {
+		user     string
+		headers  bool
+		rejected bool
+	}{
+		{"a", false, true},
+		{"a", true, false},
+		{"b", false, false},
+	} {
+		t.Run(fmt.Sprintf("%s/headers=%v", test.user, test.headers), func(t *testing.T) {
+			c, err := net.Dial("tcp", s.Addr().String())
+			require_NoError(t, err)
+			defer c.Close()
+			cr := bufio.NewReader(c)
+
+			// Wait for INFO...
+			_, _, err = cr.ReadLine()
+			require_NoError(t, err)
+
+			connect := fmt.Sprintf("CONNECT {\"user\":%q,\"pass\":\"pwd\",\"headers\":%v,\"verbose\":false}\r\nPING\r\n", test.user, test.headers)
+			_, err = c.Write([]byte(connect))
+			require_NoError(t, err)
+			c.SetReadDeadline(time.Now().Add(2 * time.Second))
+			line, _, err := cr.ReadLine()
+			require_NoError(t, err)
+			if test.rejected {
+				require_Equal(t, string(line), fmt.Sprintf("-ERR '%s'", ErrAccountRequiresHeaders))
+			} else {
+				require_Equal(t, string(line), "PONG")
+			}
+		})
+	}
+}
+
 func TestServerHeaderSupport(t *testing.T) {
 	opts := defaultServerOptions
 	s := New(&opts)
@@ -2480,6 +2525,62 @@ func TestFlushOutboundNoSliceReuseIfPartial(t *testing.T) {
 	}
 }
 
+func TestClientSlowConsumerLogThreshold(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxPending = 1000
+	opts.SlowConsumerLogThreshold = 100
+	s := &Server{opts: opts}
+	l := &captureWarnLogger{warn: make(chan string, 10)}
+	s.SetLogger(l, false, false)
+
+	fakeConn := &testConnWritePartial{}
+	c := &client{srv: s, nc: fakeConn, acc: NewAccount("ACC")}
+	c.initClient()
+
+	checkWarn := func(expected bool) {
+		t.Helper()
+		select {
+		case w := <-l.warn:
+			if !expected {
+				t.Fatalf("Unexpected warning: %q", w)
+			}
+			if !strings.Contains(w, "Slow Consumer Warning") || !strings.Contains(w, `"ACC"`) {
+				t.Fatalf("Unexpected warning: %q", w)
+			}
+		default:
+			if expected {
+				t.Fatalf("Expected a slow consumer warning")
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.queueOutbound(make([]byte, 50))
+	checkWarn(false)
+	c.queueOutbound(make([]byte, 100))
+	checkWarn(true)
+	// Only warn once while staying above the threshold.
+	c.queueOutbound(make([]byte, 100))
+	checkWarn(false)
+	// Once drained, crossing the threshold again warns again.
+	c.flushOutbound()
+	s.rateLimitLogging.Range(func(k, _ any) bool {
+		s.rateLimitLogging.Delete(k)
+		return true
+	})
+	c.queueOutbound(make([]byte, 150))
+	checkWarn(true)
+	c.mu.Unlock()
+
+	// The threshold needs to be below max pending.
+	opts = DefaultOptions()
+	opts.MaxPending = 1000
+	opts.SlowConsumerLogThreshold = 1000
+	if err := validateOptions(opts); err == nil || !strings.Contains(err.Error(), "slow_consumer_log_threshold") {
+		t.Fatalf("Expected error about slow_consumer_log_threshold, got %v", err)
+	}
+}
+
 type captureNoticeLogger struct {
 	DummyLogger
 	notices []string
@@ -2725,6 +2826,68 @@ func TestClientLimits(t *testing.T) {
 	}
 }
 
+func TestClientMaxSubsUnlimitedSentinel(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		srv     int
+		acc     int
+		maxSubs int
+	}{
+		{"default", 0, 0, 0},
+		{"explicitly unlimited", -1, -1, 0},
+		{"server limit", 2, 0, 2},
+		{"account limit", -1, 2, 2},
+		{"server and account limit", 3, 2, 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			conf := createConfFile(t, []byte(fmt.Sprintf(`
+				listen: "127.0.0.1:-1"
+				max_subscriptions: %d
+				accounts {
+					A {
+						users: [{user: a, password: a}]
+						limits { max_subscriptions: %d }
+					}
+				}
+			`, test.srv, test.acc)))
+			s, o := RunServerWithConfig(conf)
+			defer s.Shutdown()
+			require_Equal(t, o.MaxSubs, test.srv)
+
+			errCh := make(chan error, 1)
+			nc := natsConnect(t, s.ClientURL(), nats.UserInfo("a", "a"),
+				nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+					select {
+					case errCh <- err:
+					default:
+					}
+				}))
+			defer nc.Close()
+
+			// Create more subscriptions than the limit, if any.
+			subs := 10
+			if test.maxSubs > 0 {
+				subs = test.maxSubs + 1
+			}
+			for i := 0; i < subs; i++ {
+				natsSubSync(t, nc, fmt.Sprintf("foo.%d", i))
+			}
+			nc.Flush()
+			select {
+			case err := <-errCh:
+				if test.maxSubs == 0 {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				require_Contains(t, err.Error(), "maximum subscriptions exceeded")
+			case <-time.After(250 * time.Millisecond):
+				if test.maxSubs > 0 {
+					t.Fatalf("Expected maximum subscriptions exceeded error")
+				}
+			}
+		})
+	}
+}
+
 // Must be run with -race.
 func TestClientApplyAccountLimitsSigningKeysRace(t *testing.T) {
 	akp, _ := nkeys.CreateAccount()
@@ -4742,3 +4905,41 @@ func TestClientMsgsMetric(t *testing.T) {
 		t.Fatalf("Did not get expected outClientMsg/Bytes for message sent on qsub")
 	}
 }
+
+func TestTLSClientAllowedSNI(t *testing.T) {
+	conf := createConfFile(t, []byte(`
+		listen: "127.0.0.1:-1"
+		tls {
+			cert_file: "../test/configs/certs/server-cert.pem"
+			key_file:  "../test/configs/certs/server-key.pem"
+			allowed_sni: ["tenant-a.example.com", "Tenant-B.example.com"]
+		}
+	`))
+	s, o := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	connect := func(sni string) error {
+		nc, err := nats.Connect(fmt.Sprintf("tls://127.0.0.1:%d", o.Port),
+			nats.Secure(&tls.Config{ServerName: sni, InsecureSkipVerify: true}),
+			nats.MaxReconnects(0))
+		if err == nil {
+			nc.Close()
+		}
+		return err
+	}
+	require_NoError(t, connect("tenant-a.example.com"))
+	require_NoError(t, connect("tenant-b.example.com"))
+	require_Error(t, connect("tenant-c.example.com"))
+
+	// Empty hostnames are rejected.
+	conf = createConfFile(t, []byte(`
+		tls {
+			cert_file: "../test/configs/certs/server-cert.pem"
+			key_file:  "../test/configs/certs/server-key.pem"
+			allowed_sni: ["tenant-a.example.com", ""]
+		}
+	`))
+	_, err := ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "'allowed_sni' hostnames cannot be empty")
+}
@@ -636,6 +636,177 @@ func TestConfigCheck(t *testing.T) {
 			errorLine: 5,
 			errorPos:  19,
 		},
+		{
+			name: "when consumer_leader_change_grace is negative",
+			config: `
+		consumer_leader_change_grace = "-1s"`,
+			err:       errors.New(`invalid consumer_leader_change_grace of -1s, must not be negative`),
+			errorLine: 2,
+			errorPos:  3,
+		},
+		{
+			name: "when account jetstream max_buffered_msgs is negative",
+			config: `
+		accounts {
+		  A {
+		    jetstream {
+		      max_buffered_msgs = -1
+		    }
+		  }
+		}`,
+			err:       errors.New(`Expected an absolute size for "max_buffered_msgs", got -1`),
+			errorLine: 5,
+			errorPos:  9,
+		},
+		{
+			name: "when jetstream max_consumer_name_len exceeds the hard limit",
+			config: `
+		jetstream {
+		  limits {
+		    max_consumer_name_len = 256
+		  }
+		}`,
+			err:       errors.New(`max_consumer_name_len must be between 0 and 255, got 256`),
+			errorLine: 4,
+			errorPos:  7,
+		},
+		{
+			name: "when jetstream max_consumer_pending_bytes is negative",
+			config: `
+		jetstream {
+		  limits {
+		    max_consumer_pending_bytes = -1
+		  }
+		}`,
+			err:       errors.New(`max_consumer_pending_bytes must be positive, got -1`),
+			errorLine: 4,
+			errorPos:  7,
+		},
+		{
+			name: "when jetstream max_stream_subjects is negative",
+			config: `
+		jetstream {
+		  limits {
+		    max_stream_subjects = -1
+		  }
+		}`,
+			err:       errors.New(`max_stream_subjects must be positive, got -1`),
+			errorLine: 4,
+			errorPos:  7,
+		},
+		{
+			name: "when jetstream default_flow_control_heartbeat is too low",
+			config: `
+		jetstream {
+		  default_flow_control_heartbeat = "10ms"
+		}`,
+			err:       errors.New(`default_flow_control_heartbeat must be at least 100ms, got 10ms`),
+			errorLine: 3,
+			errorPos:  5,
+		},
+		{
+			name: "when jetstream ephemeral_delete_jitter is negative",
+			config: `
+		jetstream {
+		  ephemeral_delete_jitter = "-1s"
+		}`,
+			err:       errors.New(`ephemeral_delete_jitter can not be negative, got -1s`),
+			errorLine: 3,
+			errorPos:  5,
+		},
+		{
+			name: "when jetstream on_corrupt_recovery is invalid",
+			config: `
+		jetstream {
+		  on_corrupt_recovery = "ignore"
+		}`,
+			err:       errors.New(`on_corrupt_recovery must be one of "fail", "skip" or "quarantine", got "ignore"`),
+			errorLine: 3,
+			errorPos:  5,
+		},
+		{
+			name: "when leafnode remote exceeds max_urls_per_remote",
+			config: `
+		leafnodes {
+		  max_urls_per_remote: 1
+		  remotes [
+		    {
+		      urls: ["nats://127.0.0.1:1", "nats://127.0.0.1:2"]
+		    }
+		  ]
+		}`,
+			err:       errors.New(`remote leafnode has 2 urls, exceeding max_urls_per_remote of 1`),
+			errorLine: 7,
+			errorPos:  8,
+		},
+		{
+			name: "when leafnode max_urls_per_remote is negative",
+			config: `
+		leafnodes {
+		  max_urls_per_remote: -1
+		}`,
+			err:       errors.New(`max_urls_per_remote can not be negative, got -1`),
+			errorLine: 3,
+			errorPos:  5,
+		},
+		{
+			name: "when resolver max_active_accounts is negative",
+			config: `
+		resolver {
+		  type: full
+		  dir: "."
+		  max_active_accounts: -1
+		}`,
+			err:       errors.New(`resolver max_active_accounts should be a positive integer, got -1`),
+			errorLine: 5,
+			errorPos:  5,
+		},
+		{
+			name: "when jetstream key_file does not exist",
+			config: `
+		jetstream {
+		  key_file = "/nonexistent/js.key"
+		}`,
+			err:       errors.New(`error reading jetstream encryption key_file: open /nonexistent/js.key: no such file or directory`),
+			errorLine: 3,
+			errorPos:  5,
+		},
+		{
+			name: "when account disable_short_first_ping is not a boolean",
+			config: `
+		accounts {
+		  A {
+		    disable_short_first_ping = "yes"
+		  }
+		}`,
+			err:       errors.New(`Expected disable_short_first_ping to be a boolean, got string`),
+			errorLine: 4,
+			errorPos:  7,
+		},
+		{
+			name: "when account require_header_support is not a boolean",
+			config: `
+		accounts {
+		  A {
+		    require_header_support = "yes"
+		  }
+		}`,
+			err:       errors.New(`Expected require_header_support to be a boolean, got string`),
+			errorLine: 4,
+			errorPos:  7,
+		},
+		{
+			name: "when account allow_push_consumers is not a boolean",
+			config: `
+		accounts {
+		  A {
+		    allow_push_consumers = "no"
+		  }
+		}`,
+			err:       errors.New(`Expected allow_push_consumers to be a boolean, got string`),
+			errorLine: 4,
+			errorPos:  7,
+		},
 		{
 			name: "when accounts has a referenced config variable within same block",
 			config: `
@@ -1359,6 +1530,18 @@ func TestConfigCheck(t *testing.T) {
 			errorLine: 4,
 			errorPos:  18,
 		},
+		{
+			name: "when leafnode remote deny_publish has an invalid subject",
+			config: `
+		leafnodes {
+		  remotes: [
+		    { url: "nats://127.0.0.1:7422", deny_publish: "foo..bar" }
+		  ]
+		}`,
+			err:       errors.New(`subject "foo..bar" is not a valid subject`),
+			errorLine: 4,
+			errorPos:  39,
+		},
 		{
 			name: "when leafnode min_version is wrong type",
 			config: `
@@ -2506,6 +2689,63 @@ func TestConfigCheck(t *testing.T) {
 			errorLine: 6,
 			errorPos:  8,
 		},
+		{
+			name: "when accounts_required is not an array",
+			config: `
+				accounts_required: "A"
+			`,
+			err:       errors.New("error parsing accounts_required: expected an array, got string"),
+			errorLine: 2,
+			errorPos:  5,
+		},
+		{
+			name: "when accounts_required has an empty entry",
+			config: `
+				accounts_required: ["A", ""]
+			`,
+			err:       errors.New("error parsing accounts_required: each entry must be a non-empty account name"),
+			errorLine: 2,
+			errorPos:  31,
+		},
+		{
+			name: "when gateway allowed_unknown_clusters is not an array",
+			config: `
+				gateway {
+					name: "A"
+					reject_unknown_cluster: true
+					allowed_unknown_clusters: "B"
+				}
+			`,
+			err:       errors.New("error parsing allowed_unknown_clusters: expected an array of strings, got string"),
+			errorLine: 5,
+			errorPos:  6,
+		},
+		{
+			name: "when tls account_mapping uses an unsupported attribute",
+			config: `
+				tls {
+					account_mapping { from: "SERIAL" }
+				}
+			`,
+			err:       errors.New(`error parsing tls config, unsupported 'account_mapping' certificate attribute "SERIAL"`),
+			errorLine: 3,
+			errorPos:  24,
+		},
+		{
+			name: "when tls account_mapping is used without verify_and_map",
+			config: `
+				tls {
+					cert_file: "../test/configs/certs/tlsauth/server.pem"
+					key_file: "../test/configs/certs/tlsauth/server-key.pem"
+					ca_file: "../test/configs/certs/tlsauth/ca.pem"
+					verify: true
+					account_mapping { from: "OU" }
+				}
+			`,
+			err:       errors.New("tls 'account_mapping' requires 'verify_and_map' to be enabled"),
+			errorLine: 2,
+			errorPos:  5,
+		},
 	}
 
 	checkConfig := func(config string) error {
@@ -15,10 +15,12 @@ package server
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/rand"
 	"os"
@@ -31,6 +33,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/nats-io/nats-server/v2/server/avl"
 	"github.com/nats-io/nats-server/v2/server/gsl"
@@ -45,6 +49,18 @@ const (
 	JSPullRequestNatsPinId    = "Nats-Pin-Id"
 )
 
+// JSPullRequestPinPriority is the header a pull request can set with a priority hint,
+// used to steal the pin when the consumer's PinStealPolicy is allow_higher_priority.
+const JSPullRequestPinPriority = "Nats-Pin-Priority"
+
+// JSConsumerGap is the header added to deliveries of consumers with AnnounceGaps set, holding
+// the number of stream sequences passed over since the previous delivered message.
+const JSConsumerGap = "Nats-Consumer-Gap"
+
+// JSConsumerPauseUntil is the header holding the time a paused consumer resumes, sent to
+// waiting pull requests of consumers with ExpireRequestsOnPause set.
+const JSConsumerPauseUntil = "Nats-Pause-Until"
+
 var (
 	validGroupName = regexp.MustCompile(`^[a-zA-Z0-9/_=-]{1,16}$`)
 )
@@ -65,11 +81,30 @@ type ConsumerInfo struct {
 	NumPending     uint64          `json:"num_pending"`
 	Cluster        *ClusterInfo    `json:"cluster,omitempty"`
 	PushBound      bool            `json:"push_bound,omitempty"`
+	CaughtUp       bool            `json:"caught_up,omitempty"` // Set once the caught up status message was sent.
 	Paused         bool            `json:"paused,omitempty"`
 	PauseRemaining time.Duration   `json:"pause_remaining,omitempty"`
+	// RedeliveryQuiet is set while inside one of the configured redelivery quiet windows.
+	RedeliveryQuiet          bool          `json:"redelivery_quiet,omitempty"`
+	RedeliveryQuietRemaining time.Duration `json:"redelivery_quiet_remaining,omitempty"`
+	// DeliveryQuotaRemaining is only set when a delivery quota is configured.
+	DeliveryQuotaRemaining *DeliveryQuotaRemaining `json:"delivery_quota_remaining,omitempty"`
 	// TimeStamp indicates when the info was gathered
 	TimeStamp      time.Time            `json:"ts"`
 	PriorityGroups []PriorityGroupState `json:"priority_groups,omitempty"`
+	// DefaultedFields names the config fields that were filled in by the server,
+	// only included when requested with JSApiConsumerInfoRequest.DefaultedFields.
+	// A standalone server does not persist these, so they are empty after a restart
+	// until the consumer is updated again.
+	DefaultedFields []string `json:"defaulted_fields,omitempty"`
+	// PendingAckBytes is the approximate memory used by pending acks and redeliveries,
+	// only set when the server limits it with max_consumer_pending_bytes.
+	PendingAckBytes int64 `json:"pending_ack_bytes,omitempty"`
+	// QuorumDeliveryLatency is only set for replicated consumers, R1 consumers leave it empty.
+	QuorumDeliveryLatency *QuorumDeliveryLatency `json:"quorum_delivery_latency,omitempty"`
+	// NumSkipped is the number of messages skipped due to the configured skip headers,
+	// since the consumer was last created or became leader on this server.
+	NumSkipped uint64 `json:"num_skipped,omitempty"`
 }
 
 // consumerInfoClusterResponse is a response used in a cluster to communicate the consumer info
@@ -110,11 +145,17 @@ type ConsumerConfig struct {
 	MaxRequestBatch    int           `json:"max_batch,omitempty"`
 	MaxRequestExpires  time.Duration `json:"max_expires,omitempty"`
 	MaxRequestMaxBytes int           `json:"max_bytes,omitempty"`
+	// WaitingRequestIdleTimeout reaps waiting pull requests that had no activity for this long.
+	// Zero means waiting requests are only removed based on their own expiration.
+	WaitingRequestIdleTimeout time.Duration `json:"waiting_request_idle_timeout,omitempty"`
 
 	// Push based consumers.
 	DeliverSubject string        `json:"deliver_subject,omitempty"`
 	DeliverGroup   string        `json:"deliver_group,omitempty"`
 	Heartbeat      time.Duration `json:"idle_heartbeat,omitempty"`
+	// SignalCaughtUp delivers a "100 Caught Up" status message the first time
+	// the consumer has no more pending messages after creation.
+	SignalCaughtUp bool `json:"signal_caught_up,omitempty"`
 
 	// Ephemeral inactivity threshold.
 	InactiveThreshold time.Duration `json:"inactive_threshold,omitempty"`
@@ -138,6 +179,146 @@ type ConsumerConfig struct {
 	PriorityGroups []string       `json:"priority_groups,omitempty"`
 	PriorityPolicy PriorityPolicy `json:"priority_policy,omitempty"`
 	PinnedTTL      time.Duration  `json:"priority_timeout,omitempty"`
+	PinStealPolicy PinStealPolicy `json:"pin_steal_policy,omitempty"`
+
+	// AckWaitPerFilter overrides AckWait for messages matching one of the consumer's filter subjects.
+	AckWaitPerFilter map[string]time.Duration `json:"ack_wait_per_filter,omitempty"`
+
+	// DeliverTrace enables publishing of delivery decision events, used
+	// to debug why a consumer is or is not delivering messages.
+	DeliverTrace bool `json:"deliver_trace,omitempty"`
+
+	// RedeliveryQuietWindows defers redeliveries that would fall inside a window until it closes.
+	RedeliveryQuietWindows []RedeliveryQuietWindow `json:"redelivery_quiet_windows,omitempty"`
+
+	// DeliveryQuotaBytes and DeliveryQuotaMsgs pause the consumer once that many bytes or
+	// messages have been delivered, until resumed. Zero means no quota.
+	DeliveryQuotaBytes int64 `json:"delivery_quota_bytes,omitempty"`
+	DeliveryQuotaMsgs  int64 `json:"delivery_quota_msgs,omitempty"`
+
+	// ReplaySpeed scales the original timing when replaying with ReplayOriginal,
+	// 2.0 replays twice as fast and 0.5 at half speed. Zero means 1.0, the exact timing.
+	ReplaySpeed float64 `json:"replay_speed,omitempty"`
+
+	// SubjectAck additionally accepts acks on the stable subject $JS.ACK.<stream>.<consumer>,
+	// with the stream sequence of the message as the payload. See JSConsumerSubjectAckT.
+	SubjectAck bool `json:"subject_ack,omitempty"`
+
+	// NoRedeliverSubjects are subjects, within the consumer's filters, for which messages are
+	// never redelivered. They are terminated instead when nak'd or when their ack wait expires.
+	NoRedeliverSubjects []string `json:"no_redeliver_subjects,omitempty"`
+
+	// AckBatchSize and AckBatchWindow let a single ack cover a batch with AckExplicit.
+	// An ack also acks the pending messages delivered before it, up to AckBatchSize
+	// messages in total and/or within AckBatchWindow of its delivery. Zero means per-message acks.
+	AckBatchSize   int           `json:"ack_batch_size,omitempty"`
+	AckBatchWindow time.Duration `json:"ack_batch_window,omitempty"`
+
+	// MaxAckAge terminates messages still pending ack this long after their first
+	// delivery, instead of redelivering them, regardless of MaxDeliver.
+	MaxAckAge time.Duration `json:"max_ack_age,omitempty"`
+
+	// OrderByTimestamp delivers the messages of a pull consumer ordered by their publish
+	// timestamp, taken from the Nats-Time-Stamp header when present, instead of by stream
+	// sequence. Messages are gathered and sorted up to MaxRequestBatch at a time, so the
+	// ordering only holds within such a batch. This costs holding the batch in memory and
+	// sorting it, and adds latency since a batch is gathered before the first delivery.
+	OrderByTimestamp bool `json:"order_by_timestamp,omitempty"`
+
+	// DeadLetterSubject is where messages are republished, in the same account, once they
+	// exhausted MaxDeliver and before being terminated. The original headers and payload are
+	// kept, with the original stream, subject and sequence added as headers.
+	DeadLetterSubject string `json:"dead_letter_subject,omitempty"`
+
+	// ExcludeSubjects are subjects of messages not to deliver, even though they match the
+	// filter subjects, e.g. "orders.>" except for "orders.internal.>". Excluded messages are
+	// skipped while looking for the next message to deliver.
+	ExcludeSubjects []string `json:"exclude_subjects,omitempty"`
+
+	// SkipHeaders skips messages carrying any of these header values, e.g. "Nats-Skip": "true".
+	// Skipped messages count as processed: they are never delivered, are not waited on for an
+	// ack, and are considered acknowledged by this consumer for interest and work queue streams.
+	SkipHeaders map[string]string `json:"skip_headers,omitempty"`
+
+	// AckSameConnection only accepts acks received on the same connection as the pull request
+	// the message was delivered for, acks from other connections are ignored. For clients
+	// connected to other servers, this is the route or gateway the request was received on.
+	AckSameConnection bool `json:"ack_same_connection,omitempty"`
+
+	// AnnounceGaps adds the JSConsumerGap header to new deliveries that follow stream sequences
+	// the consumer passed over, e.g. deleted, filtered or skipped messages, with their count.
+	AnnounceGaps bool `json:"announce_gaps,omitempty"`
+
+	// ExpireRequestsOnPause responds to all waiting pull requests when the consumer is paused,
+	// with a status holding the JSConsumerPauseUntil header, instead of letting them expire.
+	ExpireRequestsOnPause bool `json:"expire_requests_on_pause,omitempty"`
+
+	// DeliveryReceiptSubject is where a ConsumerDeliveryReceipt is published, in the same account,
+	// for the percentage of deliveries given by DeliveryReceiptSampling, between 1 and 100.
+	DeliveryReceiptSubject  string `json:"delivery_receipt_subject,omitempty"`
+	DeliveryReceiptSampling int    `json:"delivery_receipt_sampling,omitempty"`
+
+	// DeliveryConcurrency is experimental. When above 1, deliveries of a push consumer are
+	// dispatched to that many delivery lanes, each with its own goroutine, by hashing the
+	// message subject. Messages with the same subject are delivered in stream order, but
+	// messages with different subjects can be delivered out of order with respect to each
+	// other, and to heartbeats. Not supported with flow control. Zero or one delivers serially.
+	DeliveryConcurrency int `json:"delivery_concurrency,omitempty"`
+}
+
+// ConsumerDeliveryReceipt is published to the DeliveryReceiptSubject of a consumer for sampled deliveries.
+type ConsumerDeliveryReceipt struct {
+	Stream      string    `json:"stream"`
+	Consumer    string    `json:"consumer"`
+	StreamSeq   uint64    `json:"stream_seq"`
+	ConsumerSeq uint64    `json:"consumer_seq"`
+	Deliveries  uint64    `json:"deliveries"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// DeliveryQuotaRemaining is the remaining delivery quota of a consumer.
+type DeliveryQuotaRemaining struct {
+	Bytes int64 `json:"bytes,omitempty"`
+	Msgs  int64 `json:"msgs,omitempty"`
+}
+
+// QuorumDeliveryLatency reports how long messages of a replicated consumer were held
+// back before delivery, waiting for the delivered state to reach quorum. Average and
+// Max are computed over the most recent Samples, up to quorumLatencySamples.
+type QuorumDeliveryLatency struct {
+	Samples int           `json:"samples"`
+	Average time.Duration `json:"average"`
+	Max     time.Duration `json:"max"`
+}
+
+// Number of quorum delivery latency samples to keep for reporting.
+const quorumLatencySamples = 128
+
+// RedeliveryQuietWindow is a period of time during which no redeliveries are made.
+type RedeliveryQuietWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Returns the delay to wait before replaying a message stored at ts, given the
+// previous message was stored at lts, scaled by the configured replay speed.
+// Lock should be held.
+func (o *consumer) replayDelay(ts, lts int64) time.Duration {
+	delay := time.Duration(ts - lts)
+	if o.cfg.ReplaySpeed > 0 {
+		delay = time.Duration(float64(delay) / o.cfg.ReplaySpeed)
+	}
+	return delay
+}
+
+// Returns the end of the redelivery quiet window active at the given time, or zero if none is active.
+func quietWindowEnd(windows []RedeliveryQuietWindow, now time.Time) time.Time {
+	for _, w := range windows {
+		if !now.Before(w.Start) && now.Before(w.End) {
+			return w.End
+		}
+	}
+	return time.Time{}
 }
 
 // SequenceInfo has both the consumer and the stream sequence and last activity.
@@ -152,6 +333,11 @@ type CreateConsumerRequest struct {
 	Config   ConsumerConfig `json:"config"`
 	Action   ConsumerAction `json:"action"`
 	Pedantic bool           `json:"pedantic,omitempty"`
+	// AllowUnmatchedFilter skips checking that the filter subjects overlap the stream's subjects,
+	// for streams whose subjects will be added later.
+	AllowUnmatchedFilter bool `json:"allow_unmatched_filter,omitempty"`
+	// Force allows an update to lower MaxAckPending below the current number of pending acks.
+	Force bool `json:"force,omitempty"`
 }
 
 type ConsumerAction int
@@ -290,6 +476,58 @@ func (pp *PriorityPolicy) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// PinStealPolicy determines whether the pin of a pinned client can be taken over by another client.
+type PinStealPolicy int
+
+const (
+	// The pin can not be stolen, requests with a stale or missing pin id wait or are rejected.
+	PinStealDeny PinStealPolicy = iota
+	// A request with a higher pin priority hint than the pinned client takes over the pin.
+	PinStealAllowHigherPriority
+)
+
+const (
+	PinStealDenyJSONString                = `"deny"`
+	PinStealAllowHigherPriorityJSONString = `"allow_higher_priority"`
+)
+
+var (
+	PinStealDenyJSONBytes                = []byte(PinStealDenyJSONString)
+	PinStealAllowHigherPriorityJSONBytes = []byte(PinStealAllowHigherPriorityJSONString)
+)
+
+func (sp PinStealPolicy) String() string {
+	switch sp {
+	case PinStealAllowHigherPriority:
+		return PinStealAllowHigherPriorityJSONString
+	default:
+		return PinStealDenyJSONString
+	}
+}
+
+func (sp PinStealPolicy) MarshalJSON() ([]byte, error) {
+	switch sp {
+	case PinStealAllowHigherPriority:
+		return PinStealAllowHigherPriorityJSONBytes, nil
+	case PinStealDeny:
+		return PinStealDenyJSONBytes, nil
+	default:
+		return nil, fmt.Errorf("unknown pin steal policy: %v", sp)
+	}
+}
+
+func (sp *PinStealPolicy) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case PinStealAllowHigherPriorityJSONString:
+		*sp = PinStealAllowHigherPriority
+	case PinStealDenyJSONString:
+		*sp = PinStealDeny
+	default:
+		return fmt.Errorf("unknown pin steal policy: %v", string(data))
+	}
+	return nil
+}
+
 // DeliverPolicy determines how the consumer should select the first message to deliver.
 type DeliverPolicy int
 
@@ -396,6 +634,30 @@ const (
 	// reasons to supply when terminating messages using limits
 	ackTermLimitsReason        = "Message deleted by stream limits"
 	ackTermUnackedLimitsReason = "Unacknowledged message was deleted"
+	// reason to supply when terminating messages instead of redelivering them
+	ackTermNoRedeliverReason = "Redelivery disabled for message subject"
+	// reason to supply when terminating messages pending ack for longer than MaxAckAge
+	ackTermMaxAckAgeReason = "Message exceeded max ack age"
+	// maximum length of a reason supplied by a client with +TERM
+	maxAckTermReasonLen = 256
+)
+
+// Reasons reported in delivery trace events.
+const (
+	deliverTraceDelivered      = "delivered"
+	deliverTracePaused         = "paused"
+	deliverTraceNoInterest     = "no interest"
+	deliverTraceFlowControl    = "flow control"
+	deliverTraceNoPullRequests = "no pull requests"
+	deliverTraceNoRequestFit   = "no pull request can fit message"
+	deliverTraceMaxAckPending  = "max ack pending"
+	deliverTraceNoMessages     = "no messages"
+	deliverTraceLookupError    = "message lookup error"
+	deliverTraceRateLimited    = "rate limited"
+	deliverTraceReplayDelay    = "replay delay"
+
+	// Maximum number of delivery trace events per second for a consumer.
+	deliverTraceMaxRate = 100
 )
 
 // Calculate accurate replicas for the consumer config with the parent stream config.
@@ -430,6 +692,8 @@ type consumer struct {
 	sseq              uint64             // next stream sequence
 	subjf             subjectFilters     // subject filters and their sequences
 	filters           *gsl.SimpleSublist // When we have multiple filters we will use LoadNextMsgMulti and pass this in.
+	excludes          *gsl.SimpleSublist // Subjects excluded from the filters, if any.
+	nskipped          uint64             // Number of messages skipped due to their headers.
 	dseq              uint64             // delivered consumer sequence
 	adflr             uint64             // ack delivery floor
 	asflr             uint64             // ack store floor
@@ -448,6 +712,8 @@ type consumer struct {
 	ackSub            *subscription
 	ackReplyT         string
 	ackSubj           string
+	sackSub           *subscription
+	sackSubj          string
 	fcPreOld          string
 	fcSubjOld         string
 	fcPre             string
@@ -456,6 +722,7 @@ type consumer struct {
 	nextMsgReqs       *ipQueue[*nextMsgReq]
 	resetSubj         string
 	maxp              int
+	maxpab            int64
 	pblimit           int
 	maxpb             int
 	pbytes            int
@@ -465,14 +732,28 @@ type consumer struct {
 	fcSub             *subscription
 	outq              *jsOutQ
 	pending           map[uint64]*Pending
+	pawt              map[uint64]time.Duration // Ack wait of pending messages when AckWaitPerFilter is used.
+	pfd               map[uint64]int64         // First delivery time of pending messages when MaxAckAge is used.
+	pconn             map[uint64]uint64        // Connection pending messages were delivered for when AckSameConnection is used.
+	gap               uint64                   // Stream sequences passed over since the last delivery, when AnnounceGaps is used.
+	dflt              []string                 // Config fields that were defaulted by the server.
+	caughtUp          bool                     // Whether the caught up status message was sent.
+	qbytes            int64                    // Bytes delivered since the delivery quota was last cleared.
+	qmsgs             int64                    // Messages delivered since the delivery quota was last cleared.
+	qpaused           bool                     // Whether paused due to reaching the delivery quota.
 	ptmr              *time.Timer
 	ptmrEnd           time.Time
 	rdq               []uint64
 	rdqi              avl.SequenceSet
 	rdc               map[uint64]uint64
+	tsq               []*tsOrderedMsg // Gathered batch ordered by timestamp, with OrderByTimestamp.
 	replies           map[uint64]string
 	pendingDeliveries map[uint64]*jsPubMsg        // Messages that can be delivered after achieving quorum.
 	waitingDeliveries map[string]*waitingDelivery // (Optional) request timeout messages that need to wait for replicated deliveries first.
+	pdts              map[uint64]int64            // Time at which pending deliveries were queued, waiting for quorum.
+	qdl               [quorumLatencySamples]time.Duration
+	qdli              int
+	qdln              int
 	maxdc             uint64
 	waiting           *waitQueue
 	cfg               ConsumerConfig
@@ -485,6 +766,7 @@ type consumer struct {
 	uptmr             *time.Timer // Unpause timer
 	gwdtmr            *time.Timer
 	dthresh           time.Duration
+	dgrace            time.Time     // Deletion for inactivity is deferred until then after a leader change.
 	mch               chan struct{} // Message channel
 	qch               chan struct{} // Quit channel
 	mqch              chan struct{} // The monitor's quit channel.
@@ -537,10 +819,19 @@ type consumer struct {
 	/// pinnedTtl is the remaining time before the current PinId expires.
 	pinnedTtl *time.Timer
 	pinnedTS  time.Time
+	// pinPri is the pin priority hint of the current pinned client.
+	pinPri int64
 
 	// If standalone/single-server, the offline reason needs to be stored directly in the consumer.
 	// Otherwise, if clustered it will be part of the consumer assignment.
 	offlineReason string
+
+	// Delivery trace, when enabled through DeliverTrace.
+	trlimit *rate.Limiter
+	ltrace  string
+
+	// Delivery lanes, when delivering concurrently through DeliveryConcurrency.
+	dlanes []*jsOutQ
 }
 
 // A single subject filter.
@@ -573,6 +864,9 @@ const (
 	// JsDeleteWaitTimeDefault is the default amount of time we will wait for non-durable
 	// consumers to be in an inactive state before deleting them.
 	JsDeleteWaitTimeDefault = 5 * time.Second
+	// JsDeleteJitterDefault is the default maximum jitter added to the inactive threshold of
+	// ephemeral and pull consumers, to avoid deleting many of them at the same time.
+	JsDeleteJitterDefault = time.Second
 	// JsFlowControlMaxPending specifies default pending bytes during flow control that can be outstanding.
 	JsFlowControlMaxPending = 32 * 1024 * 1024
 	// JsDefaultMaxAckPending is set for consumers with explicit ack that do not set the max ack pending.
@@ -580,10 +874,21 @@ const (
 	// JsDefaultPinnedTTL is the default grace period for the pinned consumer to send a new request before a new pin
 	// is picked by a server.
 	JsDefaultPinnedTTL = 2 * time.Minute
+	// JsMinFlowControlHeartbeat is the minimum configurable default heartbeat for flow controlled consumers.
+	JsMinFlowControlHeartbeat = 100 * time.Millisecond
+	// JsMaxDeliveryConcurrency is the maximum number of delivery lanes of a consumer.
+	JsMaxDeliveryConcurrency = 64
 )
 
 // Helper function to set consumer config defaults from above.
 func setConsumerConfigDefaults(config *ConsumerConfig, streamCfg *StreamConfig, lim *JSLimitOpts, accLim *JetStreamAccountLimits, pedantic bool) *ApiError {
+	// Use the stream's default for MaxDeliver if set, only applicable for consumers that redeliver.
+	if config.MaxDeliver == 0 && streamCfg.ConsumerLimits.DefaultMaxDeliver != 0 && (config.AckPolicy == AckExplicit || config.AckPolicy == AckAll) {
+		if pedantic {
+			return NewJSPedanticError(errors.New("max_deliver must be set if a default is configured in stream limits"))
+		}
+		config.MaxDeliver = streamCfg.ConsumerLimits.DefaultMaxDeliver
+	}
 	// Setup default of -1, meaning no limit for MaxDeliver.
 	if config.MaxDeliver == 0 || config.MaxDeliver < -1 {
 		if pedantic && config.MaxDeliver < -1 {
@@ -645,6 +950,10 @@ func setConsumerConfigDefaults(config *ConsumerConfig, streamCfg *StreamConfig,
 	if config.DeliverSubject == _EMPTY_ && config.MaxWaiting == 0 {
 		config.MaxWaiting = JSWaitQueueDefaultMax
 	}
+	// An empty per filter ack wait is the same as not having one.
+	if len(config.AckWaitPerFilter) == 0 {
+		config.AckWaitPerFilter = nil
+	}
 	// Setup proper default for ack wait if we are in explicit ack mode.
 	if config.AckWait == 0 && (config.AckPolicy == AckExplicit || config.AckPolicy == AckAll) {
 		config.AckWait = JsAckWaitDefault
@@ -670,14 +979,7 @@ func setConsumerConfigDefaults(config *ConsumerConfig, streamCfg *StreamConfig,
 	}
 	// Set proper default for max ack pending if we are ack explicit and none has been set.
 	if config.MaxAckPending == 0 && config.AckPolicy != AckNone {
-		ackPending := JsDefaultMaxAckPending
-		if lim.MaxAckPending > 0 && lim.MaxAckPending < ackPending {
-			ackPending = lim.MaxAckPending
-		}
-		if accLim.MaxAckPending > 0 && accLim.MaxAckPending < ackPending {
-			ackPending = accLim.MaxAckPending
-		}
-		config.MaxAckPending = ackPending
+		config.MaxAckPending = defaultMaxAckPending(lim, accLim)
 	}
 	// if applicable set max request batch size
 	if config.DeliverSubject == _EMPTY_ && config.MaxRequestBatch == 0 && lim.MaxRequestBatch > 0 {
@@ -687,6 +989,14 @@ func setConsumerConfigDefaults(config *ConsumerConfig, streamCfg *StreamConfig,
 		config.MaxRequestBatch = lim.MaxRequestBatch
 	}
 
+	// Flow control requires heartbeats, use the configured default if not set.
+	if config.FlowControl && config.Heartbeat == 0 && lim.FlowControlHeartbeat > 0 {
+		if pedantic {
+			return NewJSPedanticError(errors.New("idle_heartbeat must be set if flow control is enabled"))
+		}
+		config.Heartbeat = lim.FlowControlHeartbeat
+	}
+
 	// set the default value only if pinned policy is used.
 	if config.PriorityPolicy == PriorityPinnedClient && config.PinnedTTL == 0 {
 		config.PinnedTTL = JsDefaultPinnedTTL
@@ -700,14 +1010,103 @@ func setConsumerConfigDefaults(config *ConsumerConfig, streamCfg *StreamConfig,
 	return nil
 }
 
+// setConsumerConfigDefaultsWithFields is like setConsumerConfigDefaults but also returns
+// the names of the fields that were filled in or adjusted by the server.
+func setConsumerConfigDefaultsWithFields(config *ConsumerConfig, streamCfg *StreamConfig, lim *JSLimitOpts, accLim *JetStreamAccountLimits, pedantic bool) ([]string, *ApiError) {
+	orig := *config
+	if err := setConsumerConfigDefaults(config, streamCfg, lim, accLim, pedantic); err != nil {
+		return nil, err
+	}
+	return consumerDefaultedFields(&orig, config), nil
+}
+
+// consumerDefaultedFields returns the JSON names of the fields that
+// differ between the requested and the defaulted consumer config.
+func consumerDefaultedFields(orig, cfg *ConsumerConfig) []string {
+	var fields []string
+	add := func(changed bool, name string) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	add(orig.AckWait != cfg.AckWait, "ack_wait")
+	add(orig.MaxDeliver != cfg.MaxDeliver, "max_deliver")
+	add(orig.MaxWaiting != cfg.MaxWaiting, "max_waiting")
+	add(orig.MaxAckPending != cfg.MaxAckPending, "max_ack_pending")
+	add(orig.FlowControl != cfg.FlowControl, "flow_control")
+	add(orig.MaxRequestBatch != cfg.MaxRequestBatch, "max_batch")
+	add(orig.MaxRequestExpires != cfg.MaxRequestExpires, "max_expires")
+	add(orig.MaxRequestMaxBytes != cfg.MaxRequestMaxBytes, "max_bytes")
+	add(orig.Heartbeat != cfg.Heartbeat, "idle_heartbeat")
+	add(orig.InactiveThreshold != cfg.InactiveThreshold, "inactive_threshold")
+	add(orig.PinnedTTL != cfg.PinnedTTL, "priority_timeout")
+	return fields
+}
+
+// defaultMaxAckPending returns the default max ack pending, capped by the server and account limits.
+func defaultMaxAckPending(lim *JSLimitOpts, accLim *JetStreamAccountLimits) int {
+	ackPending := JsDefaultMaxAckPending
+	if lim.MaxAckPending > 0 && lim.MaxAckPending < ackPending {
+		ackPending = lim.MaxAckPending
+	}
+	if accLim.MaxAckPending > 0 && accLim.MaxAckPending < ackPending {
+		ackPending = accLim.MaxAckPending
+	}
+	return ackPending
+}
+
+// consumerDefaultedWarnings describes the adjustments the server made to a
+// consumer config, given the defaulted field names, the resulting config and
+// if max ack pending was capped by the JetStream limits.
+func consumerDefaultedWarnings(fields []string, cfg *ConsumerConfig, maxAckPendingCapped bool) []string {
+	var warnings []string
+	for _, field := range fields {
+		var value any
+		switch field {
+		case "ack_wait":
+			value = cfg.AckWait
+		case "max_deliver":
+			value = cfg.MaxDeliver
+		case "max_waiting":
+			value = cfg.MaxWaiting
+		case "max_ack_pending":
+			if maxAckPendingCapped {
+				warnings = append(warnings, fmt.Sprintf("max_ack_pending set to %d by the server, capped by JetStream limits", cfg.MaxAckPending))
+				continue
+			}
+			value = cfg.MaxAckPending
+		case "flow_control":
+			value = cfg.FlowControl
+		case "max_batch":
+			value = cfg.MaxRequestBatch
+		case "max_expires":
+			value = cfg.MaxRequestExpires
+		case "max_bytes":
+			value = cfg.MaxRequestMaxBytes
+		case "idle_heartbeat":
+			value = cfg.Heartbeat
+		case "inactive_threshold":
+			value = cfg.InactiveThreshold
+		case "priority_timeout":
+			value = cfg.PinnedTTL
+		default:
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s set to %v by the server", field, value))
+	}
+	return warnings
+}
+
 // Check the consumer config. If we are recovering don't check filter subjects.
+// When checkFilterOverlap is set, each filter subject needs to overlap one of the stream's subjects.
 func checkConsumerCfg(
 	config *ConsumerConfig,
 	srvLim *JSLimitOpts,
 	cfg *StreamConfig,
-	_ *Account,
+	acc *Account,
 	accLim *JetStreamAccountLimits,
 	isRecovering bool,
+	checkFilterOverlap bool,
 ) *ApiError {
 
 	if config.Name != _EMPTY_ && !isValidAssetName(config.Name) {
@@ -717,6 +1116,35 @@ func checkConsumerCfg(
 		return NewJSStreamInvalidConfigError(errors.New("consumer durable name can not contain '.', '*', '>', '\\', '/'"))
 	}
 
+	// The server may be configured with tighter limits than the hard ones, those
+	// are not enforced on recovery so existing consumers are not lost.
+	maxNameLen, maxDescLen := JSMaxNameLen, JSMaxDescriptionLen
+	if !isRecovering {
+		if srvLim.MaxConsumerNameLen > 0 {
+			maxNameLen = min(maxNameLen, srvLim.MaxConsumerNameLen)
+		}
+		if srvLim.MaxConsumerDescriptionLen > 0 {
+			maxDescLen = min(maxDescLen, srvLim.MaxConsumerDescriptionLen)
+		}
+	}
+	if len(config.Name) > maxNameLen || len(config.Durable) > maxNameLen {
+		return NewJSConsumerNameTooLongError(maxNameLen)
+	}
+
+	// Names need to follow the stream's naming policy, if any. Not enforced on recovery,
+	// nor for internal consumers used for sourcing and mirroring.
+	if pattern := cfg.ConsumerLimits.NamePattern; pattern != _EMPTY_ && !isRecovering && !config.Direct && !config.Sourcing {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return NewJSStreamInvalidConfigError(fmt.Errorf("consumer limits name pattern is invalid: %v", err))
+		}
+		for _, name := range []string{config.Name, config.Durable} {
+			if name != _EMPTY_ && !re.MatchString(name) {
+				return NewJSConsumerNamePatternMismatchError(name, pattern)
+			}
+		}
+	}
+
 	// Check if replicas is defined but exceeds parent stream.
 	if config.Replicas > 0 && config.Replicas > cfg.Replicas {
 		return NewJSConsumerReplicasExceedsStreamError()
@@ -752,6 +1180,32 @@ func checkConsumerCfg(
 	if config.AckWait < 0 {
 		return NewJSConsumerAckWaitNegativeError()
 	}
+	if config.DeliveryQuotaBytes < 0 || config.DeliveryQuotaMsgs < 0 {
+		return NewJSConsumerDeliveryQuotaNegativeError()
+	}
+	if config.SubjectAck && config.AckPolicy != AckExplicit && config.AckPolicy != AckAll {
+		return NewJSConsumerSubjectAckInvalidError()
+	}
+	if config.ReplaySpeed != 0 {
+		if !(config.ReplaySpeed > 0) || math.IsInf(config.ReplaySpeed, 0) {
+			return NewJSConsumerReplaySpeedInvalidError(errors.New("must be positive"))
+		}
+		if config.ReplayPolicy != ReplayOriginal {
+			return NewJSConsumerReplaySpeedInvalidError(errors.New("requires replay policy original"))
+		}
+	}
+
+	// Check redelivery quiet windows end after they start and don't overlap.
+	for i, w := range config.RedeliveryQuietWindows {
+		if !w.End.After(w.Start) {
+			return NewJSConsumerInvalidRedeliveryQuietWindowError(fmt.Errorf("window %d must end after it starts", i))
+		}
+		for j, ow := range config.RedeliveryQuietWindows[:i] {
+			if w.Start.Before(ow.End) && ow.Start.Before(w.End) {
+				return NewJSConsumerInvalidRedeliveryQuietWindowError(fmt.Errorf("window %d overlaps window %d", i, j))
+			}
+		}
+	}
 
 	// Ack Flow Control policy requires push-based flow-controlled consumer.
 	if config.AckPolicy == AckFlowControl {
@@ -783,12 +1237,17 @@ func checkConsumerCfg(
 		return NewJSConsumerMaxDeliverBackoffError()
 	}
 
-	if len(config.Description) > JSMaxDescriptionLen {
-		return NewJSConsumerDescriptionTooLongError(JSMaxDescriptionLen)
+	if len(config.Description) > maxDescLen {
+		return NewJSConsumerDescriptionTooLongError(maxDescLen)
 	}
 
 	// For now expect a literal subject if its not empty. Empty means work queue mode (pull mode).
 	if config.DeliverSubject != _EMPTY_ {
+		// Accounts may only be allowed pull consumers. Not enforced on recovery,
+		// nor for internal consumers used for sourcing and mirroring.
+		if acc != nil && !isRecovering && !config.Direct && !acc.pushConsumersAllowed() {
+			return NewJSConsumerPushDisabledError()
+		}
 		if !subjectIsLiteral(config.DeliverSubject) {
 			return NewJSConsumerDeliverToWildcardsError()
 		}
@@ -801,6 +1260,9 @@ func checkConsumerCfg(
 		if config.MaxWaiting != 0 {
 			return NewJSConsumerPushMaxWaitingError()
 		}
+		if config.WaitingRequestIdleTimeout != 0 {
+			return NewJSConsumerPushWaitingRequestIdleTimeoutError()
+		}
 		if config.MaxAckPending > 0 && config.AckPolicy == AckNone {
 			return NewJSConsumerMaxPendingAckPolicyRequiredError()
 		}
@@ -818,9 +1280,15 @@ func checkConsumerCfg(
 		if config.MaxWaiting < 0 {
 			return NewJSConsumerMaxWaitingNegativeError()
 		}
+		if config.WaitingRequestIdleTimeout < 0 {
+			return NewJSConsumerWaitingRequestIdleTimeoutNegativeError()
+		}
 		if config.Heartbeat > 0 {
 			return NewJSConsumerHBRequiresPushError()
 		}
+		if config.SignalCaughtUp {
+			return NewJSConsumerSignalCaughtUpRequiresPushError()
+		}
 		if config.FlowControl {
 			return NewJSConsumerFCRequiresPushError()
 		}
@@ -888,6 +1356,173 @@ func checkConsumerCfg(
 		}
 	}
 
+	// Check each filter can match something in the stream, catching typos that would
+	// otherwise result in a consumer that never delivers. Streams that store subjects
+	// other than their configured ones, through mirrors, sources or transforms, are skipped.
+	if checkFilterOverlap && !isRecovering && cfg != nil && len(cfg.Subjects) > 0 &&
+		cfg.Mirror == nil && len(cfg.Sources) == 0 && cfg.SubjectTransform == nil {
+		for _, filter := range subjectFilters {
+			if !slices.ContainsFunc(cfg.Subjects, func(subj string) bool { return SubjectsCollide(filter, subj) }) {
+				return NewJSConsumerFilterNoMatchError(filter)
+			}
+		}
+	}
+
+	// Exclude subjects need to be valid and distinct, overlapping our filters is checked when adding the consumer.
+	for i, subj := range config.ExcludeSubjects {
+		if subj == _EMPTY_ || !IsValidSubject(subj) {
+			return NewJSConsumerExcludeSubjectInvalidError(fmt.Errorf("%q is not a valid subject", subj))
+		}
+		for _, other := range config.ExcludeSubjects[:i] {
+			if subj == other {
+				return NewJSConsumerExcludeSubjectInvalidError(fmt.Errorf("duplicate exclude subject %q", subj))
+			}
+			if SubjectsCollide(subj, other) {
+				return NewJSConsumerExcludeSubjectInvalidError(fmt.Errorf("exclude subject %q overlaps %q", subj, other))
+			}
+		}
+	}
+
+	// Skip headers need to be valid header names and values.
+	if len(config.SkipHeaders) > JSMaxSkipHeaders {
+		return NewJSConsumerSkipHeadersInvalidError(fmt.Errorf("can not have more than %d entries", JSMaxSkipHeaders))
+	}
+	for k, v := range config.SkipHeaders {
+		if k == _EMPTY_ || strings.ContainsAny(k, " \t\r\n:") {
+			return NewJSConsumerSkipHeadersInvalidError(fmt.Errorf("%q is not a valid header name", k))
+		}
+		if v == _EMPTY_ || strings.ContainsAny(v, "\r\n") {
+			return NewJSConsumerSkipHeadersInvalidError(fmt.Errorf("%q is not a valid value for header %q", v, k))
+		}
+	}
+
+	// Acks from the same connection can only be checked for pull requests that require explicit acks.
+	if config.AckSameConnection {
+		if config.AckPolicy != AckExplicit {
+			return NewJSConsumerAckSameConnectionInvalidError(errors.New("ack policy must be explicit"))
+		}
+		if config.DeliverSubject != _EMPTY_ {
+			return NewJSConsumerAckSameConnectionInvalidError(errors.New("consumer must be pull based"))
+		}
+	}
+
+	// Per filter ack waits need to be positive and reference one of our filters.
+	if len(config.AckWaitPerFilter) > 0 {
+		if config.AckPolicy == AckNone {
+			return NewJSConsumerAckWaitPerFilterInvalidError(errors.New("ack policy none does not use an ack wait"))
+		}
+		if len(config.BackOff) > 0 {
+			return NewJSConsumerAckWaitPerFilterInvalidError(errors.New("can not be combined with backoff"))
+		}
+		for filter, aw := range config.AckWaitPerFilter {
+			if !slices.Contains(subjectFilters, filter) {
+				return NewJSConsumerAckWaitPerFilterInvalidError(fmt.Errorf("%q is not a filter subject of the consumer", filter))
+			}
+			if aw <= 0 {
+				return NewJSConsumerAckWaitPerFilterInvalidError(fmt.Errorf("ack wait for %q needs to be positive", filter))
+			}
+		}
+	}
+
+	// Subjects without redelivery need to be within our filters.
+	if len(config.NoRedeliverSubjects) > 0 {
+		if config.AckPolicy == AckNone {
+			return NewJSConsumerNoRedeliverSubjectsInvalidError(errors.New("ack policy none does not redeliver"))
+		}
+		for _, subj := range config.NoRedeliverSubjects {
+			if !IsValidSubject(subj) {
+				return NewJSConsumerNoRedeliverSubjectsInvalidError(fmt.Errorf("%q is not a valid subject", subj))
+			}
+			if len(subjectFilters) > 0 && !slices.ContainsFunc(subjectFilters, func(filter string) bool { return subjectIsSubsetMatch(subj, filter) }) {
+				return NewJSConsumerNoRedeliverSubjectsInvalidError(fmt.Errorf("%q is not a subset of the consumer's filter subjects", subj))
+			}
+		}
+	}
+
+	// Max ack age terminates pending messages, so needs to outlast the ack wait.
+	if config.MaxAckAge != 0 {
+		if config.AckPolicy == AckNone {
+			return NewJSConsumerMaxAckAgeInvalidError(errors.New("ack policy none has no pending messages"))
+		}
+		if config.MaxAckAge < 0 {
+			return NewJSConsumerMaxAckAgeInvalidError(errors.New("max ack age can not be negative"))
+		}
+		if config.MaxAckAge <= config.AckWait {
+			return NewJSConsumerMaxAckAgeInvalidError(errors.New("max ack age needs to be larger than ack wait"))
+		}
+	}
+
+	// Ack batches expand acks, so are only meaningful when messages need acking.
+	if config.AckBatchSize != 0 || config.AckBatchWindow != 0 {
+		if config.AckPolicy != AckExplicit && config.AckPolicy != AckAll {
+			return NewJSConsumerAckBatchInvalidError(fmt.Errorf("ack policy %s does not support ack batches", config.AckPolicy))
+		}
+		if config.AckBatchSize < 0 {
+			return NewJSConsumerAckBatchInvalidError(errors.New("ack batch size can not be negative"))
+		}
+		if config.AckBatchWindow < 0 {
+			return NewJSConsumerAckBatchInvalidError(errors.New("ack batch window can not be negative"))
+		}
+	}
+
+	// Messages sent to the dead letter subject must not end up back in the stream.
+	if config.DeadLetterSubject != _EMPTY_ {
+		if !IsValidPublishSubject(config.DeadLetterSubject) {
+			return NewJSConsumerDeadLetterSubjectInvalidError(errors.New("not a valid publish subject"))
+		}
+		for _, subj := range cfg.Subjects {
+			if SubjectsCollide(config.DeadLetterSubject, subj) {
+				return NewJSConsumerDeadLetterSubjectInvalidError(errors.New("forms a cycle with the stream subjects"))
+			}
+		}
+	}
+
+	// Delivery receipts need a sampling percentage, and must not end up back in the stream.
+	if config.DeliveryReceiptSubject != _EMPTY_ {
+		if !IsValidPublishSubject(config.DeliveryReceiptSubject) {
+			return NewJSConsumerDeliveryReceiptInvalidError(errors.New("not a valid publish subject"))
+		}
+		for _, subj := range cfg.Subjects {
+			if SubjectsCollide(config.DeliveryReceiptSubject, subj) {
+				return NewJSConsumerDeliveryReceiptInvalidError(errors.New("subject forms a cycle with the stream subjects"))
+			}
+		}
+		if config.DeliveryReceiptSampling < 1 || config.DeliveryReceiptSampling > 100 {
+			return NewJSConsumerDeliveryReceiptInvalidError(errors.New("sampling must be between 1 and 100"))
+		}
+	} else if config.DeliveryReceiptSampling != 0 {
+		return NewJSConsumerDeliveryReceiptInvalidError(errors.New("sampling requires a subject"))
+	}
+
+	// Concurrent delivery only keeps the order per subject, which flow control can not account for.
+	if config.DeliveryConcurrency < 0 || config.DeliveryConcurrency > JsMaxDeliveryConcurrency {
+		return NewJSConsumerDeliveryConcurrencyInvalidError(fmt.Errorf("must be between 1 and %d", JsMaxDeliveryConcurrency))
+	}
+	if config.DeliveryConcurrency > 1 {
+		if config.DeliverSubject == _EMPTY_ {
+			return NewJSConsumerDeliveryConcurrencyInvalidError(errors.New("only supported on push consumers"))
+		}
+		if config.FlowControl {
+			return NewJSConsumerDeliveryConcurrencyInvalidError(errors.New("not supported with flow control"))
+		}
+	}
+
+	// Ordering by timestamp sorts gathered batches, so needs bounded pull requests.
+	if config.OrderByTimestamp {
+		if config.DeliverSubject != _EMPTY_ {
+			return NewJSConsumerOrderByTimestampInvalidError(errors.New("only supported on pull consumers"))
+		}
+		if config.ReplayPolicy != ReplayInstant {
+			return NewJSConsumerOrderByTimestampInvalidError(errors.New("only supported with instant replay policy"))
+		}
+		if config.AckPolicy == AckAll {
+			return NewJSConsumerOrderByTimestampInvalidError(errors.New("not supported with ack all policy"))
+		}
+		if config.MaxRequestBatch <= 0 {
+			return NewJSConsumerOrderByTimestampInvalidError(errors.New("max request batch needs to be set"))
+		}
+	}
+
 	// Helper function to formulate similar errors.
 	badStart := func(dp, start string) error {
 		return fmt.Errorf("consumer delivery policy is deliver %s, but optional start %s is also set", dp, start)
@@ -971,6 +1606,13 @@ func checkConsumerCfg(
 		return NewJSConsumerMetadataLengthError(fmt.Sprintf("%dKB", JSMaxMetadataLen/1024))
 	}
 
+	if _, err := config.PinStealPolicy.MarshalJSON(); err != nil {
+		return NewJSConsumerPinStealPolicyInvalidError(err)
+	}
+	if config.PinStealPolicy != PinStealDeny && config.PriorityPolicy != PriorityPinnedClient {
+		return NewJSConsumerPinStealPolicyInvalidError(errors.New("requires pinned client priority policy"))
+	}
+
 	if config.PriorityPolicy != PriorityNone {
 		if config.DeliverSubject != "" {
 			return NewJSConsumerPushWithPriorityGroupError()
@@ -1006,14 +1648,14 @@ func checkConsumerCfg(
 }
 
 func (mset *stream) addConsumerWithAction(config *ConsumerConfig, action ConsumerAction, pedantic bool) (*consumer, error) {
-	return mset.addConsumerWithAssignment(config, _EMPTY_, nil, false, action, pedantic)
+	return mset.addConsumerWithAssignment(config, _EMPTY_, nil, false, action, pedantic, false)
 }
 
 func (mset *stream) addConsumer(config *ConsumerConfig) (*consumer, error) {
 	return mset.addConsumerWithAction(config, ActionCreateOrUpdate, false)
 }
 
-func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname string, ca *consumerAssignment, isRecovering bool, action ConsumerAction, pedantic bool) (*consumer, error) {
+func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname string, ca *consumerAssignment, isRecovering bool, action ConsumerAction, pedantic, checkFilterOverlap bool) (*consumer, error) {
 	// Check if this stream has closed.
 	if mset.closed.Load() {
 		return nil, NewJSStreamInvalidError()
@@ -1039,17 +1681,29 @@ func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname stri
 		return nil, NewJSNoLimitsError()
 	}
 
+	// Exclude subjects not overlapping the filters are allowed, but do nothing.
+	if !isRecovering {
+		for _, subj := range noOpExcludeSubjects(config, cfg.Subjects) {
+			s.Warnf("Consumer '%s > %s' exclude subject %q does not overlap any of its filter subjects",
+				acc.Name, cfg.Name, subj)
+		}
+	}
+
 	srvLim := &s.getOpts().JetStreamLimits
 	// Make sure we have sane defaults. Do so with the JS lock, otherwise a
 	// badly timed meta snapshot can result in a race condition.
 	mset.js.mu.Lock()
-	err := setConsumerConfigDefaults(config, &cfg, srvLim, selectedLimits, pedantic)
+	defaulted, err := setConsumerConfigDefaultsWithFields(config, &cfg, srvLim, selectedLimits, pedantic)
+	// In clustered mode the defaults were already applied by the meta leader.
+	if ca != nil {
+		defaulted = ca.DefaultedFields
+	}
 	mset.js.mu.Unlock()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := checkConsumerCfg(config, srvLim, &cfg, acc, selectedLimits, isRecovering); err != nil {
+	if err := checkConsumerCfg(config, srvLim, &cfg, acc, selectedLimits, isRecovering, checkFilterOverlap); err != nil {
 		return nil, err
 	}
 	sampleFreq := 0
@@ -1106,6 +1760,7 @@ func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname stri
 			mset.mu.Unlock()
 			err := eo.updateConfig(config)
 			if err == nil {
+				eo.setDefaultedFields(defaulted)
 				return eo, nil
 			}
 			return nil, NewJSConsumerCreateError(err, Unless(err))
@@ -1195,8 +1850,10 @@ func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname stri
 		sfreq:     int32(sampleFreq),
 		maxdc:     uint64(max(config.MaxDeliver, 0)), // MaxDeliver is negative (-1) when infinite.
 		maxp:      config.MaxAckPending,
+		maxpab:    srvLim.MaxConsumerPendingBytes,
 		retention: cfg.Retention,
 		created:   time.Now().UTC(),
+		dflt:      defaulted,
 	}
 
 	// Add created timestamp used for the store, must match that of the consumer assignment if it exists.
@@ -1286,6 +1943,7 @@ func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname stri
 			o.filters.Insert(filter.subject, struct{}{})
 		}
 	}
+	o.setExcludeSubjects(o.cfg.ExcludeSubjects)
 
 	if o.store != nil && o.store.HasState() {
 		// Restore our saved state.
@@ -1401,6 +2059,8 @@ func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname stri
 	o.ackReplyT = fmt.Sprintf("%s.%%d.%%d.%%d.%%d.%%d", pre)
 	// Subscribe on this ack subject for v2, we require 11 tokens, but allow for more tokens/extension.
 	o.ackSubj = fmt.Sprintf(jsAckTv2+".*.*.*.*.>", domain, accHash, cfg.Name, o.name)
+	// Stable subject for acking by stream sequence, only used with SubjectAck.
+	o.sackSubj = fmt.Sprintf(JSConsumerSubjectAckT, cfg.Name, o.name)
 
 	o.nextMsgSubj = fmt.Sprintf(JSApiRequestNextT, cfg.Name, o.name)
 	o.resetSubj = fmt.Sprintf(JSApiConsumerResetT, cfg.Name, o.name)
@@ -1464,14 +2124,14 @@ func (mset *stream) addConsumerWithAssignment(config *ConsumerConfig, oname stri
 func (o *consumer) updateInactiveThreshold(cfg *ConsumerConfig) {
 	// Ephemerals will always have inactive thresholds.
 	if !o.isDurable() && cfg.InactiveThreshold <= 0 {
-		// Add in 1 sec of jitter above and beyond the default of 5s.
-		o.dthresh = JsDeleteWaitTimeDefault + 100*time.Millisecond + time.Duration(rand.Int63n(900))*time.Millisecond
+		// Add in jitter above and beyond the default of 5s.
+		o.dthresh = JsDeleteWaitTimeDefault + o.deleteJitter()
 		// Only stamp config with default sans jitter.
 		cfg.InactiveThreshold = JsDeleteWaitTimeDefault
 	} else if cfg.InactiveThreshold > 0 {
-		// Add in up to 1 sec of jitter if pull mode.
+		// Add in jitter if pull mode.
 		if o.isPullMode() {
-			o.dthresh = cfg.InactiveThreshold + 100*time.Millisecond + time.Duration(rand.Int63n(900))*time.Millisecond
+			o.dthresh = cfg.InactiveThreshold + o.deleteJitter()
 		} else {
 			o.dthresh = cfg.InactiveThreshold
 		}
@@ -1481,6 +2141,20 @@ func (o *consumer) updateInactiveThreshold(cfg *ConsumerConfig) {
 	}
 }
 
+// Returns the jitter to add to the inactive threshold, at least a tenth of the
+// configured maximum, e.g. between 100ms and 1s with the default.
+func (o *consumer) deleteJitter() time.Duration {
+	maxJitter := JsDeleteJitterDefault
+	if o.srv != nil {
+		maxJitter = o.srv.getOpts().JetStreamEphemeralDeleteJitter
+	}
+	if maxJitter <= 0 {
+		return 0
+	}
+	minJitter := maxJitter / 10
+	return minJitter + time.Duration(rand.Int63n(int64(maxJitter-minJitter)+1))
+}
+
 // Updates the paused state. If we are the leader and the pause deadline
 // hasn't passed yet then we will start a timer to kick the consumer once
 // that deadline is reached. Lock should be held.
@@ -1499,6 +2173,9 @@ func (o *consumer) updatePauseState(cfg *ConsumerConfig) {
 		// to do.
 		return
 	}
+	if cfg.ExpireRequestsOnPause {
+		o.expireWaitingOnPause(*cfg.PauseUntil)
+	}
 	o.uptmr = time.AfterFunc(time.Until(*cfg.PauseUntil), func() {
 		o.mu.Lock()
 		defer o.mu.Unlock()
@@ -1509,6 +2186,43 @@ func (o *consumer) updatePauseState(cfg *ConsumerConfig) {
 	})
 }
 
+// Returns true if an update from current to proposed MaxAckPending lowers it.
+func lowersMaxAckPending(current, proposed int) bool {
+	return proposed > 0 && (current <= 0 || proposed < current)
+}
+
+// Checks that an update of MaxAckPending does not lower it below the
+// number of messages currently pending an ack.
+func (o *consumer) checkMaxAckPendingUpdate(maxAckPending int) *ApiError {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if !lowersMaxAckPending(o.cfg.MaxAckPending, maxAckPending) {
+		return nil
+	}
+	if pending := len(o.pending); pending > maxAckPending {
+		return NewJSConsumerMaxAckPendingBelowPendingError(pending)
+	}
+	return nil
+}
+
+// Responds to and removes all waiting pull requests, since the consumer is paused until the given time.
+// Lock should be held.
+func (o *consumer) expireWaitingOnPause(until time.Time) {
+	if o.outq == nil || o.waiting == nil || o.waiting.isEmpty() {
+		return
+	}
+	wq := o.waiting
+	for wr := wq.head; wr != nil; {
+		hdr := fmt.Appendf(nil, "NATS/1.0 409 Consumer Paused\r\n%s: %d\r\n%s: %d\r\n%s: %s\r\n\r\n",
+			JSPullRequestPendingMsgs, wr.n, JSPullRequestPendingBytes, wr.b, JSConsumerPauseUntil, until.UTC().Format(time.RFC3339Nano))
+		o.outq.send(newJSPubMsg(wr.reply, _EMPTY_, _EMPTY_, hdr, nil, nil, 0))
+		next := wr.next
+		wq.remove(nil, wr)
+		wr.recycle()
+		wr = next
+	}
+}
+
 func (o *consumer) consumerAssignment() *consumerAssignment {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -1609,6 +2323,16 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 	mset, closed := o.mset, o.closed
 	wasLeader := o.leader.Swap(isLeader)
 
+	// Leadership is static in single server mode, so only log when clustered.
+	if wasLeader != isLeader && o.srv.getOpts().LogConsumerLeaderChanges && o.srv.JetStreamIsClustered() {
+		from, to := "leader", "follower"
+		if isLeader {
+			from, to = to, from
+		}
+		o.srv.Noticef("JetStream consumer leader change for '%s > %s > %s': role %s -> %s, term %d",
+			o.acc.Name, o.stream, o.name, from, to, term)
+	}
+
 	// For clustered new consumers, starting seq selection was deferred from
 	// addConsumerWithAssignment so the scan wouldn't block the meta apply
 	// goroutine, run it here on leader-elect instead.
@@ -1657,6 +2381,7 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 		close(o.qch)
 		o.qch = nil
 	}
+	o.dlanes = nil
 	// Stop any inactivity timers. Should only be running on leaders.
 	stopAndClearTimer(&o.dtmr)
 	// Stop any unpause timers. Should only be running on leaders.
@@ -1666,6 +2391,7 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 	o.rdc = nil
 	o.rdq = nil
 	o.rdqi.Empty()
+	o.clearOrderedMsgs()
 	o.pending = nil
 	o.rsm = nil
 	o.resetPendingDeliveries()
@@ -1674,11 +2400,12 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 	// ok if they are nil, we protect inside unsubscribe()
 	o.unsubscribe(o.ackSubOld)
 	o.unsubscribe(o.ackSub)
+	o.unsubscribe(o.sackSub)
 	o.unsubscribe(o.reqSub)
 	o.unsubscribe(o.resetSub)
 	o.unsubscribe(o.fcSubOld)
 	o.unsubscribe(o.fcSub)
-	o.ackSubOld, o.ackSub, o.reqSub, o.resetSub, o.fcSubOld, o.fcSub = nil, nil, nil, nil, nil, nil
+	o.ackSubOld, o.ackSub, o.sackSub, o.reqSub, o.resetSub, o.fcSubOld, o.fcSub = nil, nil, nil, nil, nil, nil, nil
 	if o.infoSub != nil {
 		o.srv.sysUnsubscribe(o.infoSub)
 		o.infoSub = nil
@@ -1775,6 +2502,12 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 				o.mu.Unlock()
 				return nil
 			}
+			if o.cfg.SubjectAck {
+				if o.sackSub, err = o.subscribeInternal(o.sackSubj, o.pushSubjectAck); err != nil {
+					o.mu.Unlock()
+					return nil
+				}
+			}
 		}
 
 		// Setup the internal sub for next message requests regardless.
@@ -1819,10 +2552,16 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 			}
 		}
 
+		// Give reconnecting clients some extra time after a leader change.
+		dthresh := o.dthresh
+		if grace := s.getOpts().ConsumerLeaderChangeGrace; grace > 0 && dthresh > 0 && !wasLeader && s.JetStreamIsClustered() {
+			dthresh += grace
+			o.dgrace = time.Now().Add(dthresh)
+		}
 		if o.dthresh > 0 && (o.isPullMode() || !o.active) {
 			// Pull consumer. We run the dtmr all the time for this one.
 			stopAndClearTimer(&o.dtmr)
-			o.dtmr = time.AfterFunc(o.dthresh, o.deleteNotActive)
+			o.dtmr = time.AfterFunc(dthresh, o.deleteNotActive)
 		}
 
 		// Update the consumer pause tracking.
@@ -1843,6 +2582,16 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 			pch = o.pch
 		}
 		pullMode := o.isPullMode()
+		// Setup the delivery lanes if delivering concurrently.
+		o.dlanes = nil
+		if n := o.cfg.DeliveryConcurrency; n > 1 {
+			o.dlanes = make([]*jsOutQ, n)
+			for i := range o.dlanes {
+				qname := fmt.Sprintf("[ACC:%s] consumer '%s > %s' delivery lane %d", o.acc.Name, o.stream, o.name, i)
+				o.dlanes[i] = &jsOutQ{newIPQueue[*jsPubMsg](o.srv, qname)}
+			}
+		}
+		lanes, acc := o.dlanes, o.acc
 		o.mu.Unlock()
 
 		// Check if there are any pending we might need to clean up etc.
@@ -1865,6 +2614,14 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 			o.loopAndGatherMsgs(qch)
 		}()
 
+		// Now start up Go routines to deliver through the delivery lanes.
+		for _, lane := range lanes {
+			go func() {
+				setGoRoutineLabels(labels)
+				o.deliveryLaneLoop(acc, lane, qch)
+			}()
+		}
+
 		// Now start up Go routine to process acks.
 		go func() {
 			setGoRoutineLabels(labels)
@@ -1896,7 +2653,7 @@ func (o *consumer) setLeader(isLeader bool, term uint64) error {
 
 // This is coming on the wire so do not block here.
 func (o *consumer) handleClusterConsumerInfoRequest(sub *subscription, c *client, _ *Account, subject, reply string, msg []byte) {
-	go o.infoWithSnapAndReply(false, reply)
+	go o.infoWithSnapAndReply(false, reply, true)
 }
 
 // Lock should be held.
@@ -2040,6 +2797,9 @@ func (o *consumer) sendPauseAdvisoryLocked(cfg *ConsumerConfig) {
 		e.PauseUntil = *cfg.PauseUntil
 		e.Paused = time.Now().Before(e.PauseUntil)
 	}
+	if o.qpaused {
+		e.Paused = true
+	}
 
 	subj := JSAdvisoryConsumerPausePre + "." + o.stream + "." + o.name
 	o.sendAdvisory(subj, e)
@@ -2166,6 +2926,16 @@ func (o *consumer) deleteNotActive() {
 		o.mu.Unlock()
 		return
 	}
+	// Still within the grace period following a leader change.
+	if remaining := time.Until(o.dgrace); remaining > 0 {
+		if o.dtmr != nil {
+			o.dtmr.Reset(remaining)
+		} else {
+			o.dtmr = time.AfterFunc(remaining, o.deleteNotActive)
+		}
+		o.mu.Unlock()
+		return
+	}
 	// Push mode just look at active.
 	if o.isPushMode() {
 		// If we are active simply return.
@@ -2208,7 +2978,7 @@ func (o *consumer) deleteNotActive() {
 		var ackWait time.Duration
 		for seq, p := range o.pending {
 			if l == 0 {
-				ackWait = o.ackWait(0)
+				ackWait = o.ackWait(o.pendingAckWait(seq))
 			} else {
 				bi := int(o.rdc[seq])
 				if bi < 0 {
@@ -2366,7 +3136,7 @@ func (o *consumer) hasMaxDeliveries(seq uint64) bool {
 			o.notifyDeliveryExceeded(seq, dc)
 		}
 		// Determine if we signal to start flow of messages again.
-		if o.maxp > 0 && len(o.pending) >= o.maxp {
+		if o.maxPendingReached() {
 			o.signalNewMessages()
 		}
 		// Make sure to remove from pending.
@@ -2463,6 +3233,34 @@ func (o *consumer) setRateLimit(bps uint64) {
 	o.rlimit = rate.NewLimiter(rl, burst)
 }
 
+// Ack policies ordered by how strictly delivered messages are tracked.
+var ackPolicyStrictness = map[AckPolicy]int{
+	AckNone:     0,
+	AckAll:      1,
+	AckExplicit: 2,
+}
+
+// checkAckPolicyUpdate classifies an ack policy transition for a consumer update.
+// Tightening (none -> all -> explicit) is allowed and returns a warning describing
+// the redelivery implications. Loosening is rejected since it would orphan pending acks.
+func checkAckPolicyUpdate(old, new AckPolicy) (string, error) {
+	if old == new {
+		return _EMPTY_, nil
+	}
+	or, ook := ackPolicyStrictness[old]
+	nr, nok := ackPolicyStrictness[new]
+	if !ook || !nok {
+		return _EMPTY_, errors.New("ack policy can not be updated")
+	}
+	if nr < or {
+		return _EMPTY_, fmt.Errorf("ack policy can not be loosened from %s to %s", old, new)
+	}
+	if old == AckNone {
+		return fmt.Sprintf("ack policy changed from %s to %s, messages delivered before the update are not tracked and will not be redelivered", old, new), nil
+	}
+	return fmt.Sprintf("ack policy changed from %s to %s, pending messages now require individual acks and keep their current redelivery counts", old, new), nil
+}
+
 // Check if new consumer config allowed vs old.
 func (acc *Account) checkNewConsumerConfig(cfg, ncfg *ConsumerConfig) error {
 	if reflect.DeepEqual(cfg, ncfg) {
@@ -2487,8 +3285,8 @@ func (acc *Account) checkNewConsumerConfig(cfg, ncfg *ConsumerConfig) error {
 		// At least one start time is set and the other is not
 		return errors.New("start time can not be updated")
 	}
-	if cfg.AckPolicy != ncfg.AckPolicy {
-		return errors.New("ack policy can not be updated")
+	if _, err := checkAckPolicyUpdate(cfg.AckPolicy, ncfg.AckPolicy); err != nil {
+		return err
 	}
 	if cfg.ReplayPolicy != ncfg.ReplayPolicy {
 		return errors.New("replay policy can not be updated")
@@ -2499,6 +3297,9 @@ func (acc *Account) checkNewConsumerConfig(cfg, ncfg *ConsumerConfig) error {
 	if cfg.FlowControl != ncfg.FlowControl {
 		return errors.New("flow control can not be updated")
 	}
+	if cfg.DeliveryConcurrency != ncfg.DeliveryConcurrency {
+		return errors.New("delivery concurrency can not be updated")
+	}
 
 	// Deliver Subject is conditional on if its bound.
 	if cfg.DeliverSubject != ncfg.DeliverSubject {
@@ -2556,6 +3357,40 @@ func (o *consumer) updateConfig(cfg *ConsumerConfig) error {
 		o.updateDeliverSubjectLocked(cfg.DeliverSubject)
 	}
 
+	// AckPolicy, only tightening is allowed so make sure acks can be received when moving away from AckNone.
+	if cfg.AckPolicy != o.cfg.AckPolicy && o.cfg.AckPolicy == AckNone && o.isLeader() {
+		var err error
+		if o.ackSubOld == nil {
+			if o.ackSubOld, err = o.subscribeInternal(o.ackSubjOld, o.pushAck); err != nil {
+				return err
+			}
+		}
+		if o.ackSub == nil {
+			if o.ackSub, err = o.subscribeInternal(o.ackSubj, o.pushAck); err != nil {
+				return err
+			}
+		}
+	}
+
+	// SubjectAck, setup or remove the stable ack subscription.
+	if cfg.SubjectAck != o.cfg.SubjectAck && o.isLeader() {
+		if cfg.SubjectAck && o.sackSub == nil {
+			var err error
+			if o.sackSub, err = o.subscribeInternal(o.sackSubj, o.pushSubjectAck); err != nil {
+				return err
+			}
+		} else if !cfg.SubjectAck {
+			o.unsubscribe(o.sackSub)
+			o.sackSub = nil
+		}
+	}
+
+	// SignalCaughtUp, enabling it again requests a new caught up status message.
+	if cfg.SignalCaughtUp && !o.cfg.SignalCaughtUp {
+		o.caughtUp = false
+		o.signalNewMessages()
+	}
+
 	// MaxAckPending
 	if cfg.MaxAckPending != o.cfg.MaxAckPending {
 		o.maxp = cfg.MaxAckPending
@@ -2567,7 +3402,9 @@ func (o *consumer) updateConfig(cfg *ConsumerConfig) error {
 		}
 	}
 	// AckWait
-	if cfg.AckWait != o.cfg.AckWait {
+	if cfg.AckWait != o.cfg.AckWait || !maps.Equal(cfg.AckWaitPerFilter, o.cfg.AckWaitPerFilter) {
+		// Per filter ack waits will be looked up again.
+		o.pawt = nil
 		if o.ptmr != nil {
 			o.resetPtmr(100 * time.Millisecond)
 		}
@@ -2612,6 +3449,10 @@ func (o *consumer) updateConfig(cfg *ConsumerConfig) error {
 				o.sendPauseAdvisoryLocked(cfg)
 			}
 		}
+		// Updating without a pause deadline, e.g. on resume, clears the delivery quota.
+		if new.IsZero() {
+			o.clearDeliveryQuota()
+		}
 	}
 
 	// Check for Subject Filters update.
@@ -2652,6 +3493,12 @@ func (o *consumer) updateConfig(cfg *ConsumerConfig) error {
 		}
 	}
 
+	// Check for exclude subjects update.
+	if !slices.Equal(cfg.ExcludeSubjects, o.cfg.ExcludeSubjects) {
+		o.setExcludeSubjects(cfg.ExcludeSubjects)
+		updatedFilters = true
+	}
+
 	// Record new config for others that do not need special handling.
 	// Allowed but considered no-op, [Description, SampleFrequency, MaxWaiting, HeadersOnly]
 	o.cfg = *cfg
@@ -2724,11 +3571,12 @@ type jsAckMsg struct {
 	reply   string
 	hdr     int
 	msg     []byte
+	cid     uint64 // Connection the ack was received on.
 }
 
 var jsAckMsgPool sync.Pool
 
-func newJSAckMsg(subj, reply string, hdr int, msg []byte) *jsAckMsg {
+func newJSAckMsg(subj, reply string, hdr int, msg []byte, cid uint64) *jsAckMsg {
 	var m *jsAckMsg
 	am := jsAckMsgPool.Get()
 	if am != nil {
@@ -2739,7 +3587,7 @@ func newJSAckMsg(subj, reply string, hdr int, msg []byte) *jsAckMsg {
 	// When getting something from a pool it is critical that all fields are
 	// initialized. Doing this way guarantees that if someone adds a field to
 	// the structure, the compiler will fail the build if this line is not updated.
-	(*m) = jsAckMsg{subj, reply, hdr, msg}
+	(*m) = jsAckMsg{subj, reply, hdr, msg, cid}
 	return m
 }
 
@@ -2747,18 +3595,43 @@ func (am *jsAckMsg) returnToPool() {
 	if am == nil {
 		return
 	}
-	am.subject, am.reply, am.hdr, am.msg = _EMPTY_, _EMPTY_, -1, nil
+	am.subject, am.reply, am.hdr, am.msg, am.cid = _EMPTY_, _EMPTY_, -1, nil, 0
 	jsAckMsgPool.Put(am)
 }
 
 // Push the ack message to the consumer's ackMsgs queue
 func (o *consumer) pushAck(_ *subscription, c *client, _ *Account, subject, reply string, rmsg []byte) {
 	atomic.AddInt64(&o.awl, 1)
-	o.ackMsgs.push(newJSAckMsg(subject, reply, c.pa.hdr, copyBytes(rmsg)))
+	o.ackMsgs.push(newJSAckMsg(subject, reply, c.pa.hdr, copyBytes(rmsg), c.cid))
+}
+
+// Push an ack received on the stable subject ack subject to the consumer's ackMsgs queue.
+// The payload is the stream sequence, optionally followed by a space and the ack body,
+// e.g. "22" or "22 +NAK". The sequence is translated into the ack reply subject the
+// message was delivered with, so it is processed the same as for pushAck.
+func (o *consumer) pushSubjectAck(_ *subscription, c *client, _ *Account, _, reply string, rmsg []byte) {
+	_, msg := c.msgParts(rmsg)
+	seqb, body, _ := bytes.Cut(bytes.TrimSpace(msg), []byte{' '})
+	sseq, err := strconv.ParseUint(string(seqb), 10, 64)
+	if err != nil || sseq == 0 {
+		return
+	}
+
+	o.mu.RLock()
+	p, ok := o.pending[sseq]
+	if !ok {
+		o.mu.RUnlock()
+		return
+	}
+	subject := o.ackReply(sseq, p.Sequence, o.deliveryCount(sseq), p.Timestamp, 0)
+	o.mu.RUnlock()
+
+	atomic.AddInt64(&o.awl, 1)
+	o.ackMsgs.push(newJSAckMsg(subject, reply, -1, copyBytes(bytes.TrimSpace(body)), c.cid))
 }
 
 // Processes a message for the ack reply subject delivered with a message.
-func (o *consumer) processAck(subject, reply string, hdr int, rmsg []byte) {
+func (o *consumer) processAck(subject, reply string, hdr int, rmsg []byte, cid uint64) {
 	defer atomic.AddInt64(&o.awl, -1)
 
 	var msg []byte
@@ -2770,26 +3643,37 @@ func (o *consumer) processAck(subject, reply string, hdr int, rmsg []byte) {
 
 	sseq, dseq, dc, _, _ := ackReplyInfo(subject)
 
+	// Ignore acks that are not from the connection the message was delivered for, if required.
+	if !o.isAckFromDeliveryConn(sseq, cid) {
+		return
+	}
+
 	skipAckReply := sseq == 0
 
 	switch {
 	case len(msg) == 0, bytes.Equal(msg, AckAck), bytes.Equal(msg, AckOK):
+		o.processAckBatch(sseq)
 		if !o.processAckMsg(sseq, dseq, dc, reply, true) {
 			// We handle replies for acks in updateAcks
 			skipAckReply = true
 		}
 	case bytes.HasPrefix(msg, AckNext):
+		o.processAckBatch(sseq)
 		o.processAckMsg(sseq, dseq, dc, _EMPTY_, true)
-		o.processNextMsgRequest(reply, msg[len(AckNext):])
+		o.processNextMsgRequest(reply, msg[len(AckNext):], 0, cid)
 		skipAckReply = true
 	case bytes.HasPrefix(msg, AckNak):
-		o.processNak(sseq, dseq, dc, msg)
+		if o.isNoRedeliver(sseq) {
+			o.processTerm(sseq, dseq, dc, ackTermNoRedeliverReason, _EMPTY_)
+		} else {
+			o.processNak(sseq, dseq, dc, msg)
+		}
 	case bytes.Equal(msg, AckProgress):
 		o.progressUpdate(sseq)
 	case bytes.HasPrefix(msg, AckTerm):
 		var reason string
 		if buf := msg[len(AckTerm):]; len(buf) > 0 {
-			reason = string(bytes.TrimSpace(buf))
+			reason = sanitizeAckTermReason(buf)
 		}
 		if !o.processTerm(sseq, dseq, dc, reason, reply) {
 			// We handle replies for acks in updateAcks
@@ -2803,6 +3687,84 @@ func (o *consumer) processAck(subject, reply string, hdr int, rmsg []byte) {
 	}
 }
 
+// Checks whether the ack was received on the connection the message was delivered for,
+// always true unless AckSameConnection is set.
+func (o *consumer) isAckFromDeliveryConn(sseq, cid uint64) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if !o.cfg.AckSameConnection || sseq == 0 {
+		return true
+	}
+	dcid, ok := o.pconn[sseq]
+	if ok && dcid != cid {
+		o.srv.Debugf("Ignoring ack for sequence %d of consumer '%s > %s > %s' from a different connection",
+			sseq, o.acc.Name, o.stream, o.name)
+		return false
+	}
+	return true
+}
+
+// Returns a reason that is safe to be recorded in the termination advisory
+// from the payload that followed +TERM. Non-printable characters are dropped
+// and the result is capped to maxAckTermReasonLen bytes.
+func sanitizeAckTermReason(buf []byte) string {
+	reason := strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, string(bytes.TrimSpace(buf)))
+	if len(reason) > maxAckTermReasonLen {
+		// Make sure we do not cut in the middle of a multi-byte character.
+		i := maxAckTermReasonLen
+		for i > 0 && !utf8.RuneStart(reason[i]) {
+			i--
+		}
+		reason = reason[:i]
+	}
+	return strings.TrimSpace(reason)
+}
+
+// Publishes a delivery trace event if the consumer has DeliverTrace enabled.
+// Skip decisions are only reported when the reason changes, and all events
+// are sampled so that they do not exceed deliverTraceMaxRate per second.
+// Lock should be held.
+func (o *consumer) traceDelivery(reason string, pmsg *jsPubMsg, dc uint64) {
+	if !o.cfg.DeliverTrace {
+		return
+	}
+	delivered := reason == deliverTraceDelivered
+	if !delivered && reason == o.ltrace {
+		return
+	}
+	if o.trlimit == nil {
+		o.trlimit = rate.NewLimiter(deliverTraceMaxRate, deliverTraceMaxRate)
+	}
+	if !o.trlimit.Allow() {
+		return
+	}
+	o.ltrace = reason
+
+	e := JSConsumerDeliveryTraceEvent{
+		TypedEvent: TypedEvent{
+			Type: JSConsumerDeliveryTraceEventType,
+			ID:   nuid.Next(),
+			Time: time.Now().UTC(),
+		},
+		Stream:    o.stream,
+		Consumer:  o.name,
+		Delivered: delivered,
+		Reason:    reason,
+		Domain:    o.srv.getOpts().JetStreamDomain,
+	}
+	if pmsg != nil {
+		e.Subject = pmsg.subj
+		e.StreamSeq = pmsg.seq
+		e.Deliveries = dc
+	}
+	o.sendAdvisory(JSConsumerDeliveryTracePre+"."+o.stream+"."+o.name, e)
+}
+
 // Used to process a working update to delay redelivery.
 func (o *consumer) progressUpdate(seq uint64) {
 	o.mu.Lock()
@@ -2909,6 +3871,7 @@ func (o *consumer) resetLocalStartingSeq(seq uint64) {
 	o.pending, o.rdc = nil, nil
 	o.rdq = nil
 	o.rdqi.Empty()
+	o.clearOrderedMsgs()
 	o.sseq, o.dseq = seq, 1
 	o.adflr, o.asflr = o.dseq-1, o.sseq-1
 	o.ldt, o.lat = time.Time{}, time.Time{}
@@ -3017,6 +3980,10 @@ func (o *consumer) addReplicatedQueuedMsg(pmsg *jsPubMsg) {
 		o.pendingDeliveries = make(map[uint64]*jsPubMsg)
 	}
 	o.pendingDeliveries[pmsg.seq] = pmsg
+	if o.pdts == nil {
+		o.pdts = make(map[uint64]int64)
+	}
+	o.pdts[pmsg.seq] = time.Now().UnixNano()
 
 	// Is not explicitly limited in size, but will at most hold maximum waiting requests.
 	if o.waitingDeliveries == nil {
@@ -3031,6 +3998,37 @@ func (o *consumer) addReplicatedQueuedMsg(pmsg *jsPubMsg) {
 	}
 }
 
+// Records how long the pending delivery for the stream sequence waited on quorum.
+// Lock should be held.
+func (o *consumer) recordQuorumDeliveryLatency(sseq uint64) {
+	qts, ok := o.pdts[sseq]
+	if !ok {
+		return
+	}
+	delete(o.pdts, sseq)
+	o.qdl[o.qdli] = time.Duration(time.Now().UnixNano() - qts)
+	o.qdli = (o.qdli + 1) % quorumLatencySamples
+	if o.qdln < quorumLatencySamples {
+		o.qdln++
+	}
+}
+
+// Returns the quorum delivery latency stats over the recorded samples.
+// Lock should be held.
+func (o *consumer) quorumDeliveryLatency() *QuorumDeliveryLatency {
+	ql := &QuorumDeliveryLatency{Samples: o.qdln}
+	if o.qdln == 0 {
+		return ql
+	}
+	var total time.Duration
+	for _, d := range o.qdl[:o.qdln] {
+		total += d
+		ql.Max = max(ql.Max, d)
+	}
+	ql.Average = total / time.Duration(o.qdln)
+	return ql
+}
+
 // Lock should be held.
 func (o *consumer) updateAcks(dseq, sseq uint64, reply string) {
 	if o.node != nil {
@@ -3206,7 +4204,7 @@ func (o *consumer) processNak(sseq, dseq, dc uint64, nak []byte) {
 				o.removeFromRedeliverQueue(sseq)
 				if p, ok := o.pending[sseq]; ok {
 					// now - ackWait is expired now, so offset from there.
-					p.Timestamp = time.Now().Add(-o.cfg.AckWait).Add(d).UnixNano()
+					p.Timestamp = time.Now().Add(-o.pendingAckWait(sseq)).Add(d).UnixNano()
 					// Update store system which will update followers as well.
 					o.updateDelivered(p.Sequence, sseq, dc, p.Timestamp)
 					if o.ptmr != nil {
@@ -3276,7 +4274,59 @@ func (o *consumer) ackWait(next time.Duration) time.Duration {
 	if next > 0 {
 		return next + ackWaitDelay
 	}
-	return o.cfg.AckWait + ackWaitDelay
+	return o.cfg.AckWait + ackWaitDelay
+}
+
+// Returns the ack wait for the pending message with stream sequence seq. If the consumer
+// has AckWaitPerFilter set, the ack wait of the filter matching the message's subject is used.
+// Lock should be held.
+func (o *consumer) pendingAckWait(seq uint64) time.Duration {
+	if len(o.cfg.AckWaitPerFilter) == 0 {
+		return o.cfg.AckWait
+	}
+	if aw, ok := o.pawt[seq]; ok {
+		return aw
+	}
+	aw := o.cfg.AckWait
+	if mset := o.mset; mset != nil && mset.store != nil {
+		var smv StoreMsg
+		if sm, err := mset.store.LoadMsg(seq, &smv); err == nil && sm != nil {
+			for filter, faw := range o.cfg.AckWaitPerFilter {
+				if subjectIsSubsetMatch(sm.subj, filter) {
+					aw = faw
+					break
+				}
+			}
+		}
+	}
+	if o.pawt == nil {
+		o.pawt = make(map[uint64]time.Duration)
+	}
+	o.pawt[seq] = aw
+	return aw
+}
+
+// Returns whether the message with stream sequence seq should not be redelivered,
+// based on the consumer's NoRedeliverSubjects.
+func (o *consumer) isNoRedeliver(seq uint64) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.isNoRedeliverLocked(seq)
+}
+
+// Lock should be held.
+func (o *consumer) isNoRedeliverLocked(seq uint64) bool {
+	if len(o.cfg.NoRedeliverSubjects) == 0 || o.mset == nil || o.mset.store == nil {
+		return false
+	}
+	var smv StoreMsg
+	sm, err := o.mset.store.LoadMsg(seq, &smv)
+	if err != nil || sm == nil {
+		return false
+	}
+	return slices.ContainsFunc(o.cfg.NoRedeliverSubjects, func(subj string) bool {
+		return subjectIsSubsetMatch(sm.subj, subj)
+	})
 }
 
 func (o *consumer) removeRedeliveredBelow(seq uint64) {
@@ -3437,16 +4487,65 @@ func (o *consumer) clearInitialInfo() {
 	o.mu.Unlock()
 }
 
+// Returns the config fields that were defaulted by the server.
+// These are not persisted with the consumer's store, so a standalone
+// server only knows about them for consumers created or updated since
+// it started. In clustered mode they are kept in the consumer assignment.
+func (o *consumer) defaultedFields() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return slices.Clone(o.dflt)
+}
+
+// Records the config fields that were defaulted by the server.
+func (o *consumer) setDefaultedFields(fields []string) {
+	o.mu.Lock()
+	o.dflt = fields
+	o.mu.Unlock()
+}
+
+// createWarnings returns the warnings for a create or update response, the
+// given warning about the update followed by the adjustments the server made
+// to the config.
+func (o *consumer) createWarnings(warning string) []string {
+	var warnings []string
+	if warning != _EMPTY_ {
+		warnings = append(warnings, warning)
+	}
+	o.mu.RLock()
+	mset, acc, s, cfg, fields := o.mset, o.acc, o.srv, o.cfg, o.dflt
+	o.mu.RUnlock()
+	if len(fields) == 0 || mset == nil || acc == nil || s == nil {
+		return warnings
+	}
+	// The default max ack pending was capped if below the default and not taken
+	// from the stream's consumer limits, which take precedence.
+	var capped bool
+	scfg := mset.config()
+	if scfg.ConsumerLimits.MaxAckPending <= 0 {
+		if accLim, _, _, _ := acc.selectLimits(cfg.replicas(&scfg)); accLim != nil {
+			limit := defaultMaxAckPending(&s.getOpts().JetStreamLimits, accLim)
+			capped = limit < JsDefaultMaxAckPending && cfg.MaxAckPending == limit
+		}
+	}
+	return append(warnings, consumerDefaultedWarnings(fields, &cfg, capped)...)
+}
+
 // Info returns our current consumer state.
 func (o *consumer) info() *ConsumerInfo {
 	return o.infoWithSnap(false)
 }
 
+// infoWithCluster returns the consumer info, leaving out the cluster details if not wanted.
+func (o *consumer) infoWithCluster(cluster bool) *ConsumerInfo {
+	return o.infoWithSnapAndReply(false, _EMPTY_, cluster)
+}
+
 func (o *consumer) infoWithSnap(snap bool) *ConsumerInfo {
-	return o.infoWithSnapAndReply(snap, _EMPTY_)
+	return o.infoWithSnapAndReply(snap, _EMPTY_, true)
 }
 
-func (o *consumer) infoWithSnapAndReply(snap bool, reply string) *ConsumerInfo {
+func (o *consumer) infoWithSnapAndReply(snap bool, reply string, cluster bool) *ConsumerInfo {
 	o.mu.Lock()
 	mset := o.mset
 	if o.closed || mset == nil || mset.srv == nil {
@@ -3507,6 +4606,7 @@ func (o *consumer) infoWithSnapAndReply(snap bool, reply string) *ConsumerInfo {
 		NumRedelivered: len(o.rdc),
 		NumPending:     np,
 		PushBound:      o.isPushMode() && o.active,
+		CaughtUp:       o.caughtUp,
 		TimeStamp:      time.Now().UTC(),
 		PriorityGroups: priorityGroups,
 	}
@@ -3521,6 +4621,28 @@ func (o *consumer) infoWithSnapAndReply(snap bool, reply string) *ConsumerInfo {
 			info.PauseRemaining = time.Until(p)
 		}
 	}
+	if end := quietWindowEnd(o.cfg.RedeliveryQuietWindows, time.Now()); !end.IsZero() {
+		info.RedeliveryQuiet, info.RedeliveryQuietRemaining = true, time.Until(end)
+	}
+	if o.qpaused {
+		info.Paused = true
+	}
+	if o.maxpab > 0 {
+		info.PendingAckBytes = o.pendingAckBytes()
+	}
+	if o.node != nil {
+		info.QuorumDeliveryLatency = o.quorumDeliveryLatency()
+	}
+	info.NumSkipped = o.nskipped
+	if o.cfg.DeliveryQuotaBytes > 0 || o.cfg.DeliveryQuotaMsgs > 0 {
+		info.DeliveryQuotaRemaining = &DeliveryQuotaRemaining{}
+		if o.cfg.DeliveryQuotaBytes > 0 {
+			info.DeliveryQuotaRemaining.Bytes = max(o.cfg.DeliveryQuotaBytes-o.qbytes, 0)
+		}
+		if o.cfg.DeliveryQuotaMsgs > 0 {
+			info.DeliveryQuotaRemaining.Msgs = max(o.cfg.DeliveryQuotaMsgs-o.qmsgs, 0)
+		}
+	}
 
 	// We always need to pull certain data from our store.
 	if o.store != nil {
@@ -3567,7 +4689,7 @@ func (o *consumer) infoWithSnapAndReply(snap bool, reply string) *ConsumerInfo {
 	o.mu.Unlock()
 
 	// Do cluster.
-	if rg != nil {
+	if rg != nil && cluster {
 		info.Cluster = js.clusterInfo(rg)
 	}
 
@@ -3697,7 +4819,7 @@ func (o *consumer) processAckMsgLocked(sseq, dseq, dc uint64, reply string, doSa
 			if doSample {
 				o.sampleAck(sseq, dseq, dc)
 			}
-			if o.maxp > 0 && len(o.pending) >= o.maxp {
+			if o.maxPendingReached() {
 				needSignal = true
 			}
 			delete(o.pending, sseq)
@@ -3714,7 +4836,7 @@ func (o *consumer) processAckMsgLocked(sseq, dseq, dc uint64, reply string, doSa
 			// Return true to let caller respond back to the client.
 			return true
 		}
-		if o.maxp > 0 && len(o.pending) >= o.maxp {
+		if o.maxPendingReached() {
 			needSignal = true
 		}
 		sgap = sseq - o.asflr
@@ -3774,6 +4896,47 @@ func (o *consumer) processAckMsgLocked(sseq, dseq, dc uint64, reply string, doSa
 	return ackInPlace
 }
 
+// processAckBatch acks the pending messages covered by the ack batch of the
+// message with the given stream sequence, i.e. those delivered before it and
+// within AckBatchSize and AckBatchWindow of its delivery. AckAll already
+// covers all prior messages, so this only applies to AckExplicit.
+func (o *consumer) processAckBatch(sseq uint64) {
+	o.mu.RLock()
+	size, window := o.cfg.AckBatchSize, o.cfg.AckBatchWindow
+	if o.cfg.AckPolicy != AckExplicit || (size == 0 && window == 0) {
+		o.mu.RUnlock()
+		return
+	}
+	p, ok := o.pending[sseq]
+	if !ok {
+		o.mu.RUnlock()
+		return
+	}
+	var batch []uint64
+	for seq, bp := range o.pending {
+		if bp.Sequence >= p.Sequence {
+			continue
+		}
+		if size > 0 && p.Sequence-bp.Sequence >= uint64(size) {
+			continue
+		}
+		if window > 0 && p.Timestamp-bp.Timestamp > int64(window) {
+			continue
+		}
+		batch = append(batch, seq)
+	}
+	dseqs, dcs := make([]uint64, len(batch)), make([]uint64, len(batch))
+	slices.Sort(batch)
+	for i, seq := range batch {
+		dseqs[i], dcs[i] = o.pending[seq].Sequence, o.deliveryCount(seq)
+	}
+	o.mu.RUnlock()
+
+	for i, seq := range batch {
+		o.processAckMsg(seq, dseqs[i], dcs[i], _EMPTY_, false)
+	}
+}
+
 // Lock should be held.
 func (o *consumer) moveAckFloor(dseq, sseq uint64) {
 	// Only move floors if we matched an existing pending.
@@ -3797,6 +4960,10 @@ func (o *consumer) moveAckFloor(dseq, sseq uint64) {
 // even if the stream only has a single non-wildcard subject designation.
 // Read lock should be held.
 func (o *consumer) isFiltered() bool {
+	// Excluding subjects always filters out some of the stream's messages.
+	if o.excludes != nil {
+		return true
+	}
 	if o.subjf == nil {
 		return false
 	}
@@ -3972,10 +5139,14 @@ type waitingRequest struct {
 	b             int // For max bytes tracking
 	expires       time.Time
 	received      time.Time
+	la            time.Time // Last activity, used for the idle timeout.
+	ld            int       // Num delivered at last activity.
 	hb            time.Duration
 	hbt           time.Time
 	noWait        bool
 	priorityGroup *PriorityGroup
+	pinPri        int64  // Pin priority hint, see JSPullRequestPinPriority.
+	cid           uint64 // Connection the request was received on.
 }
 
 // sync.Pool for waiting requests.
@@ -4298,6 +5469,7 @@ func (o *consumer) assignNewPinId(wr *waitingRequest) {
 	}
 	o.currentPinId = nuid.Next()
 	o.pinnedTS = time.Now().UTC()
+	o.pinPri = wr.pinPri
 	wr.priorityGroup.Id = o.currentPinId
 	o.setPinnedTimer(wr.priorityGroup.Group)
 	o.sendPinnedAdvisoryLocked(wr.priorityGroup.Group)
@@ -4307,6 +5479,7 @@ func (o *consumer) assignNewPinId(wr *waitingRequest) {
 func (o *consumer) unassignPinId() {
 	o.currentPinId = _EMPTY_
 	o.pinnedTS = time.Time{}
+	o.pinPri = 0
 	if o.pinnedTtl != nil {
 		o.pinnedTtl.Stop()
 		o.pinnedTtl = nil
@@ -4467,13 +5640,15 @@ func (o *consumer) nextWaiting(sz int) *waitingRequest {
 
 // Next message request.
 type nextMsgReq struct {
-	reply string
-	msg   []byte
+	reply  string
+	msg    []byte
+	pinPri int64
+	cid    uint64 // Connection the request was received on.
 }
 
 var nextMsgReqPool sync.Pool
 
-func newNextMsgReq(reply string, msg []byte) *nextMsgReq {
+func newNextMsgReq(reply string, msg []byte, pinPri int64, cid uint64) *nextMsgReq {
 	var nmr *nextMsgReq
 	m := nextMsgReqPool.Get()
 	if m != nil {
@@ -4484,7 +5659,7 @@ func newNextMsgReq(reply string, msg []byte) *nextMsgReq {
 	// When getting something from a pool it is critical that all fields are
 	// initialized. Doing this way guarantees that if someone adds a field to
 	// the structure, the compiler will fail the build if this line is not updated.
-	(*nmr) = nextMsgReq{reply, msg}
+	(*nmr) = nextMsgReq{reply, msg, pinPri, cid}
 	return nmr
 }
 
@@ -4492,7 +5667,7 @@ func (nmr *nextMsgReq) returnToPool() {
 	if nmr == nil {
 		return
 	}
-	nmr.reply, nmr.msg = _EMPTY_, nil
+	nmr.reply, nmr.msg, nmr.pinPri, nmr.cid = _EMPTY_, nil, 0, 0
 	nextMsgReqPool.Put(nmr)
 }
 
@@ -4517,7 +5692,12 @@ func (o *consumer) processNextMsgReq(_ *subscription, c *client, _ *Account, _,
 		o.outq.send(newJSPubMsg(reply, _EMPTY_, _EMPTY_, hdr, nil, nil, 0))
 		return
 	}
-	o.nextMsgReqs.push(newNextMsgReq(reply, copyBytes(msg)))
+	// Priority hint used to steal the pin, invalid values are ignored.
+	var pinPri int64
+	if v := sliceHeader(JSPullRequestPinPriority, hdr); len(v) > 0 {
+		pinPri = max(parseInt64(v), 0)
+	}
+	o.nextMsgReqs.push(newNextMsgReq(reply, copyBytes(msg), pinPri, c.cid))
 }
 
 // processResetReq will reset a consumer to a new starting sequence.
@@ -4556,7 +5736,7 @@ func (o *consumer) processResetReq(_ *subscription, c *client, a *Account, _, re
 	}
 }
 
-func (o *consumer) processNextMsgRequest(reply string, msg []byte) {
+func (o *consumer) processNextMsgRequest(reply string, msg []byte, pinPri int64, cid uint64) {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
@@ -4613,6 +5793,7 @@ func (o *consumer) processNextMsgRequest(reply string, msg []byte) {
 		}
 	}
 
+	var stealPin bool
 	if priorityGroup != nil && o.cfg.PriorityPolicy != PriorityNone {
 		if priorityGroup.Group == _EMPTY_ {
 			sendErr(400, "Bad Request - Priority Group missing")
@@ -4625,7 +5806,10 @@ func (o *consumer) processNextMsgRequest(reply string, msg []byte) {
 
 		if o.currentPinId != _EMPTY_ {
 			if priorityGroup.Id == o.currentPinId {
+				o.pinPri = pinPri
 				o.setPinnedTimer(priorityGroup.Group)
+			} else if o.cfg.PinStealPolicy == PinStealAllowHigherPriority && pinPri > o.pinPri {
+				stealPin = true
 			} else if priorityGroup.Id != _EMPTY_ {
 				sendErr(423, "Nats-Pin-Id mismatch")
 				return
@@ -4670,8 +5854,10 @@ func (o *consumer) processNextMsgRequest(reply string, msg []byte) {
 	// Create a waiting request.
 	wr := wrPool.Get().(*waitingRequest)
 	wr.acc, wr.interest, wr.reply, wr.n, wr.d, wr.noWait, wr.expires, wr.hb, wr.hbt, wr.priorityGroup = acc, interest, reply, batchSize, 0, noWait, expires, hb, hbt, priorityGroup
+	wr.pinPri, wr.cid = pinPri, cid
 	wr.b = maxBytes
 	wr.received = time.Now()
+	wr.la, wr.ld = wr.received, 0
 
 	if o.cfg.PriorityPolicy == PriorityPrioritized {
 		if err := o.waiting.addPrioritized(wr); err != nil {
@@ -4693,6 +5879,12 @@ func (o *consumer) processNextMsgRequest(reply string, msg []byte) {
 			return
 		}
 	}
+	// Take over the pin, pending requests of the previous pinned client will be rejected.
+	if stealPin {
+		o.unassignPinId()
+		o.sendUnpinnedAdvisoryLocked(priorityGroup.Group, "stolen")
+		o.assignNewPinId(wr)
+	}
 	o.signalNewMessages()
 	// If we are clustered update our followers about this request.
 	if o.node != nil {
@@ -4741,6 +5933,96 @@ func (o *consumer) incDeliveryCount(sseq uint64) uint64 {
 	return o.rdc[sseq] + 1
 }
 
+// A message gathered for delivery ordered by timestamp.
+type tsOrderedMsg struct {
+	pmsg *jsPubMsg
+	dc   uint64
+	ts   int64
+}
+
+// Returns the publish timestamp of the message, from the Nats-Time-Stamp
+// header when present and valid, otherwise the stored timestamp.
+func publishTimestamp(pmsg *jsPubMsg) int64 {
+	if len(pmsg.hdr) > 0 {
+		if v := sliceHeader(JSTimeStamp, pmsg.hdr); len(v) > 0 {
+			if ts, err := time.Parse(time.RFC3339Nano, string(v)); err == nil {
+				return ts.UnixNano()
+			}
+		}
+	}
+	return pmsg.ts
+}
+
+// Returns the next message to deliver when ordering by timestamp. Once the gathered
+// batch is drained, up to MaxRequestBatch messages are gathered and sorted by publish timestamp.
+// Lock should be held.
+func (o *consumer) getNextMsgByTimestamp() (*jsPubMsg, uint64, error) {
+	if len(o.tsq) == 0 {
+		limit := o.cfg.MaxRequestBatch
+		// Do not gather more than what max ack pending allows to be delivered.
+		if o.maxp > 0 {
+			limit = min(limit, o.maxp-len(o.pending))
+		}
+		var err error
+		for len(o.tsq) < limit {
+			pmsg, dc, gerr := o.getNextMsg()
+			if o.closed || o.mset == nil {
+				if pmsg != nil {
+					pmsg.returnToPool()
+				}
+				return nil, 0, errBadConsumer
+			}
+			if gerr != nil || pmsg == nil {
+				err = gerr
+				break
+			}
+			o.tsq = append(o.tsq, &tsOrderedMsg{pmsg, dc, publishTimestamp(pmsg)})
+		}
+		if len(o.tsq) == 0 {
+			if err == nil {
+				err = errMaxAckPending
+			}
+			return nil, 0, err
+		}
+		slices.SortStableFunc(o.tsq, func(a, b *tsOrderedMsg) int { return cmp.Compare(a.ts, b.ts) })
+	}
+	m := o.tsq[0]
+	o.tsq[0] = nil
+	if o.tsq = o.tsq[1:]; len(o.tsq) == 0 {
+		o.tsq = nil
+	}
+	return m.pmsg, m.dc, nil
+}
+
+// Puts a message that could not be delivered back in front of the ordered batch.
+// Lock should be held.
+func (o *consumer) requeueByTimestamp(pmsg *jsPubMsg, dc uint64) {
+	o.tsq = append([]*tsOrderedMsg{{pmsg, dc, publishTimestamp(pmsg)}}, o.tsq...)
+}
+
+// Moves the messages of the ordered batch back to the redelivery queue,
+// so they are delivered again, e.g. when the stream gets purged.
+// Lock should be held.
+func (o *consumer) returnOrderedMsgs() {
+	for _, m := range o.tsq {
+		if !o.onRedeliverQueue(m.pmsg.seq) {
+			o.decDeliveryCount(m.pmsg.seq)
+			o.addToRedeliverQueue(m.pmsg.seq)
+		}
+		m.pmsg.returnToPool()
+	}
+	o.tsq = nil
+}
+
+// Drops the messages of the ordered batch.
+// Lock should be held.
+func (o *consumer) clearOrderedMsgs() {
+	for _, m := range o.tsq {
+		m.pmsg.returnToPool()
+	}
+	o.tsq = nil
+}
+
 // Used if we have to adjust on failed delivery or bad lookups.
 // Those failed attempts should not increase deliver count.
 // Lock should be held.
@@ -4767,11 +6049,36 @@ func (o *consumer) notifyDeliveryExceeded(sseq, dc uint64) {
 	}
 
 	o.sendAdvisory(o.deliveryExcEventT, e)
+	o.sendToDeadLetter(sseq, dc)
+}
+
+// Republishes a message that exhausted its max deliveries to the dead letter subject, if any.
+// Lock should be held.
+func (o *consumer) sendToDeadLetter(sseq, dc uint64) {
+	if o.cfg.DeadLetterSubject == _EMPTY_ || o.mset == nil || o.mset.store == nil {
+		return
+	}
+	var smv StoreMsg
+	sm, err := o.mset.store.LoadMsg(sseq, &smv)
+	if err != nil || sm == nil {
+		o.srv.Warnf("Unable to load message %d to send to dead letter subject for consumer '%s > %s > %s': %v",
+			sseq, o.acc, o.stream, o.name, err)
+		return
+	}
+	hdr := genHeader(sm.hdr, JSStream, o.stream)
+	hdr = genHeader(hdr, JSSubject, sm.subj)
+	hdr = genHeader(hdr, JSSequence, strconv.FormatUint(sseq, 10))
+	hdr = genHeader(hdr, JSDeadLetterConsumer, o.name)
+	hdr = genHeader(hdr, JSDeadLetterDeliveries, strconv.FormatUint(dc, 10))
+	o.outq.send(newJSPubMsg(o.cfg.DeadLetterSubject, _EMPTY_, _EMPTY_, hdr, copyBytes(sm.msg), nil, sseq))
 }
 
 // Check if the candidate subject matches a filter if its present.
 // Lock should be held.
 func (o *consumer) isFilteredMatch(subj string) bool {
+	if o.isExcludedSubject(subj) {
+		return false
+	}
 	// No filter is automatic match.
 	if o.subjf == nil {
 		return true
@@ -4793,6 +6100,107 @@ func (o *consumer) isFilteredMatch(subj string) bool {
 	return false
 }
 
+// Sets up the sublist used to check for excluded subjects.
+// Lock should be held.
+func (o *consumer) setExcludeSubjects(subjects []string) {
+	if len(subjects) == 0 {
+		o.excludes = nil
+		return
+	}
+	o.excludes = gsl.NewSublist[struct{}]()
+	for _, subj := range subjects {
+		o.excludes.Insert(subj, struct{}{})
+	}
+}
+
+// Check if the subject is one of the excluded subjects.
+// Lock should be held.
+func (o *consumer) isExcludedSubject(subj string) bool {
+	return o.excludes != nil && o.excludes.HasInterest(subj)
+}
+
+// Number of excluded messages skipped one by one before looking
+// for the next message with a load filtered on the non excluded subjects.
+const maxExcludedSkips = 32
+
+// Loads the next message from the given sequence that matches our filters but none of
+// the excluded subjects. The load is filtered on the subjects currently in the stream
+// that are not excluded, so any number of excluded messages is skipped at once.
+// Lock should be held.
+func (o *consumer) loadNextNotExcluded(fseq uint64, smp *StoreMsg) (*StoreMsg, uint64, error) {
+	store := o.mset.store
+	filters := []string{fwcs}
+	if len(o.subjf) > 0 {
+		filters = o.subjf.subjects()
+	}
+	sl := gsl.NewSublist[struct{}]()
+	for _, filter := range filters {
+		for subj := range store.SubjectsTotals(filter) {
+			if !o.isExcludedSubject(subj) {
+				sl.Insert(subj, struct{}{})
+			}
+		}
+	}
+	if sl.Count() == 0 {
+		// Everything left is excluded.
+		var ss StreamState
+		store.FastState(&ss)
+		return nil, ss.LastSeq, ErrStoreEOF
+	}
+	return store.LoadNextMsgMulti(sl, fseq, smp)
+}
+
+// Check if the message headers carry any of the skip header values.
+// Lock should be held.
+func (o *consumer) hasSkipHeader(hdr []byte) bool {
+	if len(o.cfg.SkipHeaders) == 0 || len(hdr) == 0 {
+		return false
+	}
+	for k, v := range o.cfg.SkipHeaders {
+		if val := sliceHeader(k, hdr); val != nil && string(val) == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Accounts for a message skipped due to its headers, as if it was delivered and acked.
+// Excluded subjects are not counted in num pending, skipped messages are.
+// Lock should be held.
+func (o *consumer) skipMsg(sseq uint64) {
+	o.nskipped++
+	if o.npc > 0 {
+		o.npc--
+	}
+	// Without anything pending the ack floor moves past the skipped message.
+	if len(o.pending) == 0 {
+		o.adflr, o.asflr = o.dseq-1, sseq
+	}
+	// Interest and work queue streams need to know this message is not needed by us anymore.
+	if o.retention != LimitsPolicy && o.mset != nil && o.mset.ackq != nil {
+		o.mset.ackq.push(sseq)
+	}
+}
+
+// Returns the exclude subjects that do not overlap any of the filter subjects, or the stream's
+// subjects when not filtered, so will not exclude anything.
+func noOpExcludeSubjects(config *ConsumerConfig, streamSubjects []string) []string {
+	filters := gatherSubjectFilters(config.FilterSubject, config.FilterSubjects)
+	if len(filters) == 0 {
+		filters = streamSubjects
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	var noop []string
+	for _, subj := range config.ExcludeSubjects {
+		if !slices.ContainsFunc(filters, func(filter string) bool { return SubjectsCollide(subj, filter) }) {
+			noop = append(noop, subj)
+		}
+	}
+	return noop
+}
+
 // Check if the candidate filter subject is equal to or a subset match
 // of one of the filter subjects.
 // Lock should be held.
@@ -4812,6 +6220,30 @@ func (o *consumer) isEqualOrSubsetMatch(subj string) bool {
 	return false
 }
 
+// Approximate memory used per entry of the pending, redelivery count and
+// redelivery queue structures, including map overhead.
+const (
+	pendingAckEntrySize = 64
+	rdcEntrySize        = 32
+	rdqEntrySize        = 8
+)
+
+// pendingAckBytes returns the approximate memory held by the pending-ack state.
+// Lock should be held.
+func (o *consumer) pendingAckBytes() int64 {
+	return int64(len(o.pending))*pendingAckEntrySize + int64(len(o.rdc))*rdcEntrySize + int64(len(o.rdq))*rdqEntrySize
+}
+
+// maxPendingReached returns true if either MaxAckPending or the server's
+// max_consumer_pending_bytes limit has been reached, and delivery of new messages should stall.
+// Lock should be held.
+func (o *consumer) maxPendingReached() bool {
+	if o.maxp > 0 && len(o.pending) >= o.maxp {
+		return true
+	}
+	return o.maxpab > 0 && len(o.pending) > 0 && o.pendingAckBytes() >= o.maxpab
+}
+
 var (
 	errMaxAckPending = errors.New("max ack pending reached")
 	errBadConsumer   = errors.New("consumer not valid")
@@ -4871,7 +6303,7 @@ func (o *consumer) getNextMsg() (*jsPubMsg, uint64, error) {
 	}
 
 	// Check if we have max pending.
-	if o.maxp > 0 && len(o.pending) >= o.maxp {
+	if o.maxPendingReached() {
 		// maxp only set when ack policy != AckNone and user set MaxAckPending
 		// Stall if we have hit max pending.
 		return nil, 0, errMaxAckPending
@@ -4914,12 +6346,43 @@ func (o *consumer) getNextMsg() (*jsPubMsg, uint64, error) {
 		// No filter here.
 		sm, sseq, err = o.mset.store.LoadNextMsg(_EMPTY_, false, fseq, &pmsg.StoreMsg)
 	}
+	// Skip over excluded messages, and the ones with skip headers.
+	// Long runs of excluded messages are skipped with a single load.
+	for excluded := 0; sm != nil && (o.isExcludedSubject(sm.subj) || o.hasSkipHeader(sm.hdr)); {
+		if !o.isExcludedSubject(sm.subj) {
+			o.skipMsg(sseq)
+		} else {
+			excluded++
+		}
+		fseq = sseq + 1
+		if excluded > maxExcludedSkips {
+			sm, sseq, err = o.loadNextNotExcluded(fseq, &pmsg.StoreMsg)
+		} else if filters != nil {
+			sm, sseq, err = o.mset.store.LoadNextMsgMulti(filters, fseq, &pmsg.StoreMsg)
+		} else if len(subjf) > 0 {
+			filter, wc := subjf[0].subject, subjf[0].hasWildcard
+			sm, sseq, err = o.mset.store.LoadNextMsg(filter, wc, fseq, &pmsg.StoreMsg)
+		} else {
+			sm, sseq, err = o.mset.store.LoadNextMsg(_EMPTY_, false, fseq, &pmsg.StoreMsg)
+		}
+		// Make sure to move past the excluded messages, even if there is nothing after them.
+		if sm == nil && sseq < fseq {
+			sseq = fseq - 1
+		}
+	}
 	if sm == nil {
 		pmsg.returnToPool()
 		pmsg = nil
 	}
 	// Check if we should move our o.sseq.
 	if sseq >= o.sseq {
+		// Count the sequences we passed over, announced with the next delivery.
+		if o.cfg.AnnounceGaps {
+			o.gap += sseq - o.sseq
+			if sm == nil {
+				o.gap++
+			}
+		}
 		// If we are moving step by step then sseq == o.sseq.
 		// If we have jumped we should update skipped for other replicas.
 		if sseq != o.sseq && err == ErrStoreEOF {
@@ -4942,6 +6405,7 @@ func (o *consumer) processWaiting(eos bool) (int, int, int, time.Time) {
 
 	var expired, brp int
 	s, now := o.srv, time.Now()
+	idleTimeout := o.cfg.WaitingRequestIdleTimeout
 
 	wq := o.waiting
 	remove := func(pre, wr *waitingRequest) *waitingRequest {
@@ -4959,6 +6423,13 @@ func (o *consumer) processWaiting(eos bool) (int, int, int, time.Time) {
 	for wr := wq.head; wr != nil; {
 		// Check expiration.
 		expires := !wr.expires.IsZero() && now.After(wr.expires)
+		// Deliveries count as activity for the idle timeout.
+		if wr.d != wr.ld {
+			wr.la, wr.ld = now, wr.d
+		}
+		if idleTimeout > 0 && now.Sub(wr.la) >= idleTimeout {
+			expires = true
+		}
 		if (eos && wr.noWait) || expires {
 			rdWait := o.replicateDeliveries()
 			if rdWait {
@@ -5023,6 +6494,11 @@ func (o *consumer) processWaiting(eos bool) (int, int, int, time.Time) {
 		if !wr.expires.IsZero() && (fexp.IsZero() || wr.expires.Before(fexp)) {
 			fexp = wr.expires
 		}
+		if idleTimeout > 0 {
+			if idle := wr.la.Add(idleTimeout); fexp.IsZero() || idle.Before(fexp) {
+				fexp = idle
+			}
+		}
 		// Update pre and wr here.
 		pre = wr
 		wr = wr.next
@@ -5174,7 +6650,7 @@ func (o *consumer) processInboundAcks(qch chan struct{}) {
 
 			acks := o.ackMsgs.pop()
 			for _, ack := range acks {
-				o.processAck(ack.subject, ack.reply, ack.hdr, ack.msg)
+				o.processAck(ack.subject, ack.reply, ack.hdr, ack.msg, ack.cid)
 				ack.returnToPool()
 			}
 			o.ackMsgs.recycle(&acks)
@@ -5200,7 +6676,7 @@ func (o *consumer) processInboundNextMsgReqs(qch chan struct{}) {
 		case <-o.nextMsgReqs.ch:
 			reqs := o.nextMsgReqs.pop()
 			for _, req := range reqs {
-				o.processNextMsgRequest(req.reply, req.msg)
+				o.processNextMsgRequest(req.reply, req.msg, req.pinPri, req.cid)
 				req.returnToPool()
 			}
 			o.nextMsgReqs.recycle(&reqs)
@@ -5302,24 +6778,34 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 		err = nil
 
 		// If the consumer is paused then stop sending.
-		if o.cfg.PauseUntil != nil && !o.cfg.PauseUntil.IsZero() && time.Now().Before(*o.cfg.PauseUntil) {
+		if o.qpaused || o.cfg.PauseUntil != nil && !o.cfg.PauseUntil.IsZero() && time.Now().Before(*o.cfg.PauseUntil) {
 			// If the consumer is paused and we haven't reached the deadline yet then
 			// go back to waiting.
+			o.traceDelivery(deliverTracePaused, nil, 0)
 			goto waitForMsgs
 		}
 
 		// If we are in push mode and not active or under flowcontrol let's stop sending.
 		if o.isPushMode() {
-			if !o.active || (o.maxpb > 0 && o.pbytes > o.maxpb) {
+			if !o.active {
+				o.traceDelivery(deliverTraceNoInterest, nil, 0)
+				goto waitForMsgs
+			} else if o.maxpb > 0 && o.pbytes > o.maxpb {
+				o.traceDelivery(deliverTraceFlowControl, nil, 0)
 				goto waitForMsgs
 			}
 		} else if o.waiting.isEmpty() {
 			// If we are in pull mode and no one is waiting already break and wait.
+			o.traceDelivery(deliverTraceNoPullRequests, nil, 0)
 			goto waitForMsgs
 		}
 
 		// Grab our next msg.
-		pmsg, dc, err = o.getNextMsg()
+		if o.cfg.OrderByTimestamp {
+			pmsg, dc, err = o.getNextMsgByTimestamp()
+		} else {
+			pmsg, dc, err = o.getNextMsg()
+		}
 
 		// We can release the lock now under getNextMsg so need to check this condition again here.
 		if o.closed || o.mset == nil {
@@ -5332,10 +6818,20 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 			// On EOF we can optionally fast sync num pending state.
 			if err == ErrStoreEOF {
 				o.checkNumPendingOnEOF()
+				// Signal once we have caught up, if requested.
+				if o.cfg.SignalCaughtUp && !o.caughtUp && o.isPushMode() && o.numPending() == 0 {
+					o.sendCaughtUp(o.dsubj)
+				}
 			}
 			if err == ErrStoreMsgNotFound || err == errDeletedMsg || err == ErrStoreEOF || err == errMaxAckPending {
+				if err == errMaxAckPending {
+					o.traceDelivery(deliverTraceMaxAckPending, nil, 0)
+				} else {
+					o.traceDelivery(deliverTraceNoMessages, nil, 0)
+				}
 				goto waitForMsgs
 			} else {
+				o.traceDelivery(deliverTraceLookupError, pmsg, dc)
 				if pmsg != nil {
 					s.Errorf("Received an error looking up message with sequence %d for consumer '%s > %s > %s': %v",
 						pmsg.seq, o.mset.acc, stream, o.cfg.Name, err)
@@ -5368,6 +6864,12 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 		} else if wr := o.nextWaiting(sz); wr != nil {
 			wrn, wrb = wr.n, wr.b
 			dsubj = wr.reply
+			if o.cfg.AckSameConnection {
+				if o.pconn == nil {
+					o.pconn = make(map[uint64]uint64)
+				}
+				o.pconn[pmsg.seq] = wr.cid
+			}
 			if o.cfg.PriorityPolicy == PriorityPinnedClient {
 				pmsg.hdr = genHeader(pmsg.hdr, JSPullRequestNatsPinId, o.currentPinId)
 				pmsg.buf = append(pmsg.hdr, pmsg.msg...)
@@ -5383,7 +6885,16 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 			// Need to also test that this is not going backwards since if
 			// we fail to deliver we can end up here from rdq but we do not
 			// want to decrement o.sseq if that is the case.
-			if dc == 1 && pmsg.seq == o.sseq-1 {
+			if o.cfg.OrderByTimestamp {
+				// Keep its place in the ordered batch.
+				if dc == 1 {
+					o.npc++
+				}
+				o.requeueByTimestamp(pmsg, dc)
+				o.traceDelivery(deliverTraceNoRequestFit, pmsg, dc)
+				pmsg = nil
+				goto waitForMsgs
+			} else if dc == 1 && pmsg.seq == o.sseq-1 {
 				o.sseq--
 				o.npc++
 			} else if !o.onRedeliverQueue(pmsg.seq) {
@@ -5392,14 +6903,24 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 				o.decDeliveryCount(pmsg.seq)
 				o.addToRedeliverQueue(pmsg.seq)
 			}
+			o.traceDelivery(deliverTraceNoRequestFit, pmsg, dc)
 			pmsg.returnToPool()
 			pmsg = nil
 			goto waitForMsgs
 		}
 
+		// Announce the sequences passed over since the previous delivered message.
+		if o.cfg.AnnounceGaps && dc == 1 && o.gap > 0 {
+			pmsg.hdr = genHeader(pmsg.hdr, JSConsumerGap, strconv.FormatUint(o.gap, 10))
+			pmsg.buf = append(pmsg.hdr, pmsg.msg...)
+			sz = len(pmsg.subj) + len(ackReply) + len(pmsg.hdr) + len(pmsg.msg)
+			o.gap = 0
+		}
+
 		// If we are in a replay scenario and have not caught up check if we need to delay here.
 		if o.replay && lts > 0 {
-			if delay = time.Duration(pmsg.ts - lts); delay > time.Millisecond {
+			if delay = o.replayDelay(pmsg.ts, lts); delay > time.Millisecond {
+				o.traceDelivery(deliverTraceReplayDelay, pmsg, dc)
 				o.mu.Unlock()
 				select {
 				case <-qch:
@@ -5421,6 +6942,7 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 			r := o.rlimit.ReserveN(now, sz)
 			delay := r.DelayFrom(now)
 			if delay > 0 {
+				o.traceDelivery(deliverTraceRateLimited, pmsg, dc)
 				o.mu.Unlock()
 				select {
 				case <-qch:
@@ -5434,6 +6956,7 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 		}
 
 		// Do actual delivery.
+		o.traceDelivery(deliverTraceDelivered, pmsg, dc)
 		o.deliverMsg(dsubj, ackReply, pmsg, dc, rp)
 
 		// If given request fulfilled batch size, but there are still pending bytes, send information about it.
@@ -5515,6 +7038,15 @@ func (o *consumer) sendIdleHeartbeat(subj string) {
 	o.outq.send(newJSPubMsg(subj, _EMPTY_, _EMPTY_, hdr, nil, nil, 0))
 }
 
+// Sends the status message signaling the consumer has no more pending messages.
+// Lock should be held.
+func (o *consumer) sendCaughtUp(subj string) {
+	const t = "NATS/1.0 100 Caught Up\r\n%s: %d\r\n%s: %d\r\n\r\n"
+	hdr := fmt.Appendf(nil, t, JSLastConsumerSeq, o.dseq-1, JSLastStreamSeq, o.sseq-1)
+	o.outq.send(newJSPubMsg(subj, _EMPTY_, _EMPTY_, hdr, nil, nil, 0))
+	o.caughtUp = true
+}
+
 func (o *consumer) ackReply(sseq, dseq, dc uint64, ts int64, pending uint64) string {
 	if o.useV2Ack {
 		return fmt.Sprintf(o.ackReplyT, dc, sseq, dseq, ts, pending)
@@ -5612,12 +7144,28 @@ func (o *consumer) calculateNumPending() (npc, npf uint64, err error) {
 	filters, subjf := o.filters, o.subjf
 
 	if filters != nil {
-		return o.mset.store.NumPendingMulti(o.sseq, filters, isLastPerSubject)
+		npc, npf, err = o.mset.store.NumPendingMulti(o.sseq, filters, isLastPerSubject)
 	} else if len(subjf) > 0 {
 		filter := subjf[0].subject
-		return o.mset.store.NumPending(o.sseq, filter, isLastPerSubject)
+		npc, npf, err = o.mset.store.NumPending(o.sseq, filter, isLastPerSubject)
+	} else {
+		npc, npf, err = o.mset.store.NumPending(o.sseq, _EMPTY_, isLastPerSubject)
+	}
+	if err != nil || o.excludes == nil {
+		return npc, npf, err
 	}
-	return o.mset.store.NumPending(o.sseq, _EMPTY_, isLastPerSubject)
+	// Take out excluded messages. This is exact for exclude subjects contained within
+	// a filter subject, partially overlapping ones are not accounted for. Exclude subjects
+	// do not overlap each other, so no message is taken out twice.
+	for _, subj := range o.cfg.ExcludeSubjects {
+		if len(subjf) > 0 && !slices.ContainsFunc(subjf, func(filter *subjectFilter) bool { return subjectIsSubsetMatch(subj, filter.subject) }) {
+			continue
+		}
+		if enp, _, eerr := o.mset.store.NumPending(o.sseq, subj, isLastPerSubject); eerr == nil {
+			npc -= min(enp, npc)
+		}
+	}
+	return npc, npf, nil
 }
 
 func convertToHeadersOnly(pmsg *jsPubMsg) {
@@ -5683,6 +7231,8 @@ func (o *consumer) deliverMsg(dsubj, ackReply string, pmsg *jsPubMsg, dc uint64,
 	// Send message.
 	if o.replicateDeliveries() {
 		o.addReplicatedQueuedMsg(pmsg)
+	} else if len(o.dlanes) > 0 {
+		o.deliveryLane(pmsg.subj).send(pmsg)
 	} else {
 		o.outq.send(pmsg)
 	}
@@ -5692,6 +7242,16 @@ func (o *consumer) deliverMsg(dsubj, ackReply string, pmsg *jsPubMsg, dc uint64,
 		o.sendFlowControl()
 	}
 
+	// Delivery receipts.
+	if o.cfg.DeliveryReceiptSubject != _EMPTY_ {
+		o.sendDeliveryReceipt(seq, dseq, dc)
+	}
+
+	// Delivery quota.
+	if o.cfg.DeliveryQuotaBytes > 0 || o.cfg.DeliveryQuotaMsgs > 0 {
+		o.trackDeliveryQuota(psz)
+	}
+
 	// If pull mode and we have inactivity threshold, signaled by dthresh, update last activity.
 	if o.isPullMode() && o.dthresh > 0 {
 		o.waiting.last = time.Now()
@@ -5707,6 +7267,99 @@ func (o *consumer) deliverMsg(dsubj, ackReply string, pmsg *jsPubMsg, dc uint64,
 	}
 }
 
+// Returns the delivery lane for the subject, so that messages with the same subject are delivered in order.
+// Lock should be held.
+func (o *consumer) deliveryLane(subj string) *jsOutQ {
+	// FNV-1a hash of the subject.
+	h := uint32(2166136261)
+	for i := 0; i < len(subj); i++ {
+		h ^= uint32(subj[i])
+		h *= 16777619
+	}
+	return o.dlanes[h%uint32(len(o.dlanes))]
+}
+
+// Delivers the messages queued on a delivery lane, until the quit channel is closed.
+// Messages still queued at that point were already tracked as delivered, so they are
+// delivered before returning.
+func (o *consumer) deliveryLaneLoop(acc *Account, lane *jsOutQ, qch chan struct{}) {
+	c := o.srv.createInternalJetStreamClient()
+	c.registerWithAccount(acc)
+	defer c.closeConnection(ClientClosed)
+	defer lane.unregister()
+
+	sender := &jsOutSender{c: c}
+	for {
+		select {
+		case <-lane.ch:
+			pms := lane.pop()
+			sender.send(pms)
+			lane.recycle(&pms)
+		case <-qch:
+			if pms := lane.pop(); len(pms) > 0 {
+				sender.send(pms)
+				lane.recycle(&pms)
+			}
+			return
+		}
+	}
+}
+
+// Publishes a delivery receipt to the DeliveryReceiptSubject, for the sampled deliveries.
+// Lock should be held.
+func (o *consumer) sendDeliveryReceipt(sseq, dseq, dc uint64) {
+	if o.cfg.DeliveryReceiptSampling < 100 && rand.Intn(100) >= o.cfg.DeliveryReceiptSampling {
+		return
+	}
+	receipt, err := json.Marshal(&ConsumerDeliveryReceipt{
+		Stream:      o.stream,
+		Consumer:    o.name,
+		StreamSeq:   sseq,
+		ConsumerSeq: dseq,
+		Deliveries:  dc,
+		Timestamp:   time.Now().UTC(),
+	})
+	if err != nil {
+		return
+	}
+	o.outq.send(newJSPubMsg(o.cfg.DeliveryReceiptSubject, _EMPTY_, _EMPTY_, nil, receipt, nil, 0))
+}
+
+// Tracks a delivery against the delivery quota, pausing the consumer once reached.
+// Lock should be held.
+func (o *consumer) trackDeliveryQuota(sz int) {
+	o.qbytes += int64(sz)
+	o.qmsgs++
+	if o.qpaused {
+		return
+	}
+	if (o.cfg.DeliveryQuotaBytes > 0 && o.qbytes >= o.cfg.DeliveryQuotaBytes) ||
+		(o.cfg.DeliveryQuotaMsgs > 0 && o.qmsgs >= o.cfg.DeliveryQuotaMsgs) {
+		o.qpaused = true
+		o.sendPauseAdvisoryLocked(&o.cfg)
+	}
+}
+
+// Clears the delivery quota, resuming the consumer if it was paused by it.
+func (o *consumer) resumeDeliveryQuota() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.clearDeliveryQuota()
+}
+
+// Clears the delivery quota, resuming the consumer if it was paused by it.
+// Lock should be held.
+func (o *consumer) clearDeliveryQuota() {
+	o.qbytes, o.qmsgs = 0, 0
+	if o.qpaused {
+		o.qpaused = false
+		if o.isLeader() {
+			o.sendPauseAdvisoryLocked(&o.cfg)
+		}
+		o.signalNewMessages()
+	}
+}
+
 // replicateDeliveries returns whether deliveries should be replicated before sending them.
 // If we're replicated we MUST only send the message AFTER we've got quorum for updating
 // delivered state. Otherwise, we could be in an invalid state after a leader change.
@@ -5830,11 +7483,17 @@ func (o *consumer) trackPending(sseq, dseq uint64) {
 		p.Timestamp = now.UnixNano()
 	} else {
 		o.pending[sseq] = &Pending{dseq, now.UnixNano()}
+		if o.cfg.MaxAckAge > 0 {
+			if o.pfd == nil {
+				o.pfd = make(map[uint64]int64)
+			}
+			o.pfd[sseq] = now.UnixNano()
+		}
 	}
 
 	// We could have a backoff that set a timer higher than what we need for this message.
 	// In that case, reset to lowest backoff required for a message redelivery.
-	minDelay := o.ackWait(0)
+	minDelay := o.ackWait(o.pendingAckWait(sseq))
 	if l := len(o.cfg.BackOff); l > 0 {
 		bi := int(o.rdc[sseq])
 		if bi < 0 {
@@ -5981,6 +7640,12 @@ func (o *consumer) checkPending() {
 
 	now := time.Now().UnixNano()
 	next := int64(o.ackWait(0))
+	// Redeliveries that come due inside a quiet window are deferred until it closes.
+	var quietUntil int64
+	if end := quietWindowEnd(o.cfg.RedeliveryQuietWindows, time.Unix(0, now)); !end.IsZero() {
+		quietUntil = end.UnixNano()
+	}
+	var deferred bool
 	// However, if there is backoff, initializes with the largest backoff.
 	// It will be adjusted as needed.
 	if l := len(o.cfg.BackOff); l > 0 {
@@ -6011,7 +7676,25 @@ func (o *consumer) checkPending() {
 			}
 			continue
 		}
+		// Terminate messages pending for longer than the max ack age. Without
+		// a tracked first delivery, e.g. after a leader change, use the last one.
+		if maxAge := int64(o.cfg.MaxAckAge); maxAge > 0 {
+			first, ok := o.pfd[seq]
+			if !ok {
+				first = p.Timestamp
+			}
+			if age := now - first; age >= maxAge {
+				// We hold the lock so do this in a go routine.
+				go o.processTerm(seq, p.Sequence, o.deliveryCount(seq), ackTermMaxAckAgeReason, _EMPTY_)
+				continue
+			} else if maxAge-age < next {
+				next = maxAge - age
+			}
+		}
 		elapsed, deadline := now-p.Timestamp, ttl
+		if len(o.cfg.AckWaitPerFilter) > 0 {
+			deadline = int64(o.pendingAckWait(seq))
+		}
 		if len(o.cfg.BackOff) > 0 {
 			// This is ok even if o.rdc is nil, we would get dc == 0, which is what we want.
 			dc := int(o.rdc[seq])
@@ -6031,11 +7714,16 @@ func (o *consumer) checkPending() {
 				next = nextBackoff
 			}
 		}
-		if elapsed >= deadline {
+		if elapsed >= deadline && quietUntil > 0 {
+			deferred = true
+		} else if elapsed >= deadline {
 			// We will check if we have hit our max deliveries. Previously we would do this on getNextMsg() which
 			// worked well for push consumers, but with pull based consumers would require a new pull request to be
 			// present to process and redelivered could be reported incorrectly.
-			if !o.onRedeliverQueue(seq) && !o.hasMaxDeliveries(seq) {
+			if o.isNoRedeliverLocked(seq) {
+				// Terminate instead of redelivering, we hold the lock so do this in a go routine.
+				go o.processTerm(seq, p.Sequence, o.deliveryCount(seq), ackTermNoRedeliverReason, _EMPTY_)
+			} else if !o.onRedeliverQueue(seq) && !o.hasMaxDeliveries(seq) {
 				expired = append(expired, seq)
 			}
 		} else if deadline-elapsed < next {
@@ -6044,6 +7732,31 @@ func (o *consumer) checkPending() {
 		}
 	}
 
+	// Drop cached ack waits for messages that are no longer pending.
+	if len(o.pawt) > len(o.pending) {
+		for seq := range o.pawt {
+			if _, ok := o.pending[seq]; !ok {
+				delete(o.pawt, seq)
+			}
+		}
+	}
+	// Same for first delivery times.
+	if len(o.pfd) > len(o.pending) {
+		for seq := range o.pfd {
+			if _, ok := o.pending[seq]; !ok {
+				delete(o.pfd, seq)
+			}
+		}
+	}
+	// And for the connections messages were delivered for.
+	if len(o.pconn) > len(o.pending) {
+		for seq := range o.pconn {
+			if _, ok := o.pending[seq]; !ok {
+				delete(o.pconn, seq)
+			}
+		}
+	}
+
 	if len(expired) > 0 {
 		// We need to sort.
 		slices.Sort(expired)
@@ -6059,6 +7772,10 @@ func (o *consumer) checkPending() {
 		o.signalNewMessages()
 	}
 
+	if deferred && quietUntil-now < next {
+		next = quietUntil - now
+	}
+
 	if len(o.pending) > 0 {
 		o.resetPtmr(time.Duration(next))
 	} else {
@@ -6512,6 +8229,9 @@ func (o *consumer) purge(sseq uint64, slseq uint64, isWider bool) {
 		o.adflr, o.asflr = o.dseq-1, o.sseq-1
 	}
 
+	// Gathered messages might have been purged, so queue them for redelivery to be checked below.
+	o.returnOrderedMsgs()
+
 	// We need to remove all those being queued for redelivery under o.rdq
 	if len(o.rdq) > 0 {
 		rdq := o.rdq
@@ -6624,6 +8344,7 @@ func (o *consumer) stopWithFlags(dflag, sdflag, doSignal, advisory bool) error {
 		close(o.qch)
 		o.qch = nil
 	}
+	o.dlanes = nil
 
 	a := o.acc
 	store := o.store
@@ -6632,12 +8353,14 @@ func (o *consumer) stopWithFlags(dflag, sdflag, doSignal, advisory bool) error {
 	o.active = false
 	o.unsubscribe(o.ackSubOld)
 	o.unsubscribe(o.ackSub)
+	o.unsubscribe(o.sackSub)
 	o.unsubscribe(o.reqSub)
 	o.unsubscribe(o.resetSub)
 	o.unsubscribe(o.fcSubOld)
 	o.unsubscribe(o.fcSub)
 	o.ackSubOld = nil
 	o.ackSub = nil
+	o.sackSub = nil
 	o.reqSub = nil
 	o.resetSub = nil
 	o.fcSubOld = nil
@@ -6910,7 +8633,8 @@ func (o *consumer) signalSubs() []string {
 // We know that this subject matches us by how the parent handles registering us with the signaling sublist,
 // but we must check if we are leader.
 // We do need the sequence of the message however and we use the msg as the encoded seq.
-func (o *consumer) processStreamSignal(seq uint64) {
+// The subject is only needed to leave out messages of excluded subjects.
+func (o *consumer) processStreamSignal(subj string, seq uint64) {
 	// We can get called here now when not leader, so bail fast
 	// and without acquiring any locks.
 	if !o.leader.Load() {
@@ -6921,6 +8645,9 @@ func (o *consumer) processStreamSignal(seq uint64) {
 	if o.mset == nil {
 		return
 	}
+	if o.isExcludedSubject(subj) {
+		return
+	}
 	if seq > o.npf {
 		o.npc++
 	}
@@ -7156,6 +8883,7 @@ func (o *consumer) resetPendingDeliveries() {
 		pmsg.returnToPool()
 	}
 	o.pendingDeliveries = nil
+	o.pdts = nil
 	for _, wd := range o.waitingDeliveries {
 		wd.recycle()
 	}
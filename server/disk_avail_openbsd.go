@@ -35,3 +35,12 @@ func diskAvailable(storeDir string) int64 {
 	}
 	return ba
 }
+
+func diskSize(dir string) int64 {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		// Used 1TB default as a guess if all else fails.
+		return JetStreamMaxStoreDefault
+	}
+	return int64(uint64(fs.F_blocks) * uint64(fs.F_bsize))
+}
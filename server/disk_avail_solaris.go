@@ -17,6 +17,7 @@ package server
 
 import (
 	"os"
+
 	"golang.org/x/sys/unix"
 )
 
@@ -36,3 +37,11 @@ func diskAvailable(storeDir string) int64 {
 	return ba
 }
 
+func diskSize(dir string) int64 {
+	var fs unix.Statvfs_t
+	if err := unix.Statvfs(dir, &fs); err != nil {
+		// Used 1TB default as a guess if all else fails.
+		return JetStreamMaxStoreDefault
+	}
+	return int64(uint64(fs.Frsize) * uint64(fs.Blocks))
+}
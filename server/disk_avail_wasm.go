@@ -18,3 +18,7 @@ package server
 func diskAvailable(storeDir string) int64 {
 	return JetStreamMaxStoreDefault
 }
+
+func diskSize(dir string) int64 {
+	return JetStreamMaxStoreDefault
+}
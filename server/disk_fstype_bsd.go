@@ -0,0 +1,47 @@
+// Copyright 2026 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build darwin || freebsd
+
+package server
+
+import "syscall"
+
+// Filesystem type names, as reported by statfs(2), of network filesystems
+// that are known to cause problems for JetStream storage.
+var networkFilesystems = map[string]struct{}{
+	"nfs":    {},
+	"smbfs":  {},
+	"afpfs":  {},
+	"webdav": {},
+}
+
+// networkFilesystemType returns the type of the filesystem holding dir
+// if it is a known network filesystem, or an empty string otherwise.
+func networkFilesystemType(dir string) (string, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		return _EMPTY_, err
+	}
+	var b []byte
+	for _, c := range fs.Fstypename {
+		if c == 0 {
+			break
+		}
+		b = append(b, byte(c))
+	}
+	if _, ok := networkFilesystems[string(b)]; ok {
+		return string(b), nil
+	}
+	return _EMPTY_, nil
+}
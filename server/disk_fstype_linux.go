@@ -0,0 +1,44 @@
+// Copyright 2026 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package server
+
+import "syscall"
+
+// Filesystem magic numbers, from statfs(2), of network and cluster filesystems
+// that are known to cause problems for JetStream storage.
+var networkFilesystems = map[uint32]string{
+	0x6969:     "nfs",
+	0x517b:     "smb",
+	0xfe534d42: "smb2",
+	0xff534d42: "cifs",
+	0x00c36400: "ceph",
+	0x5346414f: "afs",
+	0x73757245: "coda",
+	0x01021997: "9p",
+	0x01161970: "gfs2",
+	0x7461636f: "ocfs2",
+	0x0bd00bd0: "lustre",
+}
+
+// networkFilesystemType returns the type of the filesystem holding dir
+// if it is a known network filesystem, or an empty string otherwise.
+func networkFilesystemType(dir string) (string, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		return _EMPTY_, err
+	}
+	return networkFilesystems[uint32(fs.Type)], nil
+}
@@ -39,6 +39,10 @@ var (
 	// due to a connection not coming from a proxy.
 	ErrAuthProxyRequired = errors.New("proxy connection required")
 
+	// ErrAuthJWTTooOld represents an error condition on failed authentication
+	// due to a user JWT issued longer than max_user_jwt_age ago, even if not expired.
+	ErrAuthJWTTooOld = errors.New("user JWT too old")
+
 	// ErrMaxPayload represents an error condition when the payload is too big.
 	ErrMaxPayload = errors.New("maximum payload exceeded")
 
@@ -119,6 +123,9 @@ var (
 	// ErrBadSampling is returned when the sampling for latency tracking is not 1 >= sample <= 100.
 	ErrBadSampling = errors.New("bad sampling percentage, should be 1-100")
 
+	// ErrLatencySubjectNotAllowed is returned when the latency results subject is not in the account's allow list.
+	ErrLatencySubjectNotAllowed = errors.New("latency subject not allowed")
+
 	// ErrAccountValidation is returned when an account has failed validation.
 	ErrAccountValidation = errors.New("account validation failed")
 
@@ -188,6 +195,10 @@ var (
 	// on if they want no responders behavior.
 	ErrNoRespondersRequiresHeaders = errors.New("no responders requires headers support")
 
+	// ErrAccountRequiresHeaders signals that a client needs to have headers
+	// on to connect to an account configured with require_header_support.
+	ErrAccountRequiresHeaders = errors.New("account requires clients with headers support")
+
 	// ErrClusterNameConfigConflict signals that the options for cluster name in cluster and gateway are in conflict.
 	ErrClusterNameConfigConflict = errors.New("cluster name conflicts between cluster and gateway definitions")
 
@@ -219,6 +230,10 @@ var (
 	// when rejecting a remote due to leafnodes.min_version.
 	ErrLeafNodeMinVersionRejected = errors.New("connection rejected since minimum version required is")
 
+	// ErrLeafNodeMaxAccountsExceeded is returned when a remote server would bind more
+	// accounts with its leafnode connections than allowed by max_accounts_per_connection.
+	ErrLeafNodeMaxAccountsExceeded = errors.New("leafnode max accounts per connection exceeded")
+
 	// ErrInvalidMappingDestination is used for all subject mapping destination errors
 	ErrInvalidMappingDestination = errors.New("invalid mapping destination")
 
@@ -94,6 +94,8 @@ const (
 
 	ocspPeerRejectEventSubj           = "$SYS.SERVER.%s.OCSP.PEER.CONN.REJECT"
 	ocspPeerChainlinkInvalidEventSubj = "$SYS.SERVER.%s.OCSP.PEER.LINK.INVALID"
+
+	maxConnSoftLimitEventSubj = "$SYS.SERVER.%s.CLIENT.MAXCONN.SOFT"
 )
 
 // FIXME(dlc) - make configurable.
@@ -171,11 +173,52 @@ type DisconnectEventMsg struct {
 	Sent     DataStats  `json:"sent"`
 	Received DataStats  `json:"received"`
 	Reason   string     `json:"reason"`
+	// LastError is the last error sent to the client, if any.
+	// Only included with the extended close advisory detail.
+	LastError string `json:"last_error,omitempty"`
+	// Subscriptions is the number of subscriptions the client had when disconnected.
+	// Only included with the extended close advisory detail.
+	Subscriptions int `json:"subscriptions,omitempty"`
 }
 
 // DisconnectEventMsgType is the schema type for DisconnectEventMsg
 const DisconnectEventMsgType = "io.nats.server.advisory.v1.client_disconnect"
 
+// CloseAdvisoryDetail is how much is included in the client disconnect advisories.
+type CloseAdvisoryDetail uint8
+
+const (
+	// CloseAdvisoryDetailBasic includes the client info, the data transferred and the reason.
+	CloseAdvisoryDetailBasic CloseAdvisoryDetail = iota
+	// CloseAdvisoryDetailExtended adds the last error sent to the client and its
+	// number of subscriptions.
+	CloseAdvisoryDetailExtended
+)
+
+// String returns the config value of the detail level.
+func (d CloseAdvisoryDetail) String() string {
+	switch d {
+	case CloseAdvisoryDetailExtended:
+		return "extended"
+	default:
+		return "basic"
+	}
+}
+
+// MaxConnSoftLimitEventMsg is sent when the number of client connections
+// reaches the max_connections_soft limit.
+type MaxConnSoftLimitEventMsg struct {
+	TypedEvent
+	Server         ServerInfo `json:"server"`
+	Connections    int        `json:"connections"`
+	SoftLimit      int        `json:"soft_limit"`
+	MaxConnections int        `json:"max_connections"`
+	LameDuck       bool       `json:"lame_duck,omitempty"`
+}
+
+// MaxConnSoftLimitEventMsgType is the schema type for MaxConnSoftLimitEventMsg
+const MaxConnSoftLimitEventMsgType = "io.nats.server.advisory.v1.max_connections_soft_limit"
+
 // OCSPPeerRejectEventMsg is sent when a peer TLS handshake is ultimately rejected due to OCSP invalidation.
 // A "peer" can be an inbound client connection or a leaf connection to a remote server. Peer in event payload
 // is always the peer's (TLS) leaf cert, which may or may be the invalid cert (See also OCSPPeerChainlinkInvalidEventMsg)
@@ -522,7 +565,7 @@ RESET:
 
 	// Grab tags and metadata.
 	opts := s.getOpts()
-	tags, metadata, featureFlags := opts.Tags, opts.Metadata, opts.getMergedFeatureFlags()
+	tags, metadata, featureFlags := opts.Tags, opts.getMergedMetadata(), opts.getMergedFeatureFlags()
 
 	for s.eventsRunning() {
 		select {
@@ -1763,12 +1806,18 @@ func (s *Server) remoteServerUpdate(sub *subscription, c *client, _ *Account, su
 		stats = ssm.Stats.JetStream.Stats
 	}
 
+	// JetStream may use a cluster name different from the routing one.
+	cluster := si.Cluster
+	if cfg != nil && cfg.ClusterName != _EMPTY_ {
+		cluster = cfg.ClusterName
+	}
+
 	node := getHash(si.Name)
 	accountNRG := si.AccountNRG()
 	oldInfo, _ := s.nodeToInfo.Swap(node, nodeInfo{
 		name:            si.Name,
 		version:         si.Version,
-		cluster:         si.Cluster,
+		cluster:         cluster,
 		domain:          si.Domain,
 		id:              si.ID,
 		tags:            si.Tags,
@@ -2599,7 +2648,7 @@ func (s *Server) accountConnectEvent(c *client) {
 
 // accountDisconnectEvent will send an account client disconnect event if there is interest.
 // This is a billing event.
-func (s *Server) accountDisconnectEvent(c *client, now time.Time, reason string) {
+func (s *Server) accountDisconnectEvent(c *client, now time.Time, reason string, subs map[string]*subscription) {
 	s.mu.Lock()
 	if !s.eventsEnabled() {
 		s.mu.Unlock()
@@ -2607,6 +2656,7 @@ func (s *Server) accountDisconnectEvent(c *client, now time.Time, reason string)
 	}
 	eid := s.nextEventID()
 	s.mu.Unlock()
+	extended := s.getOpts().CloseAdvisoryDetail == CloseAdvisoryDetailExtended
 
 	c.mu.Lock()
 
@@ -2653,6 +2703,9 @@ func (s *Server) accountDisconnectEvent(c *client, now time.Time, reason string)
 		},
 		Reason: reason,
 	}
+	if extended {
+		m.LastError, m.Subscriptions = c.lastErr, len(subs)
+	}
 	accName := c.acc.Name
 	c.mu.Unlock()
 
@@ -3328,6 +3381,28 @@ func (s *Server) sendOCSPPeerRejectEvent(kind string, peer *x509.Certificate, re
 	s.sendInternalMsg(subj, _EMPTY_, &m.Server, &m)
 }
 
+// sendMaxConnSoftLimitEventLocked sends a system level event to the system account
+// when the number of client connections reaches the max connections soft limit.
+// Server lock should be held.
+func (s *Server) sendMaxConnSoftLimitEventLocked(conns, limit, maxConn int, ldm bool) {
+	if !s.eventsEnabled() {
+		return
+	}
+	m := MaxConnSoftLimitEventMsg{
+		TypedEvent: TypedEvent{
+			Type: MaxConnSoftLimitEventMsgType,
+			ID:   s.nextEventID(),
+			Time: time.Now().UTC(),
+		},
+		Connections:    conns,
+		SoftLimit:      limit,
+		MaxConnections: maxConn,
+		LameDuck:       ldm,
+	}
+	subj := fmt.Sprintf(maxConnSoftLimitEventSubj, s.info.ID)
+	s.sendInternalMsg(subj, _EMPTY_, &m.Server, &m)
+}
+
 // sendOCSPPeerChainlinkInvalidEvent sends a system level event to system account when a link in a peer's trust chain
 // is OCSP invalid.
 func (s *Server) sendOCSPPeerChainlinkInvalidEvent(peer *x509.Certificate, link *x509.Certificate, reason string) {
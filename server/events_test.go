@@ -604,6 +604,59 @@ func TestSystemAccountDisconnectBadLogin(t *testing.T) {
 	}
 }
 
+func TestSystemAccountDisconnectEventCloseAdvisoryDetail(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		detail   string
+		lastErr  string
+		numSubs  int
+		expected CloseAdvisoryDetail
+	}{
+		{"default", _EMPTY_, _EMPTY_, 0, CloseAdvisoryDetailBasic},
+		{"basic", `close_advisory_detail: "basic"`, _EMPTY_, 0, CloseAdvisoryDetailBasic},
+		{"extended", `close_advisory_detail: "extended"`, "Permissions Violation for Publish to \"secret\"", 2, CloseAdvisoryDetailExtended},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			conf := createConfFile(t, []byte(fmt.Sprintf(`
+				listen: 127.0.0.1:-1
+				%s
+				accounts {
+					SYS { users = [{user: sys, password: pwd}] }
+					A { users = [{user: a, password: pwd, permissions: {publish: {deny: "secret"}}}] }
+				}
+				system_account: SYS
+			`, test.detail)))
+			s, opts := RunServerWithConfig(conf)
+			defer s.Shutdown()
+			require_Equal(t, opts.CloseAdvisoryDetail, test.expected)
+
+			ncs := natsConnect(t, s.ClientURL(), nats.UserInfo("sys", "pwd"))
+			defer ncs.Close()
+			sub := natsSubSync(t, ncs, "$SYS.ACCOUNT.A.DISCONNECT")
+			natsFlush(t, ncs)
+
+			nc := natsConnect(t, s.ClientURL(), nats.UserInfo("a", "pwd"), nats.ErrorHandler(func(*nats.Conn, *nats.Subscription, error) {}))
+			natsSubSync(t, nc, "foo")
+			natsSubSync(t, nc, "bar")
+			natsPub(t, nc, "secret", nil)
+			natsFlush(t, nc)
+			nc.Close()
+
+			msg := natsNexMsg(t, sub, time.Second)
+			var dem DisconnectEventMsg
+			require_NoError(t, json.Unmarshal(msg.Data, &dem))
+			require_Equal(t, dem.Reason, ClientClosed.String())
+			require_Equal(t, dem.LastError, test.lastErr)
+			require_Equal(t, dem.Subscriptions, test.numSubs)
+		})
+	}
+
+	conf := createConfFile(t, []byte(`close_advisory_detail: "verbose"`))
+	_, err := ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "close_advisory_detail must be 'basic' or 'extended'")
+}
+
 func TestSysSubscribeRace(t *testing.T) {
 	s, opts := runTrustedServer(t)
 	defer s.Shutdown()
@@ -11572,6 +11572,27 @@ func (mb *msgBlock) ensurePerSubjectInfoLoaded() error {
 	return mb.generatePerSubjectInfo()
 }
 
+// preload eagerly loads the per subject state of all blocks and the messages
+// of the last block, which are otherwise loaded lazily on first access.
+func (fs *fileStore) preload() error {
+	fs.mu.RLock()
+	blks, lmb := append([]*msgBlock(nil), fs.blks...), fs.lmb
+	fs.mu.RUnlock()
+
+	for _, mb := range blks {
+		mb.mu.Lock()
+		err := mb.ensurePerSubjectInfoLoaded()
+		if err == nil && mb == lmb && mb.msgs > 0 && mb.cacheNotLoaded() {
+			err = mb.loadMsgsWithLock()
+		}
+		mb.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Called on recovery to populate the global psim state.
 // Lock should be held.
 func (fs *fileStore) populateGlobalPerSubjectInfo(mb *msgBlock) error {
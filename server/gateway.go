@@ -145,6 +145,7 @@ type srvGateway struct {
 	info     *Info                  // Gateway Info protocol
 	infoJSON []byte                 // Marshal'ed Info protocol
 	runknown bool                   // Rejects unknown (not configured) gateway connections
+	aunknown map[string]struct{}    // Unknown gateways accepted even if runknown is set
 	replyPfx []byte                 // Will be "$GNR.<1:reserved>.<8:cluster hash>.<8:server hash>."
 
 	// For backward compatibility
@@ -359,6 +360,12 @@ func (s *Server) newGateway(opts *Options) error {
 		runknown: opts.Gateway.RejectUnknown,
 		oldHash:  getOldHash(opts.Gateway.Name),
 	}
+	if len(opts.Gateway.AllowedUnknown) > 0 {
+		gateway.aunknown = make(map[string]struct{}, len(opts.Gateway.AllowedUnknown))
+		for _, name := range opts.Gateway.AllowedUnknown {
+			gateway.aunknown[name] = struct{}{}
+		}
+	}
 	gateway.Lock()
 	defer gateway.Unlock()
 
@@ -471,13 +478,17 @@ func (g *srvGateway) updateRemotesTLSConfig(opts *Options) {
 	}
 }
 
-// Returns if this server rejects connections from gateways that are not
-// explicitly configured.
-func (g *srvGateway) rejectUnknown() bool {
+// Returns if this server rejects connections from the given gateway when it
+// is not explicitly configured. Gateways in the allowed unknown list are
+// accepted even if this server is configured to reject unknown gateways.
+func (g *srvGateway) rejectUnknown(name string) bool {
 	g.RLock()
-	reject := g.runknown
-	g.RUnlock()
-	return reject
+	defer g.RUnlock()
+	if !g.runknown {
+		return false
+	}
+	_, allowed := g.aunknown[name]
+	return !allowed
 }
 
 // Starts the gateways accept loop and solicit explicit gateways
@@ -546,6 +557,7 @@ func (s *Server) startGatewayAcceptLoop() {
 		MaxPayload:   s.info.MaxPayload,
 		Gateway:      opts.Gateway.Name,
 		GatewayNRP:   true,
+		Tags:         copyStrings(opts.Tags),
 		Headers:      s.supportsHeaders(),
 		Proto:        s.getServerProto(),
 	}
@@ -1010,7 +1022,7 @@ func (c *client) processGatewayConnect(arg []byte) error {
 
 	// If we reject unknown gateways, make sure we have it configured,
 	// otherwise return an error.
-	if s.gateway.rejectUnknown() && s.getRemoteGateway(connect.Gateway) == nil {
+	if s.gateway.rejectUnknown(connect.Gateway) && s.getRemoteGateway(connect.Gateway) == nil {
 		c.Errorf("Rejecting connection from gateway %q", connect.Gateway)
 		c.sendErr(fmt.Sprintf("Connection to gateway %q rejected", s.getGatewayName()))
 		c.closeConnection(WrongGateway)
@@ -1088,7 +1100,7 @@ func (c *client) processGatewayInfo(info *Info) {
 			if !isFirstINFO && info.GatewayCmd == gatewayCmdGossip {
 				// If we are configured to reject unknown, do not attempt to
 				// connect to one that we don't have configured.
-				if s.gateway.rejectUnknown() && s.getRemoteGateway(info.Gateway) == nil {
+				if s.gateway.rejectUnknown(info.Gateway) && s.getRemoteGateway(info.Gateway) == nil {
 					return
 				}
 				s.processImplicitGateway(info)
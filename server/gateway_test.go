@@ -1764,6 +1764,41 @@ func TestGatewayRejectUnknown(t *testing.T) {
 	}
 }
 
+func TestGatewayRejectUnknownWithAllowedClusters(t *testing.T) {
+	o2 := testDefaultOptionsForGateway("B")
+	s2 := runGatewayServer(o2)
+	defer s2.Shutdown()
+
+	// A rejects non configured gateways, except for C.
+	o1 := testGatewayOptionsFromToWithServers(t, "A", "B", s2)
+	o1.Gateway.RejectUnknown = true
+	o1.Gateway.AllowedUnknown = []string{"C"}
+	s1 := runGatewayServer(o1)
+	defer s1.Shutdown()
+
+	waitForOutboundGateways(t, s1, 1, time.Second)
+	waitForInboundGateways(t, s1, 1, time.Second)
+
+	// D is not in the allowed list, so A should not connect to it.
+	o4 := testGatewayOptionsFromToWithServers(t, "D", "B", s2)
+	s4 := runGatewayServer(o4)
+	defer s4.Shutdown()
+
+	// C is, so A should accept its connection and connect to it.
+	o3 := testGatewayOptionsFromToWithServers(t, "C", "B", s2)
+	s3 := runGatewayServer(o3)
+	defer s3.Shutdown()
+
+	waitForOutboundGateways(t, s3, 3, 2*time.Second)
+	waitForOutboundGateways(t, s1, 2, 2*time.Second)
+	waitForInboundGateways(t, s1, 2, 2*time.Second)
+	if s1.getOutboundGatewayConnection("C") == nil {
+		t.Fatalf("A should have outbound gateway to C")
+	}
+	if s1.getOutboundGatewayConnection("D") != nil {
+		t.Fatalf("A should not have outbound gateway to D")
+	}
+}
 func TestGatewayNoReconnectOnClose(t *testing.T) {
 	o2 := testDefaultOptionsForGateway("B")
 	s2 := runGatewayServer(o2)
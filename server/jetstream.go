@@ -24,7 +24,9 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -41,15 +43,17 @@ import (
 // JetStreamConfig determines this server's configuration.
 // MaxMemory and MaxStore are in bytes.
 type JetStreamConfig struct {
-	MaxMemory    int64         `json:"max_memory"`              // MaxMemory is the maximum size of memory type streams
-	MaxStore     int64         `json:"max_storage"`             // MaxStore is the maximum size of file store type streams
-	StoreDir     string        `json:"store_dir,omitempty"`     // StoreDir is where storage files are stored
-	SyncInterval time.Duration `json:"sync_interval,omitempty"` // SyncInterval is how frequently we sync to disk in the background by calling fsync
-	SyncAlways   bool          `json:"sync_always,omitempty"`   // SyncAlways indicates flushes are done after every write
-	Domain       string        `json:"domain,omitempty"`        // Domain is the JetStream domain
-	CompressOK   bool          `json:"compress_ok,omitempty"`   // CompressOK indicates if compression is supported
-	UniqueTag    string        `json:"unique_tag,omitempty"`    // UniqueTag is the unique tag assigned to this instance
-	Strict       bool          `json:"strict,omitempty"`        // Strict indicates if strict JSON parsing is performed
+	MaxMemory     int64         `json:"max_memory"`               // MaxMemory is the maximum size of memory type streams
+	MaxStore      int64         `json:"max_storage"`              // MaxStore is the maximum size of file store type streams
+	StoreDir      string        `json:"store_dir,omitempty"`      // StoreDir is where storage files are stored
+	SyncInterval  time.Duration `json:"sync_interval,omitempty"`  // SyncInterval is how frequently we sync to disk in the background by calling fsync
+	SyncAlways    bool          `json:"sync_always,omitempty"`    // SyncAlways indicates flushes are done after every write
+	Domain        string        `json:"domain,omitempty"`         // Domain is the JetStream domain
+	DomainAliases []string      `json:"domain_aliases,omitempty"` // DomainAliases are alternate names resolving to the JetStream domain
+	CompressOK    bool          `json:"compress_ok,omitempty"`    // CompressOK indicates if compression is supported
+	UniqueTag     string        `json:"unique_tag,omitempty"`     // UniqueTag is the unique tag assigned to this instance
+	Strict        bool          `json:"strict,omitempty"`         // Strict indicates if strict JSON parsing is performed
+	ClusterName   string        `json:"cluster_name,omitempty"`   // ClusterName is the JetStream cluster name when different from the routing cluster name
 }
 
 // Statistics about JetStream for this server.
@@ -60,6 +64,8 @@ type JetStreamStats struct {
 	ReservedStore  uint64            `json:"reserved_storage"`
 	Accounts       int               `json:"accounts"`
 	HAAssets       int               `json:"ha_assets"`
+	FreeMemory     int64             `json:"free_memory,omitempty"`
+	MemoryLow      bool              `json:"memory_low,omitempty"`
 	API            JetStreamAPIStats `json:"api"`
 }
 
@@ -72,6 +78,7 @@ type JetStreamAccountLimits struct {
 	MemoryMaxStreamBytes int64 `json:"memory_max_stream_bytes"`
 	StoreMaxStreamBytes  int64 `json:"storage_max_stream_bytes"`
 	MaxBytesRequired     bool  `json:"max_bytes_required"`
+	MaxHAAssets          int   `json:"max_ha_assets,omitempty"`
 }
 
 type JetStreamTier struct {
@@ -81,6 +88,7 @@ type JetStreamTier struct {
 	ReservedStore  uint64                 `json:"reserved_storage"`
 	Streams        int                    `json:"streams"`
 	Consumers      int                    `json:"consumers"`
+	HAAssets       int                    `json:"ha_assets,omitempty"`
 	Limits         JetStreamAccountLimits `json:"limits"`
 }
 
@@ -114,6 +122,7 @@ type jetStream struct {
 	storeUsed      int64
 	queueLimit     int64
 	infoQueueLimit int64
+	memFree        int64 // Last sampled free system memory, only when min_free_memory is set.
 	clustered      int32
 	mu             sync.RWMutex
 	srv            *Server
@@ -127,6 +136,13 @@ type jetStream struct {
 	// System level request to purge a stream move
 	accountPurge *subscription
 
+	// Semaphores limiting concurrent snapshots and restores, nil if unlimited.
+	snapshotSem chan struct{}
+	restoreSem  chan struct{}
+
+	// Preload status of the configured streams, keyed by "account:stream".
+	preload map[string]string
+
 	// Some bools regarding general state.
 	metaRecovering bool
 	standAlone     bool
@@ -135,6 +151,7 @@ type jetStream struct {
 
 	// Atomic versions
 	disabled atomic.Bool
+	memLow   atomic.Bool
 }
 
 type remoteUsage struct {
@@ -203,14 +220,17 @@ func (s *Server) EnableJetStream(config *JetStreamConfig) error {
 
 	if config == nil || config.MaxMemory <= 0 || config.MaxStore <= 0 {
 		var storeDir, domain, uniqueTag string
+		var domainAliases []string
 		var maxStore, maxMem int64
 		if config != nil {
 			storeDir, domain, uniqueTag = config.StoreDir, config.Domain, config.UniqueTag
+			domainAliases = config.DomainAliases
 			maxStore, maxMem = config.MaxStore, config.MaxMemory
 		}
 		config = s.dynJetStreamConfig(storeDir, maxStore, maxMem)
 		if domain != _EMPTY_ {
 			config.Domain = domain
+			config.DomainAliases = domainAliases
 		}
 		if uniqueTag != _EMPTY_ {
 			config.UniqueTag = uniqueTag
@@ -414,7 +434,16 @@ func (s *Server) initJetStreamEncryption() (err error) {
 
 // enableJetStream will start up the JetStream subsystem.
 func (s *Server) enableJetStream(cfg JetStreamConfig) error {
+	if cfg.ClusterName == _EMPTY_ {
+		cfg.ClusterName = s.getOpts().JetStreamClusterName
+	}
 	js := &jetStream{srv: s, config: cfg, accounts: make(map[string]*jsAccount), apiSubs: NewSublistNoCache(), infoSubs: gsl.NewSimpleSublist()}
+	if ps := s.getOpts().JetStreamPreloadStreams; len(ps) > 0 {
+		js.preload = make(map[string]string, len(ps))
+		for _, p := range ps {
+			js.preload[p] = streamPreloadPending
+		}
+	}
 	s.gcbMu.Lock()
 	if s.gcbOutMax = s.getOpts().JetStreamMaxCatchup; s.gcbOutMax == 0 {
 		s.gcbOutMax = defaultMaxTotalCatchupOutBytes
@@ -427,6 +456,12 @@ func (s *Server) enableJetStream(cfg JetStreamConfig) error {
 	// TODO: Not currently reloadable.
 	atomic.StoreInt64(&js.queueLimit, s.getOpts().JetStreamRequestQueueLimit)
 	atomic.StoreInt64(&js.infoQueueLimit, s.getOpts().JetStreamInfoQueueLimit)
+	if n := s.getOpts().JetStreamMaxConcurrentSnapshots; n > 0 {
+		js.snapshotSem = make(chan struct{}, n)
+	}
+	if n := s.getOpts().JetStreamMaxConcurrentRestores; n > 0 {
+		js.restoreSem = make(chan struct{}, n)
+	}
 
 	s.js.Store(js)
 
@@ -448,6 +483,15 @@ func (s *Server) enableJetStream(cfg JetStreamConfig) error {
 		os.Remove(tmpfile.Name())
 	}
 
+	if s.getOpts().JetStreamDisallowNetworkStorage {
+		fstype, err := networkFilesystemType(cfg.StoreDir)
+		if err != nil {
+			s.Warnf("Unable to check if storage directory is on a network filesystem: %v", err)
+		} else if fstype != _EMPTY_ {
+			return fmt.Errorf("storage directory is on a network filesystem (%s), which is disallowed", fstype)
+		}
+	}
+
 	if err := s.initJetStreamEncryption(); err != nil {
 		return err
 	}
@@ -476,10 +520,19 @@ func (s *Server) enableJetStream(cfg JetStreamConfig) error {
 
 	s.Noticef("  Max Memory:      %s", friendlyBytes(cfg.MaxMemory))
 	s.Noticef("  Max Storage:     %s", friendlyBytes(cfg.MaxStore))
+	if minFree := opts.JetStreamMinFreeMemory; minFree > 0 {
+		s.Noticef("  Min Free Memory: %s", friendlyBytes(minFree))
+	}
 	s.Noticef("  Store Directory: \"%s\"", cfg.StoreDir)
 	if cfg.Domain != _EMPTY_ {
 		s.Noticef("  Domain:          %s", cfg.Domain)
 	}
+	if len(cfg.DomainAliases) > 0 {
+		s.Noticef("  Domain Aliases:  %s", strings.Join(cfg.DomainAliases, ", "))
+	}
+	if cfg.ClusterName != _EMPTY_ {
+		s.Noticef("  Cluster Name:    %s", cfg.ClusterName)
+	}
 
 	if ek := opts.JetStreamKey; ek != _EMPTY_ {
 		s.Noticef("  Encryption:      %s", opts.JetStreamCipher)
@@ -523,6 +576,19 @@ func (s *Server) enableJetStream(cfg JetStreamConfig) error {
 		}
 		// Set our atomic bool to clustered.
 		s.jsClustered.Store(true)
+	} else {
+		// All streams have been recovered.
+		js.checkPreloadStreams()
+	}
+
+	// If configured, start watching the free system memory.
+	if minFree := opts.JetStreamMinFreeMemory; minFree > 0 {
+		if memAvailable() < 0 {
+			s.Warnf("Free system memory can not be determined on %s, min_free_memory will be ignored", runtime.GOOS)
+		} else {
+			js.checkFreeMemory(minFree)
+			s.startGoRoutine(func() { js.monitorFreeMemory(minFree) })
+		}
 	}
 
 	// Mark when we are up and running.
@@ -531,6 +597,148 @@ func (s *Server) enableJetStream(cfg JetStreamConfig) error {
 	return nil
 }
 
+// How often we sample the free system memory when min_free_memory is set.
+const defaultFreeMemorySampleInterval = time.Second
+
+// monitorFreeMemory periodically samples the free system memory until
+// JetStream is shutdown or disabled.
+func (js *jetStream) monitorFreeMemory(minFree int64) {
+	s := js.srv
+	defer s.grWG.Done()
+
+	t := time.NewTicker(defaultFreeMemorySampleInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.quitCh:
+			return
+		case <-t.C:
+			if js.disabled.Load() || js.isShuttingDown() {
+				return
+			}
+			js.checkFreeMemory(minFree)
+		}
+	}
+}
+
+// checkFreeMemory samples the free system memory and updates whether memory
+// storage writes should be rejected. If the free memory can not be determined
+// writes are always allowed.
+func (js *jetStream) checkFreeMemory(minFree int64) {
+	free := memAvailable()
+	atomic.StoreInt64(&js.memFree, free)
+	low := free >= 0 && free < minFree
+	if js.memLow.Swap(low) == low {
+		return
+	}
+	if low {
+		js.srv.Warnf("JetStream free memory %s is below the minimum of %s, rejecting memory storage writes",
+			friendlyBytes(free), friendlyBytes(minFree))
+	} else {
+		js.srv.Noticef("JetStream free memory %s is above the minimum of %s, accepting memory storage writes",
+			friendlyBytes(free), friendlyBytes(minFree))
+	}
+}
+
+// memoryLow returns true if writes to memory storage streams should be
+// rejected because the free system memory is below min_free_memory.
+func (js *jetStream) memoryLow(storeType StorageType) bool {
+	return storeType == MemoryStorage && js.memLow.Load()
+}
+
+// Preload status of the streams configured with preload_streams.
+const (
+	streamPreloadPending  = "pending"
+	streamPreloadLoaded   = "loaded"
+	streamPreloadNotFound = "not_found"
+)
+
+// preloadStream eagerly loads the state of the stream if it is configured to be
+// preloaded and was not loaded yet.
+func (js *jetStream) preloadStream(mset *stream, accName, name string) {
+	key := accName + ":" + name
+	js.mu.Lock()
+	if status, ok := js.preload[key]; !ok || status != streamPreloadPending {
+		js.mu.Unlock()
+		return
+	}
+	js.mu.Unlock()
+
+	status, start := streamPreloadLoaded, time.Now()
+	if fs, ok := mset.store.(*fileStore); ok {
+		if err := fs.preload(); err != nil {
+			status = fmt.Sprintf("error: %v", err)
+			js.srv.Warnf("Error preloading stream '%s > %s': %v", accName, name, err)
+		}
+	}
+	if status == streamPreloadLoaded {
+		js.srv.Noticef("Preloaded stream '%s > %s' in %v", accName, name, time.Since(start).Round(time.Millisecond))
+	}
+
+	js.mu.Lock()
+	js.preload[key] = status
+	js.mu.Unlock()
+}
+
+// checkPreloadStreams warns about streams configured to be preloaded that were
+// not found once all streams have been recovered.
+func (js *jetStream) checkPreloadStreams() {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	for key, status := range js.preload {
+		if status == streamPreloadPending {
+			js.preload[key] = streamPreloadNotFound
+			js.srv.Warnf("Stream %q configured to be preloaded was not found", key)
+		}
+	}
+}
+
+// Actions taken for corrupted state found while recovering, see on_corrupt_recovery.
+const (
+	JsCorruptRecoveryFail       = "fail"
+	JsCorruptRecoverySkip       = "skip"
+	JsCorruptRecoveryQuarantine = "quarantine"
+)
+
+// This is where corrupted streams and consumers are moved to within an account's store directory.
+const quarantineDir = "__quarantine__"
+
+// handleCorruptRecovery applies the configured on_corrupt_recovery action to a stream
+// or consumer whose state in dir could not be recovered. An error is only returned
+// if recovery should fail. When canQuarantine is false, e.g. for state that could not
+// be decrypted and may only need the right key, quarantine falls back to skipping.
+func (s *Server) handleCorruptRecovery(jsa *jsAccount, asset, dir string, canQuarantine bool) error {
+	switch s.getOpts().JetStreamOnCorruptRecovery {
+	case JsCorruptRecoveryFail:
+		s.Errorf("  Corrupted %s could not be recovered, failing", asset)
+		return fmt.Errorf("corrupted %s could not be recovered", asset)
+	case JsCorruptRecoveryQuarantine:
+		if !canQuarantine {
+			s.Warnf("  Unreadable %s skipped, not quarantined", asset)
+			return nil
+		}
+		rel, err := filepath.Rel(jsa.storeDir, dir)
+		if err != nil {
+			s.Errorf("  Corrupted %s could not be quarantined, skipping: %v", asset, err)
+			return nil
+		}
+		qdir := filepath.Join(jsa.storeDir, quarantineDir, fmt.Sprintf("%s.%d", rel, time.Now().UnixNano()))
+		if err := os.MkdirAll(filepath.Dir(qdir), defaultDirPerms); err != nil {
+			s.Errorf("  Corrupted %s could not be quarantined, skipping: %v", asset, err)
+			return nil
+		}
+		if err := os.Rename(dir, qdir); err != nil {
+			s.Errorf("  Corrupted %s could not be quarantined, skipping: %v", asset, err)
+			return nil
+		}
+		s.Warnf("  Corrupted %s quarantined to %q", asset, qdir)
+	default:
+		s.Warnf("  Corrupted %s skipped", asset)
+	}
+	return nil
+}
+
 const jsNoExtend = "no_extend"
 const jsWillExtend = "will_extend"
 
@@ -562,13 +770,14 @@ func (s *Server) updateJetStreamInfoStatus(enabled bool) {
 func (s *Server) restartJetStream() error {
 	opts := s.getOpts()
 	cfg := JetStreamConfig{
-		StoreDir:     opts.StoreDir,
-		SyncInterval: opts.SyncInterval,
-		SyncAlways:   opts.SyncAlways,
-		MaxMemory:    opts.JetStreamMaxMemory,
-		MaxStore:     opts.JetStreamMaxStore,
-		Domain:       opts.JetStreamDomain,
-		Strict:       !opts.NoJetStreamStrict,
+		StoreDir:      opts.StoreDir,
+		SyncInterval:  opts.SyncInterval,
+		SyncAlways:    opts.SyncAlways,
+		MaxMemory:     opts.JetStreamMaxMemory,
+		MaxStore:      opts.JetStreamMaxStore,
+		Domain:        opts.JetStreamDomain,
+		DomainAliases: opts.JetStreamDomainAliases,
+		Strict:        !opts.NoJetStreamStrict,
 	}
 	s.Noticef("Restarting JetStream")
 	err := s.EnableJetStream(&cfg)
@@ -637,7 +846,7 @@ func (s *Server) handleOutOfSpace(mset *stream) {
 			Server:   s.Name(),
 			ServerID: s.ID(),
 			Stream:   stream,
-			Cluster:  s.cachedClusterName(),
+			Cluster:  s.jsClusterName(),
 			Domain:   s.getOpts().JetStreamDomain,
 		}
 		s.publishAdvisory(nil, JSAdvisoryServerOutOfStorage, adv)
@@ -766,7 +975,7 @@ func (a *Account) enableAllJetStreamServiceImportsAndMappings() error {
 	// Check if we have a Domain specified.
 	// If so add in a subject mapping that will allow local connected clients to reach us here as well.
 	if opts := s.getOpts(); opts.JetStreamDomain != _EMPTY_ {
-		mappings := generateJSMappingTable(opts.JetStreamDomain)
+		mappings := generateJSDomainMappingTable(opts.JetStreamDomain, opts.JetStreamDomainAliases)
 		a.mu.RLock()
 		for _, m := range a.mappings {
 			delete(mappings, m.src)
@@ -801,6 +1010,11 @@ func (s *Server) configJetStream(acc *Account, tq chan<- func()) error {
 	acc.mu.RLock()
 	jsLimits := acc.jsLimits
 	acc.mu.RUnlock()
+	// Accounts not allowed to use JetStream are treated as not configured for it.
+	if jsLimits != nil && !s.jetStreamAllowedForAccount(acc.GetName()) {
+		s.Warnf("JetStream not allowed for account %q by the server configuration", acc.GetName())
+		jsLimits = nil
+	}
 	if jsLimits != nil {
 		// Check if already enabled. This can be during a reload.
 		if acc.JetStreamEnabled() {
@@ -831,6 +1045,13 @@ func (s *Server) configJetStream(acc *Account, tq chan<- func()) error {
 	return nil
 }
 
+// jetStreamAllowedForAccount returns whether the account may use JetStream,
+// which is restricted to the accounts listed in JetStreamAllowedAccounts, if any.
+func (s *Server) jetStreamAllowedForAccount(name string) bool {
+	allowed := s.getOpts().JetStreamAllowedAccounts
+	return len(allowed) == 0 || slices.Contains(allowed, name)
+}
+
 // configAllJetStreamAccounts walk all configured accounts and turn on jetstream if requested.
 func (s *Server) configAllJetStreamAccounts(tq chan<- func()) error {
 	// Check to see if system account has been enabled. We could arrive here via reload and
@@ -1120,6 +1341,27 @@ func (s *Server) JetStreamReservedResources() (int64, int64, error) {
 	return js.memReserved, js.storeReserved, nil
 }
 
+// tryAcquireSem tries to acquire a slot of the given semaphore without blocking.
+// A nil semaphore has no limit.
+func tryAcquireSem(sem chan struct{}) bool {
+	if sem == nil {
+		return true
+	}
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseSem releases a slot acquired with tryAcquireSem.
+func releaseSem(sem chan struct{}) {
+	if sem != nil {
+		<-sem
+	}
+}
+
 func (s *Server) getJetStream() *jetStream {
 	return s.js.Load()
 }
@@ -1145,6 +1387,10 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 		return fmt.Errorf("jetstream can not be enabled on the system account")
 	}
 
+	if !s.jetStreamAllowedForAccount(a.GetName()) {
+		return NewJSNotAllowedForAccountError()
+	}
+
 	s.mu.RLock()
 	if s.sys == nil {
 		s.mu.RUnlock()
@@ -1248,25 +1494,36 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 	encrypted := s.getOpts().JetStreamKey != _EMPTY_
 	sc := s.getOpts().JetStreamCipher
 
-	doConsumers := func(mset *stream, odir string) {
+	doConsumers := func(mset *stream, odir string) error {
 		ofis, _ := os.ReadDir(odir)
 		if len(ofis) > 0 {
 			s.Noticef("  Recovering %d consumers for stream - '%s > %s'", len(ofis), mset.accName(), mset.name())
 		}
 		for _, ofi := range ofis {
-			metafile := filepath.Join(odir, ofi.Name(), JetStreamMetaFile)
-			metasum := filepath.Join(odir, ofi.Name(), JetStreamMetaFileSum)
+			cdir := filepath.Join(odir, ofi.Name())
+			asset := fmt.Sprintf("consumer '%s > %s > %s'", a.Name, mset.name(), ofi.Name())
+			metafile := filepath.Join(cdir, JetStreamMetaFile)
+			metasum := filepath.Join(cdir, JetStreamMetaFileSum)
 			if _, err := os.Stat(metafile); os.IsNotExist(err) {
 				s.Warnf("    Missing consumer metafile %q", metafile)
+				if err := s.handleCorruptRecovery(jsa, asset, cdir, true); err != nil {
+					return err
+				}
 				continue
 			}
 			buf, err := os.ReadFile(metafile)
 			if err != nil {
 				s.Warnf("    Error reading consumer metafile %q: %v", metafile, err)
+				if err := s.handleCorruptRecovery(jsa, asset, cdir, true); err != nil {
+					return err
+				}
 				continue
 			}
 			if _, err := os.Stat(metasum); os.IsNotExist(err) {
 				s.Warnf("    Missing consumer checksum for %q", metasum)
+				if err := s.handleCorruptRecovery(jsa, asset, cdir, true); err != nil {
+					return err
+				}
 				continue
 			}
 
@@ -1278,6 +1535,9 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 				nbuf, _, err := s.decryptMeta(sc, key, buf, a.Name, ctxName)
 				if err != nil {
 					s.Warnf("  Error decrypting our consumer metafile: %v", err)
+					if err := s.handleCorruptRecovery(jsa, asset, cdir, false); err != nil {
+						return err
+					}
 					continue
 				}
 				buf = nbuf
@@ -1291,6 +1551,9 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 				cfg = FileConsumerInfo{}
 				if err := json.Unmarshal(buf, &cfg); err != nil {
 					s.Warnf("    Error unmarshalling consumer metafile %q: %v", metafile, err)
+					if err := s.handleCorruptRecovery(jsa, asset, cdir, true); err != nil {
+						return err
+					}
 					continue
 				}
 			}
@@ -1351,7 +1614,7 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 				// the consumer can reconnect. We will create it as a durable and switch it.
 				cfg.ConsumerConfig.Durable = ofi.Name()
 			}
-			obs, err := mset.addConsumerWithAssignment(&cfg.ConsumerConfig, _EMPTY_, nil, true, ActionCreateOrUpdate, false)
+			obs, err := mset.addConsumerWithAssignment(&cfg.ConsumerConfig, _EMPTY_, nil, true, ActionCreateOrUpdate, false, false)
 			if err != nil {
 				s.Warnf("    Error adding consumer '%s > %s > %s': %v", a.Name, mset.name(), cfg.Name, err)
 				continue
@@ -1363,6 +1626,7 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 				obs.setCreatedTime(cfg.Created)
 			}
 		}
+		return nil
 	}
 
 	// Now recover the streams.
@@ -1370,6 +1634,7 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 	doStream := func(fi os.DirEntry) error {
 		plaintext := true
 		mdir := filepath.Join(sdir, fi.Name())
+		asset := fmt.Sprintf("stream '%s > %s'", a.Name, fi.Name())
 		// Check for partially deleted streams. They are marked with "." prefix.
 		if strings.HasPrefix(fi.Name(), tsep) {
 			go os.RemoveAll(mdir)
@@ -1384,28 +1649,28 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 		metasum := filepath.Join(mdir, JetStreamMetaFileSum)
 		if _, err := os.Stat(metafile); os.IsNotExist(err) {
 			s.Warnf("  Missing stream metafile for %q", metafile)
-			return nil
+			return s.handleCorruptRecovery(jsa, asset, mdir, true)
 		}
 		buf, err := os.ReadFile(metafile)
 		if err != nil {
 			s.Warnf("  Error reading metafile %q: %v", metafile, err)
-			return nil
+			return s.handleCorruptRecovery(jsa, asset, mdir, true)
 		}
 		if _, err := os.Stat(metasum); os.IsNotExist(err) {
 			s.Warnf("  Missing stream checksum file %q", metasum)
-			return nil
+			return s.handleCorruptRecovery(jsa, asset, mdir, true)
 		}
 		sum, err := os.ReadFile(metasum)
 		if err != nil {
 			s.Warnf("  Error reading Stream metafile checksum %q: %v", metasum, err)
-			return nil
+			return s.handleCorruptRecovery(jsa, asset, mdir, true)
 		}
 		hh.Write(buf)
 		var hb [highwayhash.Size64]byte
 		checksum := hex.EncodeToString(hh.Sum(hb[:0]))
 		if checksum != string(sum) {
 			s.Warnf("  Stream metafile %q: checksums do not match %q vs %q", metafile, sum, checksum)
-			return nil
+			return s.handleCorruptRecovery(jsa, asset, mdir, true)
 		}
 
 		// Track if we are converting ciphers.
@@ -1418,14 +1683,14 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 			s.Debugf("  Stream metafile is encrypted, reading encrypted keyfile")
 			if len(keyBuf) < minMetaKeySize {
 				s.Warnf("  Bad stream encryption key length of %d", len(keyBuf))
-				return nil
+				return s.handleCorruptRecovery(jsa, asset, mdir, false)
 			}
 			// Decode the buffer before proceeding.
 			var nbuf []byte
 			nbuf, convertingCiphers, err = s.decryptMeta(sc, keyBuf, buf, a.Name, fi.Name())
 			if err != nil {
 				s.Warnf("  Error decrypting our stream metafile: %v", err)
-				return nil
+				return s.handleCorruptRecovery(jsa, asset, mdir, false)
 			}
 			buf = nbuf
 			plaintext = false
@@ -1439,7 +1704,7 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 			cfg = FileStreamInfo{}
 			if err := json.Unmarshal(buf, &cfg); err != nil {
 				s.Warnf("  Error unmarshalling stream metafile %q: %v", metafile, err)
-				return nil
+				return s.handleCorruptRecovery(jsa, asset, mdir, true)
 			}
 		}
 		if supported := supportsRequiredApiLevel(cfg.Metadata); !supported || strictErr != nil {
@@ -1482,7 +1747,7 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 
 				// Now do the consumers.
 				odir := filepath.Join(sdir, fi.Name(), consumerDir)
-				doConsumers(mset, odir)
+				return doConsumers(mset, odir)
 			}
 			return nil
 		}
@@ -1530,7 +1795,9 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 			}
 		}
 
-		// Add in the stream.
+		// Add in the stream. Errors here are not subject to on_corrupt_recovery, the
+		// stream is always skipped since its metadata was valid and the failure may
+		// come from limits or the store instead of corrupted state.
 		mset, err := a.recoverStream(&cfg.StreamConfig)
 		if err != nil {
 			s.Warnf("  Error recreating stream %q: %v", cfg.Name, err)
@@ -1625,7 +1892,9 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 
 		// Now do the consumers.
 		odir := filepath.Join(sdir, fi.Name(), consumerDir)
-		doConsumers(mset, odir)
+		if err := doConsumers(mset, odir); err != nil {
+			return err
+		}
 
 		// Collect to check for dangling messages.
 		// TODO(dlc) - Can be removed eventually.
@@ -1637,13 +1906,34 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 		return nil
 	}
 
+	// Track the first error that should fail recovery, if any.
+	var (
+		rmu  sync.Mutex
+		rerr error
+	)
+	recoverStream := func(fi os.DirEntry) {
+		rmu.Lock()
+		failed := rerr != nil
+		rmu.Unlock()
+		if failed {
+			return
+		}
+		if err := doStream(fi); err != nil {
+			rmu.Lock()
+			if rerr == nil {
+				rerr = err
+			}
+			rmu.Unlock()
+		}
+	}
+
 	if tq != nil {
 		// If a parallelTaskQueue was provided then use that for concurrency.
 		var wg sync.WaitGroup
 		wg.Add(len(fis))
 		for _, fi := range fis {
 			tq <- func() {
-				doStream(fi)
+				recoverStream(fi)
 				wg.Done()
 			}
 		}
@@ -1651,9 +1941,12 @@ func (a *Account) EnableJetStream(limits map[string]JetStreamAccountLimits, tq c
 	} else {
 		// No parallelTaskQueue provided, do inline as before.
 		for _, fi := range fis {
-			doStream(fi)
+			recoverStream(fi)
 		}
 	}
+	if rerr != nil {
+		return rerr
+	}
 
 	// Make sure to cleanup any old remaining snapshots.
 	os.RemoveAll(filepath.Join(jsa.storeDir, snapsDir))
@@ -1937,6 +2230,15 @@ func (a *Account) JetStreamUsage() JetStreamAccountStats {
 				stats.ReservedMemory, stats.ReservedStore = reservedStorage(sas, _EMPTY_)
 			}
 			for _, sa := range sas {
+				// Replicated streams and consumers count as HA assets.
+				if sa.Group != nil && len(sa.Group.Peers) > 1 {
+					stats.HAAssets++
+				}
+				for _, ca := range sa.consumers {
+					if ca.Group != nil && len(ca.Group.Peers) > 1 {
+						stats.HAAssets++
+					}
+				}
 				if defaultTier {
 					stats.Consumers += len(sa.consumers)
 				} else {
@@ -2343,6 +2645,17 @@ func (js *jetStream) limitsExceeded(storeType StorageType) bool {
 	return js.wouldExceedLimits(storeType, 0)
 }
 
+// Returns the larger of the bytes used and the bytes reserved for the given storage type.
+// Lowering the server limit below this would require data loss.
+func (js *jetStream) usage(storeType StorageType) int64 {
+	js.mu.RLock()
+	defer js.mu.RUnlock()
+	if storeType == MemoryStorage {
+		return max(atomic.LoadInt64(&js.memUsed), js.memReserved)
+	}
+	return max(atomic.LoadInt64(&js.storeUsed), js.storeReserved)
+}
+
 func tierName(replicas int) string {
 	// TODO (mh) this is where we could select based off a placement tag as well "qos:tier"
 	if replicas == 0 {
@@ -2586,6 +2899,10 @@ func (js *jetStream) usageStats() *JetStreamStats {
 	}
 	stats.Store = uint64(used)
 	stats.HAAssets = s.numRaftNodes()
+	if free := atomic.LoadInt64(&js.memFree); free > 0 {
+		stats.FreeMemory = free
+	}
+	stats.MemoryLow = js.memLow.Load()
 	return &stats
 }
 
@@ -2871,6 +3188,30 @@ func validateJetStreamOptions(o *Options) error {
 			return fmt.Errorf("invalid domain name: may not contain ., * or >")
 		}
 	}
+	if len(o.JetStreamDomainAliases) > 0 {
+		if o.JetStreamDomain == _EMPTY_ {
+			return fmt.Errorf("domain aliases require a domain")
+		}
+		seen := make(map[string]struct{}, len(o.JetStreamDomainAliases))
+		for _, alias := range o.JetStreamDomainAliases {
+			if alias == _EMPTY_ {
+				return fmt.Errorf("invalid domain alias: can not be empty")
+			}
+			if subj := fmt.Sprintf(jsDomainAPI, alias); !IsValidSubject(subj) || !isValidName(alias) {
+				return fmt.Errorf("invalid domain alias %q: may not contain ., * or >", alias)
+			}
+			if alias == o.JetStreamDomain {
+				return fmt.Errorf("invalid domain alias %q: same as the domain", alias)
+			}
+			if _, ok := seen[alias]; ok {
+				return fmt.Errorf("invalid domain alias %q: duplicate", alias)
+			}
+			seen[alias] = struct{}{}
+		}
+	}
+	if strings.Contains(o.JetStreamClusterName, " ") {
+		return ErrClusterNameHasSpaces
+	}
 	// If not clustered no checks needed past here.
 	if !o.JetStream || o.Cluster.Port == 0 {
 		return nil
@@ -222,6 +222,11 @@ const (
 	jsAckPre    = "$JS.ACK."
 	jsAckPreLen = len(jsAckPre)
 
+	// JSConsumerSubjectAckT is the stable subject a consumer with SubjectAck enabled
+	// accepts acks on. The payload is the stream sequence of the message to ack,
+	// optionally followed by a space and the ack type, e.g. "22" or "22 +NAK".
+	JSConsumerSubjectAckT = "$JS.ACK.%s.%s"
+
 	// jsFlowControl is for flow control subjects.
 	jsFlowControlPre = "$JS.FC."
 	// jsFlowControl is for FC responses.
@@ -246,6 +251,10 @@ const (
 	// JSAdvisoryConsumerMsgTerminatedPre is a notification published when a message has been terminated.
 	JSAdvisoryConsumerMsgTerminatedPre = "$JS.EVENT.ADVISORY.CONSUMER.MSG_TERMINATED"
 
+	// JSConsumerDeliveryTracePre is a notification published, when enabled with
+	// DeliverTrace, explaining the delivery decisions of a consumer.
+	JSConsumerDeliveryTracePre = "$JS.EVENT.TRACE.CONSUMER.DELIVERY"
+
 	// JSAdvisoryStreamCreatedPre notification that a stream was created.
 	JSAdvisoryStreamCreatedPre = "$JS.EVENT.ADVISORY.STREAM.CREATED"
 
@@ -351,6 +360,16 @@ func generateJSMappingTable(domain string) map[string]string {
 	return mappings
 }
 
+// generateJSDomainMappingTable is like generateJSMappingTable, but also maps the
+// domain aliases, so that requests addressed to them reach the domain's API as well.
+func generateJSDomainMappingTable(domain string, aliases []string) map[string]string {
+	mappings := generateJSMappingTable(domain)
+	for _, alias := range aliases {
+		maps.Copy(mappings, generateJSMappingTable(alias))
+	}
+	return mappings
+}
+
 // JSMaxDescription is the maximum description length for streams and consumers.
 const JSMaxDescriptionLen = 4 * 1024
 
@@ -358,6 +377,9 @@ const JSMaxDescriptionLen = 4 * 1024
 // It's calculated by summing length of all keys and values.
 const JSMaxMetadataLen = 128 * 1024
 
+// JSMaxSkipHeaders is the maximum number of entries in a consumer's skip headers.
+const JSMaxSkipHeaders = 32
+
 // JSMaxNameLen is the maximum name lengths for streams, consumers and templates.
 // Picked 255 as it seems to be a widely used file name limit
 const JSMaxNameLen = 255
@@ -707,6 +729,9 @@ const JSWaitQueueDefaultMax = 512
 type JSApiConsumerCreateResponse struct {
 	ApiResponse
 	*ConsumerInfo
+	// Warnings describes implications of an update, e.g. for a tightened ack
+	// policy, and adjustments made by the server to the config.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 const JSApiConsumerCreateResponseType = "io.nats.jetstream.api.v1.consumer_create_response"
@@ -731,6 +756,15 @@ type JSApiConsumerPauseResponse struct {
 	PauseRemaining time.Duration `json:"pause_remaining,omitempty"`
 }
 
+// JSApiConsumerInfoRequest allows to request optional details in a consumer info response.
+type JSApiConsumerInfoRequest struct {
+	// DefaultedFields includes the names of the config fields that were filled in by the server.
+	DefaultedFields bool `json:"defaulted_fields,omitempty"`
+	// OmitCluster leaves out the cluster details to keep responses small, useful when
+	// polling often. When not set the server default, consumer_info_omit_cluster, applies.
+	OmitCluster *bool `json:"omit_cluster,omitempty"`
+}
+
 type JSApiConsumerInfoResponse struct {
 	ApiResponse
 	*ConsumerInfo
@@ -1032,6 +1066,9 @@ func (s *Server) sendAPIResponse(ci *ClientInfo, acc *Account, subject, reply, r
 		s.sendInternalAccountMsg(nil, reply, response)
 	}
 	s.sendJetStreamAPIAuditAdvisory(ci, acc, subject, request, response)
+	if auditSubj := s.getOpts().JetStreamAuditSubject; auditSubj != _EMPTY_ {
+		s.sendJetStreamAuditEvent(auditSubj, ci, acc, subject, request, response)
+	}
 }
 
 func (s *Server) sendAPIErrResponse(ci *ClientInfo, acc *Account, subject, reply, request, response string) {
@@ -1318,7 +1355,11 @@ func (s *Server) jsAccountInfoRequest(sub *subscription, c *client, _ *Account,
 		if !doErr {
 			return
 		}
-		resp.Error = NewJSNotEnabledForAccountError()
+		if !s.jetStreamAllowedForAccount(acc.GetName()) {
+			resp.Error = NewJSNotAllowedForAccountError()
+		} else {
+			resp.Error = NewJSNotEnabledForAccountError()
+		}
 	} else {
 		stats := acc.JetStreamUsage()
 		resp.JetStreamAccountStats = &stats
@@ -2027,6 +2068,8 @@ func (s *Server) jsStreamInfoRequest(sub *subscription, c *client, a *Account, s
 		Alternates: js.streamAlternates(ci, config.Name),
 		TimeStamp:  time.Now().UTC(),
 	}
+	resp.StreamInfo.DedupEntries = mset.numMsgIds()
+	resp.StreamInfo.MaxBufferedMsgs, resp.StreamInfo.MaxBufferedSize = mset.bufferedLimits()
 	if clusterWideConsCount > 0 {
 		resp.StreamInfo.State.Consumers = clusterWideConsCount
 	}
@@ -3881,6 +3924,26 @@ func (s *Server) jsStreamRestoreRequest(sub *subscription, c *client, _ *Account
 func (s *Server) processStreamRestore(ci *ClientInfo, acc *Account, cfg *StreamConfig, subject, reply, msg string) <-chan error {
 	var resp = JSApiStreamRestoreResponse{ApiResponse: ApiResponse{Type: JSApiStreamRestoreResponseType}}
 
+	// Returned to the caller to wait for completion.
+	doneCh := make(chan error, 1)
+
+	// Limit the number of restores in flight, clients can retry later.
+	var sem chan struct{}
+	if js := s.getJetStream(); js != nil {
+		sem = js.restoreSem
+	}
+	if !tryAcquireSem(sem) {
+		apiErr := NewJSStreamRestoreTooManyConcurrentError()
+		// When coming from the API, respond directly. Otherwise the caller
+		// will report the error returned through doneCh.
+		if subject != _EMPTY_ {
+			resp.Error = apiErr
+			s.sendAPIErrResponse(ci, acc, subject, reply, msg, s.jsonResponse(&resp))
+		}
+		doneCh <- apiErr
+		return doneCh
+	}
+
 	streamName := cfg.Name
 	s.Noticef("Starting restore for stream '%s > %s'", acc.Name, streamName)
 
@@ -4002,6 +4065,7 @@ func (s *Server) processStreamRestore(ci *ClientInfo, acc *Account, cfg *StreamC
 
 	sub, err := acc.subscribeInternal(restoreSubj, processChunk)
 	if err != nil {
+		releaseSem(sem)
 		closeWithError(err)
 		resp.Error = NewJSRestoreSubscribeFailedError(err, restoreSubj)
 		s.sendAPIErrResponse(ci, acc, subject, reply, msg, s.jsonResponse(&resp))
@@ -4012,13 +4076,11 @@ func (s *Server) processStreamRestore(ci *ClientInfo, acc *Account, cfg *StreamC
 	resp.DeliverSubject = restoreSubj
 	s.sendAPIResponse(ci, acc, subject, reply, msg, s.jsonResponse(resp))
 
-	// Returned to the caller to wait for completion.
-	doneCh := make(chan error, 1)
-
 	// Monitor the progress from another Go routine.
 	s.startGoRoutine(func() {
 		defer s.grWG.Done()
 		defer func() {
+			releaseSem(sem)
 			closeWithError(ErrConnectionClosed)
 			sub.client.processUnsub(sub.sid)
 			activeQ.unregister()
@@ -4192,9 +4254,21 @@ func (s *Server) jsStreamSnapshotRequest(sub *subscription, c *client, _ *Accoun
 		return
 	}
 
+	// Limit the number of snapshots in flight, clients can retry later.
+	var sem chan struct{}
+	if js := s.getJetStream(); js != nil {
+		sem = js.snapshotSem
+	}
+	if !tryAcquireSem(sem) {
+		resp.Error = NewJSStreamSnapshotTooManyConcurrentError()
+		s.sendAPIErrResponse(ci, acc, subject, reply, smsg, s.jsonResponse(&resp))
+		return
+	}
+
 	// We will do the snapshot in a go routine as well since check msgs may
 	// stall this go routine.
 	go func() {
+		defer releaseSem(sem)
 		if req.CheckMsgs {
 			s.Noticef("Starting health check and snapshot for stream '%s > %s'", mset.jsa.account.Name, mset.name())
 		} else {
@@ -4367,6 +4441,19 @@ const (
 	ccLegacyDurable
 )
 
+// hasExplicitDeliverPolicy returns whether the consumer create request in msg
+// contains a deliver policy, as opposed to relying on the DeliverAll default.
+func hasExplicitDeliverPolicy(msg []byte) bool {
+	var req struct {
+		Config map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return false
+	}
+	_, ok := req.Config["deliver_policy"]
+	return ok
+}
+
 // Request to create a consumer where stream and optional consumer name are part of the subject, and optional
 // filtered subjects can be at the tail end.
 // Assumes stream and consumer names are single tokens.
@@ -4572,8 +4659,18 @@ func (s *Server) jsConsumerCreateRequest(sub *subscription, c *client, a *Accoun
 		return
 	}
 
+	// If configured, do not let the deliver policy silently default to DeliverAll.
+	if s.getOpts().JetStreamRequireExplicitDeliverPolicy && !req.Config.Sourcing && !hasExplicitDeliverPolicy(msg) {
+		resp.Error = NewJSConsumerDeliverPolicyRequiredError()
+		s.sendAPIErrResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(&resp))
+		return
+	}
+
+	// Sourcing consumers follow the origin stream's subjects, so are not checked for overlap.
+	checkFilterOverlap := !req.AllowUnmatchedFilter && !req.Config.Sourcing
+
 	if isClustered && !direct {
-		s.jsClusteredConsumerRequest(ci, acc, subject, reply, rmsg, req.Stream, &req.Config, req.Action, req.Pedantic)
+		s.jsClusteredConsumerRequest(ci, acc, subject, reply, rmsg, req.Stream, &req.Config, req.Action, req.Pedantic, checkFilterOverlap, req.Force)
 		return
 	}
 
@@ -4613,16 +4710,26 @@ func (s *Server) jsConsumerCreateRequest(sub *subscription, c *client, a *Accoun
 		}
 	}
 
+	var ackWarning string
 	if o := stream.lookupConsumer(consumerName); o != nil {
 		if o.offlineReason != _EMPTY_ {
 			resp.Error = NewJSConsumerOfflineReasonError(errors.New(o.offlineReason))
 			s.sendDelayedAPIErrResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(&resp), nil, errRespDelay)
 			return
 		}
+		// Unless forced, do not lower MaxAckPending below what is currently pending.
+		if !req.Force {
+			if err := o.checkMaxAckPendingUpdate(req.Config.MaxAckPending); err != nil {
+				resp.Error = err
+				s.sendAPIErrResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(&resp))
+				return
+			}
+		}
 		// If the consumer already exists then don't allow updating the PauseUntil, just set
 		// it back to whatever the current configured value is.
 		o.mu.RLock()
 		req.Config.PauseUntil = o.cfg.PauseUntil
+		ackWarning, _ = checkAckPolicyUpdate(o.cfg.AckPolicy, req.Config.AckPolicy)
 		// If a durable sourcing consumer is used, we need to reset the deliver policy.
 		if req.Config.Sourcing && req.Config.Durable != _EMPTY_ {
 			req.Config.DeliverPolicy = o.cfg.DeliverPolicy
@@ -4635,7 +4742,7 @@ func (s *Server) jsConsumerCreateRequest(sub *subscription, c *client, a *Accoun
 	// Initialize/update asset version metadata.
 	setStaticConsumerMetadata(&req.Config)
 
-	o, err := stream.addConsumerWithAction(&req.Config, req.Action, req.Pedantic)
+	o, err := stream.addConsumerWithAssignment(&req.Config, _EMPTY_, nil, false, req.Action, req.Pedantic, checkFilterOverlap)
 
 	if err != nil {
 		if IsNatsErr(err, JSConsumerStoreFailedErrF) {
@@ -4648,6 +4755,7 @@ func (s *Server) jsConsumerCreateRequest(sub *subscription, c *client, a *Accoun
 		return
 	}
 	resp.ConsumerInfo = setDynamicConsumerInfoMetadata(o.initialInfo())
+	resp.Warnings = o.createWarnings(ackWarning)
 	s.sendAPIResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(resp))
 
 	o.mu.RLock()
@@ -4910,10 +5018,14 @@ func (s *Server) jsConsumerInfoRequest(sub *subscription, c *client, _ *Account,
 
 	var resp = JSApiConsumerInfoResponse{ApiResponse: ApiResponse{Type: JSApiConsumerInfoResponseType}}
 
+	// Only a request for optional details is allowed, anything else must be empty.
+	var req JSApiConsumerInfoRequest
 	if !isEmptyRequest(msg) {
-		resp.Error = NewJSNotEmptyRequestError()
-		s.sendAPIErrResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(&resp))
-		return
+		if err := s.unmarshalRequest(c, acc, subject, msg, &req); err != nil {
+			resp.Error = NewJSNotEmptyRequestError()
+			s.sendAPIErrResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(&resp))
+			return
+		}
 	}
 
 	// If we are in clustered mode we need to be the consumer leader to proceed.
@@ -5039,6 +5151,9 @@ func (s *Server) jsConsumerInfoRequest(sub *subscription, c *client, _ *Account,
 						Config:    setDynamicConsumerMetadata(ca.Config),
 						TimeStamp: time.Now().UTC(),
 					}
+					if req.DefaultedFields {
+						resp.ConsumerInfo.DefaultedFields = ca.DefaultedFields
+					}
 					b := s.jsonResponse(resp)
 					js.mu.RUnlock()
 					s.sendAPIResponse(ci, acc, subject, reply, string(msg), b)
@@ -5097,12 +5212,19 @@ func (s *Server) jsConsumerInfoRequest(sub *subscription, c *client, _ *Account,
 		return
 	}
 
-	if resp.ConsumerInfo = setDynamicConsumerInfoMetadata(obs.info()); resp.ConsumerInfo == nil {
+	omitCluster := s.getOpts().JetStreamConsumerInfoOmitCluster
+	if req.OmitCluster != nil {
+		omitCluster = *req.OmitCluster
+	}
+	if resp.ConsumerInfo = setDynamicConsumerInfoMetadata(obs.infoWithCluster(!omitCluster)); resp.ConsumerInfo == nil {
 		// This consumer returned nil which means it's closed. Respond with not found.
 		resp.Error = NewJSConsumerNotFoundError()
 		s.sendAPIErrResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(&resp))
 		return
 	}
+	if req.DefaultedFields {
+		resp.ConsumerInfo.DefaultedFields = obs.defaultedFields()
+	}
 	s.sendAPIResponse(ci, acc, subject, reply, string(msg), s.jsonResponse(resp))
 }
 
@@ -5372,3 +5494,71 @@ func (s *Server) sendJetStreamAPIAuditAdvisory(ci *ClientInfo, acc *Account, sub
 		Domain:   s.getOpts().JetStreamDomain,
 	})
 }
+
+// sendJetStreamAuditEvent publishes an audit event to the system account on the
+// configured audit subject for successful stream and consumer lifecycle requests.
+// The consumer action is only known when the request is available, which is not
+// the case for responses sent by clustered assignments.
+func (s *Server) sendJetStreamAuditEvent(auditSubj string, ci *ClientInfo, acc *Account, subject, request, response string) {
+	var action ActionAdvisoryType
+	var stream, consumer string
+	var isConsumer bool
+
+	switch {
+	case strings.HasPrefix(subject, "$JS.API.STREAM.CREATE."):
+		action, stream = CreateEvent, tokenAt(subject, 5)
+	case strings.HasPrefix(subject, "$JS.API.STREAM.UPDATE."):
+		action, stream = ModifyEvent, tokenAt(subject, 5)
+	case strings.HasPrefix(subject, "$JS.API.STREAM.DELETE."):
+		action, stream = DeleteEvent, tokenAt(subject, 5)
+	case strings.HasPrefix(subject, "$JS.API.CONSUMER.CREATE."):
+		action, stream, isConsumer = CreateEvent, tokenAt(subject, 5), true
+	case strings.HasPrefix(subject, "$JS.API.CONSUMER.DURABLE.CREATE."):
+		action, stream, isConsumer = CreateEvent, tokenAt(subject, 6), true
+	case strings.HasPrefix(subject, "$JS.API.CONSUMER.DELETE."):
+		action, stream, consumer = DeleteEvent, tokenAt(subject, 5), tokenAt(subject, 6)
+	default:
+		return
+	}
+
+	// Only successful requests are audited.
+	var resp ApiResponse
+	if err := json.Unmarshal([]byte(response), &resp); err != nil || resp.Error != nil {
+		return
+	}
+
+	var consumerAction string
+	if isConsumer {
+		var cresp JSApiConsumerCreateResponse
+		if json.Unmarshal([]byte(response), &cresp) == nil && cresp.ConsumerInfo != nil {
+			consumer = cresp.ConsumerInfo.Name
+		}
+		var req CreateConsumerRequest
+		if request != _EMPTY_ && json.Unmarshal([]byte(request), &req) == nil {
+			switch req.Action {
+			case ActionCreate:
+				consumerAction = "create"
+			case ActionUpdate:
+				action, consumerAction = ModifyEvent, "update"
+			default:
+				consumerAction = "create_or_update"
+			}
+		}
+	}
+
+	s.publishAdvisory(nil, auditSubj, JSAuditEvent{
+		TypedEvent: TypedEvent{
+			Type: JSAuditEventType,
+			ID:   nuid.Next(),
+			Time: time.Now().UTC(),
+		},
+		Server:         s.Name(),
+		Client:         ci.forAdvisory(),
+		Account:        acc.GetName(),
+		Stream:         stream,
+		Consumer:       consumer,
+		Action:         action,
+		ConsumerAction: consumerAction,
+		Domain:         s.getOpts().JetStreamDomain,
+	})
+}
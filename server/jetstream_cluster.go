@@ -117,6 +117,9 @@ type Placement struct {
 	Cluster   string   `json:"cluster,omitempty"`
 	Tags      []string `json:"tags,omitempty"`
 	Preferred string   `json:"preferred,omitempty"`
+	// Servers pins the replicas to the named servers. When set, tags are not
+	// considered and placement fails if not enough of these servers are available.
+	Servers []string `json:"servers,omitempty"`
 }
 
 // Define types of the entry.
@@ -304,10 +307,13 @@ type consumerAssignment struct {
 	Subject    string          `json:"subject,omitempty"`
 	Reply      string          `json:"reply,omitempty"`
 	State      *ConsumerState  `json:"state,omitempty"`
+	// DefaultedFields names the config fields that were filled in by the meta leader.
+	DefaultedFields []string `json:"defaulted_fields,omitempty"`
 	// Internal
 	responded   atomic.Bool // copied via clone() to satisfy go vet's noCopy check
 	recovering  bool
 	err         error
+	warning     string // Warning to include in the response, e.g. for a tightened ack policy.
 	unsupported *unsupportedConsumerAssignment
 }
 
@@ -357,8 +363,10 @@ func (ca *consumerAssignment) clone() *consumerAssignment {
 		State:       ca.State,
 		recovering:  ca.recovering,
 		err:         ca.err,
+		warning:     ca.warning,
 		unsupported: ca.unsupported,
 	}
+	cca.DefaultedFields = ca.DefaultedFields
 	cca.responded.Store(ca.responded.Load())
 	return cca
 }
@@ -434,6 +442,8 @@ type writeableConsumerAssignment struct {
 	Stream     string          `json:"stream"`
 	ConfigJSON json.RawMessage `json:"consumer"`
 	Group      *raftGroup      `json:"group"`
+	// DefaultedFields names the config fields that were filled in by the meta leader.
+	DefaultedFields []string `json:"defaulted_fields,omitempty"`
 }
 
 // streamPurge is what the stream leader will replicate when purging a stream.
@@ -481,6 +491,48 @@ func (s *Server) trackedJetStreamServers() (js, total int) {
 	return js, total
 }
 
+// Returns the cluster name of the known server with the given name, if any.
+func (s *Server) clusterNameForServer(name string) string {
+	var cluster string
+	s.nodeToInfo.Range(func(k, v any) bool {
+		if ni := v.(nodeInfo); ni.name == name {
+			cluster = ni.cluster
+			return false
+		}
+		return true
+	})
+	return cluster
+}
+
+// Returns the cluster of the known servers that replicas are pinned to
+// with placement, if any, along with the names of the servers that are
+// not known. Servers are checked to all be in the same cluster when the
+// stream config is validated.
+func (s *Server) placementServersCluster(p *Placement) (string, []string) {
+	if p == nil {
+		return _EMPTY_, nil
+	}
+	var cluster string
+	var unknown []string
+	for _, name := range p.Servers {
+		if cn := s.clusterNameForServer(name); cn == _EMPTY_ {
+			unknown = append(unknown, name)
+		} else if cluster == _EMPTY_ {
+			cluster = cn
+		}
+	}
+	return cluster, unknown
+}
+
+// Returns a peer selection error for servers pinned with placement that are not known.
+func unknownPlacementServersError(unknown []string) *selectPeerError {
+	err := &selectPeerError{}
+	for _, name := range unknown {
+		err.addMissingServer(name)
+	}
+	return err
+}
+
 func (s *Server) getJetStreamCluster() (*jetStream, *jetStreamCluster) {
 	if s.isShuttingDown() {
 		return nil, nil
@@ -1921,6 +1973,7 @@ func (js *jetStream) monitorCluster() {
 					// Signals we have replayed all of our metadata.
 					wasMetaRecovering := js.isMetaRecovering()
 					js.clearMetaRecovering()
+					js.checkPreloadStreams()
 					recovering = false
 					// Clear.
 					ru = nil
@@ -2291,7 +2344,7 @@ func (js *jetStream) decodeMetaSnapshot(buf []byte) (map[string]map[string]*stre
 				if wca.Stream == _EMPTY_ {
 					wca.Stream = sa.Config.Name // Rehydrate from the stream name.
 				}
-				ca := &consumerAssignment{Client: wca.Client, Created: wca.Created, Name: wca.Name, Stream: wca.Stream, ConfigJSON: wca.ConfigJSON, Group: wca.Group}
+				ca := &consumerAssignment{Client: wca.Client, Created: wca.Created, Name: wca.Name, Stream: wca.Stream, ConfigJSON: wca.ConfigJSON, Group: wca.Group, DefaultedFields: wca.DefaultedFields}
 				if err := decodeConsumerAssignmentConfig(ca); err != nil {
 					return nil, err
 				}
@@ -2325,12 +2378,13 @@ func (js *jetStream) encodeMetaSnapshot(streams map[string]map[string]*streamAss
 			}
 			for _, ca := range sa.consumers {
 				wca := writeableConsumerAssignment{
-					Client:     ca.Client.forAssignmentSnap(),
-					Created:    ca.Created,
-					Name:       ca.Name,
-					Stream:     ca.Stream,
-					ConfigJSON: ca.ConfigJSON,
-					Group:      ca.Group,
+					Client:          ca.Client.forAssignmentSnap(),
+					Created:         ca.Created,
+					Name:            ca.Name,
+					Stream:          ca.Stream,
+					ConfigJSON:      ca.ConfigJSON,
+					Group:           ca.Group,
+					DefaultedFields: ca.DefaultedFields,
 				}
 				wsa.Consumers = append(wsa.Consumers, &wca)
 				nca++
@@ -2602,7 +2656,7 @@ func (js *jetStream) processRemovePeer(peer string) {
 			},
 			Server:   s.Name(),
 			ServerID: s.ID(),
-			Cluster:  s.cachedClusterName(),
+			Cluster:  s.jsClusterName(),
 			Domain:   s.getOpts().JetStreamDomain,
 		}
 		s.publishAdvisory(nil, JSAdvisoryServerRemoved, adv)
@@ -3864,7 +3918,7 @@ func (js *jetStream) runStreamMigration(mset *stream, sa *streamAssignment, n Ra
 		csa := sa.copyGroup()
 		csa.Group.Peers = newPeers
 		csa.Group.Preferred = ourPeerId
-		csa.Group.Cluster = s.cachedClusterName()
+		csa.Group.Cluster = s.jsClusterName()
 		meta.ForwardProposal(encodeUpdateStreamAssignment(csa))
 		s.Noticef("Scaling down '%s > %s' to %+v", accName, streamName, s.peerSetToNames(newPeers))
 	} else {
@@ -6101,7 +6155,7 @@ func (js *jetStream) processClusterCreateConsumer(oca, ca *consumerAssignment, s
 	var didCreate, isConfigUpdate, needsLocalResponse bool
 	if o == nil {
 		// Add in the consumer if needed.
-		if o, err = mset.addConsumerWithAssignment(ca.Config, ca.Name, ca, js.isMetaRecovering(), ActionCreateOrUpdate, false); err == nil {
+		if o, err = mset.addConsumerWithAssignment(ca.Config, ca.Name, ca, js.isMetaRecovering(), ActionCreateOrUpdate, false, false); err == nil {
 			didCreate = true
 		}
 	} else {
@@ -6114,6 +6168,10 @@ func (js *jetStream) processClusterCreateConsumer(oca, ca *consumerAssignment, s
 			// JS lock needed as this can mutate the consumer assignments and race with updateInactivityThreshold.
 			js.mu.Lock()
 			err := o.updateConfig(ca.Config)
+			if err == nil {
+				ca.warning, _ = checkAckPolicyUpdate(cfg.AckPolicy, ca.Config.AckPolicy)
+				o.setDefaultedFields(ca.DefaultedFields)
+			}
 			js.mu.Unlock()
 			if err != nil && err != NewJSConsumerNameExistError() {
 				// This is essentially an update that has failed. Respond back to metaleader if we are not recovering.
@@ -6132,6 +6190,9 @@ func (js *jetStream) processClusterCreateConsumer(oca, ca *consumerAssignment, s
 				js.mu.RUnlock()
 				return
 			}
+		} else if ca.Config.PauseUntil == nil && !js.isMetaRecovering() {
+			// A resume request proposes an unchanged config, which clears the delivery quota.
+			o.resumeDeliveryQuota()
 		}
 
 		var sendState bool
@@ -6251,7 +6312,7 @@ func (js *jetStream) processClusterCreateConsumer(oca, ca *consumerAssignment, s
 				// Need to clear from rg too.
 				js.mu.Lock()
 				rg.node = nil
-				client, subject, reply := ca.Client, ca.Subject, ca.Reply
+				client, subject, reply, warning := ca.Client, ca.Subject, ca.Reply, ca.warning
 				js.mu.Unlock()
 				// Perform the leader change in a goroutine, otherwise we could block meta operations.
 				if o.shouldStartMonitor() {
@@ -6266,6 +6327,7 @@ func (js *jetStream) processClusterCreateConsumer(oca, ca *consumerAssignment, s
 								s.sendAPIErrResponse(client, acc, subject, reply, _EMPTY_, s.jsonResponse(&resp))
 							} else {
 								resp.ConsumerInfo = setDynamicConsumerInfoMetadata(o.info())
+								resp.Warnings = o.createWarnings(warning)
 								s.sendAPIResponse(client, acc, subject, reply, _EMPTY_, s.jsonResponse(&resp))
 							}
 						},
@@ -6338,7 +6400,7 @@ func (js *jetStream) processClusterCreateConsumer(oca, ca *consumerAssignment, s
 				if o.IsLeader() || (!didCreate && needsLocalResponse) {
 					// Process if existing as an update. Double check that this is not recovered.
 					js.mu.RLock()
-					client, subject, reply, recovering, sourcing := ca.Client, ca.Subject, ca.Reply, ca.recovering, ca.Config.Sourcing
+					client, subject, reply, recovering, sourcing, warning := ca.Client, ca.Subject, ca.Reply, ca.recovering, ca.Config.Sourcing, ca.warning
 					js.mu.RUnlock()
 					if !recovering {
 						// If it's a sourcing consumer, we need to respond after the consumer has been reset instead.
@@ -6356,6 +6418,7 @@ func (js *jetStream) processClusterCreateConsumer(oca, ca *consumerAssignment, s
 						} else {
 							var resp = JSApiConsumerCreateResponse{ApiResponse: ApiResponse{Type: JSApiConsumerCreateResponseType}}
 							resp.ConsumerInfo = setDynamicConsumerInfoMetadata(o.info())
+							resp.Warnings = o.createWarnings(warning)
 							s.sendAPIResponse(client, acc, subject, reply, _EMPTY_, s.jsonResponse(&resp))
 						}
 					}
@@ -6865,7 +6928,7 @@ func (js *jetStream) runConsumerMigration(o *consumer, ca *consumerAssignment, n
 		n.ProposeKnownPeers(newPeers)
 		cca := ca.copyGroup()
 		cca.Group.Peers = newPeers
-		cca.Group.Cluster = s.cachedClusterName()
+		cca.Group.Cluster = s.jsClusterName()
 		meta.ForwardProposal(encodeAddConsumerAssignment(cca))
 		s.Noticef("Scaling down '%s > %s > %s' to %+v", accName, streamName, consumerName, s.peerSetToNames(newPeers))
 
@@ -7007,6 +7070,7 @@ func (js *jetStream) applyConsumerEntries(o *consumer, ce *CommittedEntry, isLea
 					dsubj, seq := pmsg.dsubj, pmsg.seq
 					o.outq.send(pmsg)
 					delete(o.pendingDeliveries, sseq)
+					o.recordQuorumDeliveryLatency(sseq)
 
 					// Might need to send a request timeout after sending the last replicated delivery.
 					if wd, ok := o.waitingDeliveries[dsubj]; ok && wd.seq == seq {
@@ -7252,7 +7316,7 @@ func (js *jetStream) processConsumerLeaderChangeWithAssignment(o *consumer, ca *
 	js.mu.RLock()
 	s, account, err := js.srv, ca.Client.serviceAccount(), ca.err
 	client, subject, reply, streamName, consumerName, sourcing := ca.Client, ca.Subject, ca.Reply, ca.Stream, ca.Name, ca.Config.Sourcing
-	hasResponded := ca.markResponded()
+	warning, hasResponded := ca.warning, ca.markResponded()
 	js.mu.RUnlock()
 
 	acc, _ := s.LookupAccount(account)
@@ -7309,6 +7373,7 @@ func (js *jetStream) processConsumerLeaderChangeWithAssignment(o *consumer, ca *
 			}
 		} else {
 			resp.ConsumerInfo = setDynamicConsumerInfoMetadata(o.initialInfo())
+			resp.Warnings = o.createWarnings(warning)
 			s.sendAPIResponse(client, acc, subject, reply, _EMPTY_, s.jsonResponse(&resp))
 		}
 		o.sendCreateAdvisory()
@@ -7635,7 +7700,7 @@ func (s *Server) sendDomainLeaderElectAdvisory() {
 		},
 		Leader:   node.GroupLeader(),
 		Replicas: s.replicas(node),
-		Cluster:  s.cachedClusterName(),
+		Cluster:  s.jsClusterName(),
 		Domain:   s.getOpts().JetStreamDomain,
 	}
 
@@ -7876,6 +7941,7 @@ type selectPeerError struct {
 	noJsClust   bool
 	noMatchTags map[string]struct{}
 	excludeTags map[string]struct{}
+	noServers   map[string]struct{}
 }
 
 func (e *selectPeerError) Error() string {
@@ -7922,6 +7988,20 @@ func (e *selectPeerError) Error() string {
 		}
 		b.WriteString("]")
 	}
+	if len(e.noServers) != 0 {
+		b.WriteString(", placement servers not available [")
+		var firstServerWritten bool
+		for server := range e.noServers {
+			if firstServerWritten {
+				b.WriteString(", ")
+			}
+			firstServerWritten = true
+			b.WriteRune('\'')
+			b.WriteString(server)
+			b.WriteRune('\'')
+		}
+		b.WriteString("]")
+	}
 
 	return b.String()
 }
@@ -7940,6 +8020,13 @@ func (e *selectPeerError) addExcludeTag(t string) {
 	e.excludeTags[t] = struct{}{}
 }
 
+func (e *selectPeerError) addMissingServer(name string) {
+	if e.noServers == nil {
+		e.noServers = map[string]struct{}{}
+	}
+	e.noServers[name] = struct{}{}
+}
+
 func (e *selectPeerError) accumulate(eAdd *selectPeerError) {
 	if eAdd == nil {
 		return
@@ -7961,6 +8048,9 @@ func (e *selectPeerError) accumulate(eAdd *selectPeerError) {
 	for tag := range eAdd.excludeTags {
 		e.addExcludeTag(tag)
 	}
+	for server := range eAdd.noServers {
+		e.addMissingServer(server)
+	}
 }
 
 // selectPeerGroup will select a group of peers to start a raft group.
@@ -7982,7 +8072,14 @@ func (cc *jetStreamCluster) selectPeerGroup(r int, cluster string, cfg *StreamCo
 		exclude bool
 	}
 	var ti []tagInfo
-	if cfg.Placement != nil {
+	// Check for servers the replicas are pinned to, these take precedence over tags.
+	var sp map[string]bool
+	if cfg.Placement != nil && len(cfg.Placement.Servers) > 0 {
+		sp = make(map[string]bool, len(cfg.Placement.Servers))
+		for _, name := range cfg.Placement.Servers {
+			sp[name] = false
+		}
+	} else if cfg.Placement != nil {
 		ti = make([]tagInfo, 0, len(cfg.Placement.Tags))
 		for _, t := range cfg.Placement.Tags {
 			ti = append(ti, tagInfo{
@@ -8006,7 +8103,10 @@ func (cc *jetStreamCluster) selectPeerGroup(r int, cluster string, cfg *StreamCo
 	s, peers := cc.s, cc.meta.Peers()
 
 	uniqueTagPrefix := s.getOpts().JetStreamUniqueTag
-	if uniqueTagPrefix != _EMPTY_ {
+	if sp != nil {
+		// Servers named explicitly are not subject to the uniqueness check.
+		uniqueTagPrefix = _EMPTY_
+	} else if uniqueTagPrefix != _EMPTY_ {
 		for _, t := range ti {
 			if strings.HasPrefix(t.tag, uniqueTagPrefix) {
 				// disable uniqueness check if explicitly listed in tags
@@ -8099,6 +8199,13 @@ func (cc *jetStreamCluster) selectPeerGroup(r int, cluster string, cfg *StreamCo
 			continue
 		}
 		ni := si.(nodeInfo)
+		// Only select from the servers the replicas are pinned to.
+		if sp != nil {
+			if _, ok := sp[ni.name]; !ok {
+				s.Debugf("Peer selection: discard %s@%s reason: not a placement server", ni.name, ni.cluster)
+				continue
+			}
+		}
 		// Only select from the designated named cluster.
 		if ni.cluster != cluster {
 			s.Debugf("Peer selection: discard %s@%s reason: not target cluster %s", ni.name, ni.cluster, cluster)
@@ -8204,6 +8311,9 @@ func (cc *jetStreamCluster) selectPeerGroup(r int, cluster string, cfg *StreamCo
 		}
 		// Add to our list of potential nodes.
 		nodes = append(nodes, wn{p.ID, available, ni.offline, peerHA[p.ID], peerStreams[p.ID]})
+		if sp != nil {
+			sp[ni.name] = true
+		}
 		if !ni.offline {
 			onlinePeers++
 		}
@@ -8218,6 +8328,11 @@ func (cc *jetStreamCluster) selectPeerGroup(r int, cluster string, cfg *StreamCo
 		} else if !missingNodes && missingQuorum {
 			err.offline = true
 		}
+		for name, selectable := range sp {
+			if !selectable {
+				err.addMissingServer(name)
+			}
+		}
 		s.Debugf("Peer selection: required %d nodes but found %d (cluster: %s replica: %d existing: %v/%d peers: %d result-peers: %d err: %+v)",
 			r-len(existing), len(nodes), cluster, r, existing, replaceFirstExisting, len(peers), len(nodes), err)
 		return nil, &err
@@ -8296,6 +8411,19 @@ func (js *jetStream) tieredStreamAndReservationCount(accName, tier string, cfg *
 	return numStreams, reservation
 }
 
+// Returns the JetStream cluster name of the server the client is connected to,
+// which may differ from the routing cluster name in the client info.
+func (s *Server) jsClusterForClient(ci *ClientInfo) string {
+	if ci.Server != _EMPTY_ {
+		if ni, ok := s.nodeToInfo.Load(getHash(ci.Server)); ok && ni != nil {
+			if cn := ni.(nodeInfo).cluster; cn != _EMPTY_ {
+				return cn
+			}
+		}
+	}
+	return ci.Cluster
+}
+
 // createGroupForStream will create a group for assignment for the stream.
 // Lock should be held.
 func (js *jetStream) createGroupForStream(ci *ClientInfo, cfg *StreamConfig) (*raftGroup, *selectPeerError) {
@@ -8305,11 +8433,18 @@ func (js *jetStream) createGroupForStream(ci *ClientInfo, cfg *StreamConfig) (*r
 	}
 
 	// Default connected cluster from the request origin.
-	cc, cluster := js.cluster, ci.Cluster
+	cc, cluster := js.cluster, js.srv.jsClusterForClient(ci)
 	// If specified, override the default.
 	clusterDefined := cfg.Placement != nil && cfg.Placement.Cluster != _EMPTY_
+	// Servers pinned by name need to be known and determine the cluster.
+	cn, unknown := js.srv.placementServersCluster(cfg.Placement)
+	if len(unknown) > 0 {
+		return nil, unknownPlacementServersError(unknown)
+	}
 	if clusterDefined {
 		cluster = cfg.Placement.Cluster
+	} else if cn != _EMPTY_ {
+		cluster, clusterDefined = cn, true
 	}
 	clusters := []string{cluster}
 	if !clusterDefined {
@@ -8371,6 +8506,41 @@ func (js *jetStream) jsClusteredStreamLimitsCheck(acc *Account, cfg *StreamConfi
 	return nil
 }
 
+// jsClusteredHAAssetsLimitCheck checks if the account is allowed to add another
+// replicated stream or consumer. When the account has its own max HA assets limit,
+// the tighter of the account and server limits applies.
+// Read lock needs to be held.
+func (js *jetStream) jsClusteredHAAssetsLimitCheck(acc *Account, replicas int) *ApiError {
+	selectedLimits, _, _, apiErr := acc.selectLimits(replicas)
+	if apiErr != nil {
+		return apiErr
+	}
+	maxHaAssets, limit := selectedLimits.MaxHAAssets, "account"
+	if maxHaAssets <= 0 {
+		// Only the server wide limit applies, which is enforced per server.
+		return nil
+	}
+	if srvMax := js.srv.getOpts().JetStreamLimits.MaxHAAssets; srvMax > 0 && srvMax < maxHaAssets {
+		maxHaAssets, limit = srvMax, "server"
+	}
+
+	var numHaAssets int
+	for sa := range js.streamAssignmentsOrInflightSeq(acc.Name) {
+		if sa.Group != nil && len(sa.Group.Peers) > 1 {
+			numHaAssets++
+		}
+		for ca := range js.consumerAssignmentsOrInflightSeq(acc.Name, sa.Config.Name) {
+			if ca.Group != nil && len(ca.Group.Peers) > 1 {
+				numHaAssets++
+			}
+		}
+	}
+	if numHaAssets >= maxHaAssets {
+		return NewJSMaximumHAAssetsLimitError(limit, maxHaAssets)
+	}
+	return nil
+}
+
 func (s *Server) jsClusteredStreamRequest(ci *ClientInfo, acc *Account, subject, reply string, rmsg []byte, config *StreamConfigRequest) {
 	js, cc := s.getJetStreamCluster()
 	if js == nil || cc == nil {
@@ -8441,6 +8611,13 @@ func (s *Server) jsClusteredStreamRequest(ci *ClientInfo, acc *Account, subject,
 
 	// Create a new one here if needed.
 	if rg == nil {
+		if cfg.Replicas > 1 {
+			if apiErr = js.jsClusteredHAAssetsLimitCheck(acc, cfg.Replicas); apiErr != nil {
+				resp.Error = apiErr
+				s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
+				return
+			}
+		}
 		nrg, err := js.createGroupForStream(ci, cfg)
 		if err != nil {
 			resp.Error = NewJSClusterNoPeersError(err)
@@ -8693,16 +8870,34 @@ func (s *Server) jsClusteredStreamUpdateRequest(ci *ClientInfo, acc *Account, su
 				s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
 				return
 			}
+			// Scaling up from a single replica adds a replicated stream.
+			if len(rg.Peers) <= 1 {
+				if err := js.jsClusteredHAAssetsLimitCheck(acc, newCfg.Replicas); err != nil {
+					resp.Error = err
+					s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
+					return
+				}
+			}
 			// Check if we do not have a cluster assigned, and if we do not make sure we
 			// try to pick one. This could happen with older streams that were assigned by
 			// previous servers.
+			// Servers pinned by name need to be known.
+			pcn, unknown := s.placementServersCluster(newCfg.Placement)
+			if len(unknown) > 0 {
+				resp.Error = NewJSClusterNoPeersError(unknownPlacementServersError(unknown))
+				s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
+				return
+			}
 			if rg.Cluster == _EMPTY_ {
 				// Prefer placement directives if we have them.
 				if newCfg.Placement != nil && newCfg.Placement.Cluster != _EMPTY_ {
 					rg.Cluster = newCfg.Placement.Cluster
+				} else if pcn != _EMPTY_ {
+					// Servers pinned by name determine the cluster.
+					rg.Cluster = pcn
 				} else {
 					// Fall back to the cluster assignment from the client.
-					rg.Cluster = ci.Cluster
+					rg.Cluster = s.jsClusterForClient(ci)
 				}
 			}
 			peers, err := cc.selectPeerGroup(newCfg.Replicas, rg.Cluster, newCfg, rg.Peers, 0, nil)
@@ -9547,7 +9742,7 @@ func (cc *jetStreamCluster) createGroupForConsumer(cfg *ConsumerConfig, sa *stre
 }
 
 // jsClusteredConsumerRequest is first point of entry to create a consumer in clustered mode.
-func (s *Server) jsClusteredConsumerRequest(ci *ClientInfo, acc *Account, subject, reply string, rmsg []byte, stream string, cfg *ConsumerConfig, action ConsumerAction, pedantic bool) {
+func (s *Server) jsClusteredConsumerRequest(ci *ClientInfo, acc *Account, subject, reply string, rmsg []byte, stream string, cfg *ConsumerConfig, action ConsumerAction, pedantic, checkFilterOverlap, force bool) {
 	js, cc := s.getJetStreamCluster()
 	if js == nil || cc == nil {
 		return
@@ -9580,13 +9775,14 @@ func (s *Server) jsClusteredConsumerRequest(ci *ClientInfo, acc *Account, subjec
 	}
 	srvLim := &s.getOpts().JetStreamLimits
 	// Make sure we have sane defaults
-	if err := setConsumerConfigDefaults(cfg, &streamCfg, srvLim, selectedLimits, pedantic); err != nil {
+	defaulted, err := setConsumerConfigDefaultsWithFields(cfg, &streamCfg, srvLim, selectedLimits, pedantic)
+	if err != nil {
 		resp.Error = err
 		s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
 		return
 	}
 
-	if err := checkConsumerCfg(cfg, srvLim, &streamCfg, acc, selectedLimits, false); err != nil {
+	if err := checkConsumerCfg(cfg, srvLim, &streamCfg, acc, selectedLimits, false, checkFilterOverlap); err != nil {
 		resp.Error = err
 		s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
 		return
@@ -9728,6 +9924,13 @@ func (s *Server) jsClusteredConsumerRequest(ci *ClientInfo, acc *Account, subjec
 			s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
 			return
 		}
+		if replicas := cfg.replicas(sa.Config); replicas > 1 {
+			if apiErr := js.jsClusteredHAAssetsLimitCheck(acc, replicas); apiErr != nil {
+				resp.Error = apiErr
+				s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
+				return
+			}
+		}
 		rg := cc.createGroupForConsumer(cfg, sa)
 		if rg == nil {
 			resp.Error = NewJSInsufficientResourcesError()
@@ -9809,14 +10012,15 @@ func (s *Server) jsClusteredConsumerRequest(ci *ClientInfo, acc *Account, subjec
 		}
 
 		ca = &consumerAssignment{
-			Group:   rg,
-			Stream:  stream,
-			Name:    oname,
-			Config:  cfg,
-			Subject: subject,
-			Reply:   reply,
-			Client:  ci,
-			Created: time.Now().UTC(),
+			Group:           rg,
+			Stream:          stream,
+			Name:            oname,
+			Config:          cfg,
+			Subject:         subject,
+			Reply:           reply,
+			Client:          ci,
+			Created:         time.Now().UTC(),
+			DefaultedFields: defaulted,
 		}
 	} else {
 		// If the consumer already exists then don't allow updating the PauseUntil, just set
@@ -9828,9 +10032,35 @@ func (s *Server) jsClusteredConsumerRequest(ci *ClientInfo, acc *Account, subjec
 		// Reset notion of scaling up, if this was done in a previous update.
 		nca.Group.ScaleUp = false
 
+		// Unless forced, do not lower MaxAckPending below what is currently pending.
+		// Only the consumer leader knows, so ask it. We are in our own Go routine here.
+		if !force && lowersMaxAckPending(ca.Config.MaxAckPending, cfg.MaxAckPending) && !s.allPeersOffline(ca.Group) {
+			// Need to release js lock.
+			js.mu.Unlock()
+			oci, err := sysRequest[ConsumerInfo](s, clusterConsumerInfoT, ci.serviceAccount(), sa.Config.Name, oname)
+			// Re-acquire here.
+			js.mu.Lock()
+			if err != nil {
+				s.Warnf("Did not receive consumer info results for '%s > %s > %s' due to: %s", acc, sa.Config.Name, oname, err)
+			} else if oci != nil && oci.NumAckPending > cfg.MaxAckPending {
+				resp.Error = NewJSConsumerMaxAckPendingBelowPendingError(oci.NumAckPending)
+				s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
+				return
+			}
+		}
+
 		rBefore := nca.Config.replicas(sa.Config)
 		rAfter := cfg.replicas(sa.Config)
 
+		// Scaling up from a single replica adds a replicated consumer.
+		if rBefore <= 1 && rAfter > 1 {
+			if apiErr := js.jsClusteredHAAssetsLimitCheck(acc, rAfter); apiErr != nil {
+				resp.Error = apiErr
+				s.sendAPIErrResponse(ci, acc, subject, reply, string(rmsg), s.jsonResponse(&resp))
+				return
+			}
+		}
+
 		var curLeader string
 		if rBefore != rAfter {
 			// We are modifying nodes here. We want to do our best to preserve the current leader.
@@ -9898,6 +10128,7 @@ func (s *Server) jsClusteredConsumerRequest(ci *ClientInfo, acc *Account, subjec
 
 		// Update config and client info on copy of existing.
 		nca.Config = cfg
+		nca.DefaultedFields = defaulted
 		nca.Client = ci
 		nca.Subject = subject
 		nca.Reply = reply
@@ -10319,6 +10550,15 @@ func (mset *stream) processClusteredInboundMsg(subject, reply string, hdr, msg [
 		return NewJSInsufficientResourcesError()
 	}
 
+	// Reject writes to memory storage while the system is low on free memory.
+	if js.memoryLow(stype) {
+		if canRespond {
+			b, _ := json.Marshal(&JSPubAckResponse{PubAck: &PubAck{Stream: name}, Error: NewJSMemoryLowError()})
+			outq.send(newJSPubMsg(reply, _EMPTY_, _EMPTY_, nil, b, nil, 0))
+		}
+		return NewJSMemoryLowError()
+	}
+
 	// Check here pre-emptively if we have exceeded our account limits.
 	if exceeded, err := jsa.wouldExceedLimits(st, tierName, r, csubject, hdr, msg); exceeded {
 		if err == nil {
@@ -11000,7 +11240,7 @@ func (mset *stream) handleClusterSyncRequest(sub *subscription, c *client, _ *Ac
 func (js *jetStream) offlineClusterInfo(rg *raftGroup) *ClusterInfo {
 	s := js.srv
 
-	ci := &ClusterInfo{Name: s.ClusterName(), RaftGroup: rg.Name}
+	ci := &ClusterInfo{Name: s.jsClusterName(), RaftGroup: rg.Name}
 	for _, peer := range rg.Peers {
 		if sir, ok := s.nodeToInfo.Load(peer); ok && sir != nil {
 			si := sir.(nodeInfo)
@@ -11021,7 +11261,7 @@ func (js *jetStream) clusterInfo(rg *raftGroup) *ClusterInfo {
 	if rg == nil || rg.node == nil {
 		js.mu.RUnlock()
 		return &ClusterInfo{
-			Name:   s.cachedClusterName(),
+			Name:   s.jsClusterName(),
 			Leader: s.Name(),
 		}
 	}
@@ -11034,7 +11274,7 @@ func (js *jetStream) clusterInfo(rg *raftGroup) *ClusterInfo {
 	js.mu.RUnlock()
 
 	ci := &ClusterInfo{
-		Name:        s.cachedClusterName(),
+		Name:        s.jsClusterName(),
 		Leader:      s.serverNameForNode(n.GroupLeader()),
 		LeaderSince: n.LeaderSince(),
 		SystemAcc:   n.IsSystemAccount(),
@@ -11187,6 +11427,8 @@ func (mset *stream) processClusterStreamInfoRequest(reply string) {
 		Mirror:    mset.mirrorInfo(),
 		TimeStamp: time.Now().UTC(),
 	}
+	si.DedupEntries = mset.numMsgIds()
+	si.MaxBufferedMsgs, si.MaxBufferedSize = mset.bufferedLimits()
 
 	// Check for out of band catchups.
 	if mset.hasCatchupPeers() {
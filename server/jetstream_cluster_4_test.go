@@ -8746,3 +8746,563 @@ func TestJetStreamClusterApplyEntriesRejectEmptyNormal(t *testing.T) {
 		t.Fatalf("expected errBadEntryOp from applyConsumerEntries, got %v", err)
 	}
 }
+
+func TestJetStreamClusterAccountMaxHAAssets(t *testing.T) {
+	tmpl := strings.Replace(jsClusterAccountsTempl,
+		`ONE { users = [ { user: "one", pass: "p" } ]; jetstream: enabled }`,
+		`ONE { users = [ { user: "one", pass: "p" } ]; jetstream: {max_ha_assets: 2} }`, 1)
+	c := createJetStreamClusterWithTemplate(t, tmpl, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer(), nats.UserInfo("one", "p"))
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST-1", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST-1", &nats.ConsumerConfig{Durable: "C1", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+
+	// The account limit has been reached for both streams and consumers.
+	_, err = js.AddStream(&nats.StreamConfig{Name: "TEST-2", Subjects: []string{"bar"}, Replicas: 3})
+	require_Error(t, err, NewJSMaximumHAAssetsLimitError("account", 2))
+	_, err = js.AddConsumer("TEST-1", &nats.ConsumerConfig{Durable: "C2", AckPolicy: nats.AckExplicitPolicy})
+	require_Error(t, err, NewJSMaximumHAAssetsLimitError("account", 2))
+
+	// Non-replicated assets are not limited.
+	_, err = js.AddStream(&nats.StreamConfig{Name: "TEST-3", Subjects: []string{"baz"}, Replicas: 1})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST-1", &nats.ConsumerConfig{Durable: "C3", AckPolicy: nats.AckExplicitPolicy, Replicas: 1})
+	require_NoError(t, err)
+
+	// Scaling them up is limited as well.
+	_, err = js.UpdateStream(&nats.StreamConfig{Name: "TEST-3", Subjects: []string{"baz"}, Replicas: 3})
+	require_Error(t, err, NewJSMaximumHAAssetsLimitError("account", 2))
+	_, err = js.UpdateConsumer("TEST-1", &nats.ConsumerConfig{Durable: "C3", AckPolicy: nats.AckExplicitPolicy, Replicas: 3})
+	require_Error(t, err, NewJSMaximumHAAssetsLimitError("account", 2))
+
+	info, err := js.AccountInfo()
+	require_NoError(t, err)
+	var resp JSApiAccountInfoResponse
+	msg, err := nc.Request(JSApiAccountInfo, nil, time.Second)
+	require_NoError(t, err)
+	require_NoError(t, json.Unmarshal(msg.Data, &resp))
+	require_Equal(t, resp.HAAssets, 2)
+	require_Equal(t, resp.Limits.MaxHAAssets, 2)
+	require_Equal(t, info.Streams, 2)
+
+	// Other accounts are not affected.
+	nc2, js2 := jsClientConnect(t, c.randomServer(), nats.UserInfo("two", "p"))
+	defer nc2.Close()
+	for _, name := range []string{"A", "B", "C"} {
+		_, err = js2.AddStream(&nats.StreamConfig{Name: name, Replicas: 3})
+		require_NoError(t, err)
+	}
+}
+
+func TestJetStreamClusterConsumerAckPolicyUpdate(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+
+	updateConsumer := func(name string, ackPolicy AckPolicy, replicas int) *JSApiConsumerCreateResponse {
+		t.Helper()
+		ccReq := CreateConsumerRequest{
+			Stream: "TEST",
+			Config: ConsumerConfig{Durable: name, AckPolicy: ackPolicy, Replicas: replicas},
+		}
+		req, err := json.Marshal(ccReq)
+		require_NoError(t, err)
+		msg, err := nc.Request(fmt.Sprintf(JSApiDurableCreateT, "TEST", name), req, 2*time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerCreateResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		return &resp
+	}
+
+	for _, replicas := range []int{1, 3} {
+		t.Run(fmt.Sprintf("R%d", replicas), func(t *testing.T) {
+			name := fmt.Sprintf("C%d", replicas)
+			resp := updateConsumer(name, AckAll, replicas)
+			require_True(t, resp.Error == nil)
+			c.waitOnConsumerLeader(globalAccountName, "TEST", name)
+
+			// Tightening is allowed but warns.
+			resp = updateConsumer(name, AckExplicit, replicas)
+			require_True(t, resp.Error == nil)
+			require_Equal(t, resp.Config.AckPolicy, AckExplicit)
+			require_Contains(t, ackPolicyWarning(resp), "ack policy changed from all to explicit")
+
+			// Loosening is rejected.
+			resp = updateConsumer(name, AckNone, replicas)
+			require_NotNil(t, resp.Error)
+			require_Contains(t, resp.Error.Description, "ack policy can not be loosened")
+		})
+	}
+}
+
+func TestJetStreamClusterConsumerInfoDefaultedFields(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "C", AckPolicy: nats.AckExplicitPolicy, MaxDeliver: 5})
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+
+	checkDefaulted := func() {
+		t.Helper()
+		msg, err := nc.Request(fmt.Sprintf(JSApiConsumerInfoT, "TEST", "C"), []byte(`{"defaulted_fields":true}`), 2*time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerInfoResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		require_True(t, resp.Error == nil)
+		require_True(t, slices.Contains(resp.DefaultedFields, "ack_wait"))
+		require_True(t, slices.Contains(resp.DefaultedFields, "max_ack_pending"))
+		require_False(t, slices.Contains(resp.DefaultedFields, "max_deliver"))
+	}
+	checkDefaulted()
+
+	// All replicas know the defaulted fields, so a new leader reports them too.
+	_, err = nc.Request(fmt.Sprintf(JSApiConsumerLeaderStepDownT, "TEST", "C"), nil, 2*time.Second)
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+	checkDefaulted()
+}
+
+func TestJetStreamClusterConsumerCreateWarnings(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+
+	for _, replicas := range []int{1, 3} {
+		name := fmt.Sprintf("C%d", replicas)
+		req, err := json.Marshal(&CreateConsumerRequest{
+			Stream: "TEST",
+			Config: ConsumerConfig{Durable: name, AckPolicy: AckExplicit, Replicas: replicas},
+		})
+		require_NoError(t, err)
+		msg, err := nc.Request(fmt.Sprintf(JSApiDurableCreateT, "TEST", name), req, 2*time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerCreateResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		require_True(t, resp.Error == nil)
+		require_True(t, slices.Contains(resp.Warnings, fmt.Sprintf("max_ack_pending set to %d by the server", JsDefaultMaxAckPending)))
+		require_True(t, slices.Contains(resp.Warnings, fmt.Sprintf("ack_wait set to %v by the server", JsAckWaitDefault)))
+	}
+}
+
+func TestJetStreamClusterLogConsumerLeaderChanges(t *testing.T) {
+	tmpl := "log_consumer_leader_changes: true\n" + jsClusterTempl
+	c := createJetStreamClusterWithTemplate(t, tmpl, "R3S", 3)
+	defer c.shutdown()
+
+	loggers := make(map[*Server]*captureNoticeLogger)
+	for _, s := range c.servers {
+		l := &captureNoticeLogger{}
+		s.SetLogger(l, false, false)
+		loggers[s] = l
+	}
+	hasNotice := func(s *Server, role string) bool {
+		l := loggers[s]
+		l.Lock()
+		defer l.Unlock()
+		for _, n := range l.notices {
+			if strings.Contains(n, "JetStream consumer leader change for '$G > TEST > C'") && strings.Contains(n, role) {
+				return true
+			}
+		}
+		return false
+	}
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "C", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+	ol := c.consumerLeader(globalAccountName, "TEST", "C")
+	checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+		if !hasNotice(ol, "role follower -> leader") {
+			return errors.New("leader change not logged")
+		}
+		return nil
+	})
+
+	_, err = nc.Request(fmt.Sprintf(JSApiConsumerLeaderStepDownT, "TEST", "C"), nil, 2*time.Second)
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+	nl := c.consumerLeader(globalAccountName, "TEST", "C")
+	require_NotEqual(t, ol, nl)
+	checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+		if !hasNotice(ol, "role leader -> follower") || !hasNotice(nl, "role follower -> leader") {
+			return errors.New("leader change not logged")
+		}
+		return nil
+	})
+}
+
+func TestJetStreamClusterConsumerLeaderChangeGrace(t *testing.T) {
+	tmpl := "consumer_leader_change_grace: 3s\n" + jsClusterTempl
+	c := createJetStreamClusterWithTemplate(t, tmpl, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{
+		Durable:           "C",
+		AckPolicy:         nats.AckExplicitPolicy,
+		InactiveThreshold: time.Second,
+		Replicas:          3,
+	})
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+
+	_, err = nc.Request(fmt.Sprintf(JSApiConsumerLeaderStepDownT, "TEST", "C"), nil, 2*time.Second)
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+
+	// Past the inactive threshold, but still within the grace period.
+	time.Sleep(2500 * time.Millisecond)
+	_, err = js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+
+	// Once the grace period has passed, the consumer is deleted.
+	checkFor(t, 5*time.Second, 250*time.Millisecond, func() error {
+		if _, err := js.ConsumerInfo("TEST", "C"); err == nil {
+			return errors.New("consumer still exists")
+		}
+		return nil
+	})
+}
+
+func TestJetStreamClusterJetStreamClusterName(t *testing.T) {
+	tmpl := strings.Replace(jsClusterTempl, "store_dir:", "cluster_name: JSC, store_dir:", 1)
+	c := createJetStreamClusterWithTemplate(t, tmpl, "R3S", 3)
+	defer c.shutdown()
+
+	// The routing cluster name is unchanged.
+	for _, s := range c.servers {
+		require_Equal(t, s.ClusterName(), "R3S")
+		require_Equal(t, s.JetStreamConfig().ClusterName, "JSC")
+	}
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	// Default placement uses the JetStream cluster name.
+	si, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+	require_Equal(t, si.Cluster.Name, "JSC")
+
+	ci, err := js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "C", Replicas: 3})
+	require_NoError(t, err)
+	require_Equal(t, ci.Cluster.Name, "JSC")
+
+	// Explicit placement must reference the JetStream cluster name.
+	_, err = js.AddStream(&nats.StreamConfig{Name: "P", Replicas: 3, Placement: &nats.Placement{Cluster: "JSC"}})
+	require_NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{Name: "BAD", Replicas: 3, Placement: &nats.Placement{Cluster: "R3S"}})
+	require_Error(t, err)
+
+	// Must not contain spaces.
+	conf := createConfFile(t, []byte(`
+		jetstream: {cluster_name: "J S C"}
+	`))
+	_, err = ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), ErrClusterNameHasSpaces.Error())
+}
+
+func TestJetStreamClusterConsumerDeliveryQuotaResume(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+	for range 4 {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	sub := natsSubSync(t, nc, "deliver")
+	defer sub.Unsubscribe()
+
+	req := CreateConsumerRequest{
+		Stream: "TEST",
+		Config: ConsumerConfig{Durable: "C", DeliverSubject: "deliver", AckPolicy: AckNone, Replicas: 3, DeliveryQuotaMsgs: 2},
+	}
+	data, err := json.Marshal(req)
+	require_NoError(t, err)
+	_, err = nc.Request(fmt.Sprintf(JSApiDurableCreateT, "TEST", "C"), data, 2*time.Second)
+	require_NoError(t, err)
+
+	expectMsgs := func(n int) {
+		t.Helper()
+		for range n {
+			natsNexMsg(t, sub, 2*time.Second)
+		}
+		_, err := sub.NextMsg(250 * time.Millisecond)
+		require_Error(t, err, nats.ErrTimeout)
+	}
+	expectMsgs(2)
+
+	_, err = nc.Request(fmt.Sprintf(JSApiConsumerPauseT, "TEST", "C"), nil, 2*time.Second)
+	require_NoError(t, err)
+	expectMsgs(2)
+}
+
+func TestJetStreamClusterConsumerQuorumDeliveryLatency(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+	for _, cfg := range []*nats.ConsumerConfig{
+		{Durable: "R3", AckPolicy: nats.AckExplicitPolicy, Replicas: 3},
+		{Durable: "R1", AckPolicy: nats.AckExplicitPolicy, Replicas: 1},
+	} {
+		_, err = js.AddConsumer("TEST", cfg)
+		require_NoError(t, err)
+		c.waitOnConsumerLeader(globalAccountName, "TEST", cfg.Durable)
+	}
+
+	for i := 0; i < 10; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	consumerInfo := func(name string) *ConsumerInfo {
+		t.Helper()
+		resp, err := nc.Request(fmt.Sprintf(JSApiConsumerInfoT, "TEST", name), nil, 2*time.Second)
+		require_NoError(t, err)
+		var ccResp JSApiConsumerInfoResponse
+		require_NoError(t, json.Unmarshal(resp.Data, &ccResp))
+		require_True(t, ccResp.Error == nil)
+		return ccResp.ConsumerInfo
+	}
+
+	for _, name := range []string{"R3", "R1"} {
+		sub, err := js.PullSubscribe("foo", name, nats.Bind("TEST", name))
+		require_NoError(t, err)
+		msgs, err := sub.Fetch(10, nats.MaxWait(2*time.Second))
+		require_NoError(t, err)
+		require_Len(t, len(msgs), 10)
+	}
+
+	ql := consumerInfo("R3").QuorumDeliveryLatency
+	require_NotNil(t, ql)
+	require_Equal(t, ql.Samples, 10)
+	require_True(t, ql.Average > 0)
+	require_True(t, ql.Max >= ql.Average)
+
+	require_True(t, consumerInfo("R1").QuorumDeliveryLatency == nil)
+}
+
+func TestJetStreamClusterConsumerInfoOmitCluster(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Replicas: 3})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "C", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+
+	consumerInfo := func(req string) *ConsumerInfo {
+		t.Helper()
+		resp, err := nc.Request(fmt.Sprintf(JSApiConsumerInfoT, "TEST", "C"), []byte(req), 2*time.Second)
+		require_NoError(t, err)
+		var ciResp JSApiConsumerInfoResponse
+		require_NoError(t, json.Unmarshal(resp.Data, &ciResp))
+		require_True(t, ciResp.Error == nil)
+		return ciResp.ConsumerInfo
+	}
+
+	// Included by default.
+	require_NotNil(t, consumerInfo(_EMPTY_).Cluster)
+	require_True(t, consumerInfo(`{"omit_cluster":true}`).Cluster == nil)
+
+	// Change the server default, which requests can still override.
+	for _, s := range c.servers {
+		s.optsMu.Lock()
+		s.opts.JetStreamConsumerInfoOmitCluster = true
+		s.optsMu.Unlock()
+	}
+	require_True(t, consumerInfo(_EMPTY_).Cluster == nil)
+	require_NotNil(t, consumerInfo(`{"omit_cluster":false}`).Cluster)
+}
+
+func TestJetStreamClusterStreamPlacementServers(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R5S", 5)
+	defer c.shutdown()
+
+	nc, _ := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	createStream := func(cfg *StreamConfig) *JSApiStreamCreateResponse {
+		t.Helper()
+		req, err := json.Marshal(cfg)
+		require_NoError(t, err)
+		resp, err := nc.Request(fmt.Sprintf(JSApiStreamCreateT, cfg.Name), req, 5*time.Second)
+		require_NoError(t, err)
+		var scResp JSApiStreamCreateResponse
+		require_NoError(t, json.Unmarshal(resp.Data, &scResp))
+		return &scResp
+	}
+
+	pinned := []string{"S-2", "S-4"}
+	resp := createStream(&StreamConfig{
+		Name:      "TEST",
+		Subjects:  []string{"foo"},
+		Storage:   FileStorage,
+		Replicas:  2,
+		Placement: &Placement{Servers: pinned},
+	})
+	require_True(t, resp.Error == nil)
+	c.waitOnStreamLeader(globalAccountName, "TEST")
+
+	si := resp.StreamInfo
+	require_NotNil(t, si.Cluster)
+	require_Equal(t, si.Cluster.Name, "R5S")
+	require_True(t, slices.Contains(pinned, si.Cluster.Leader))
+	require_Len(t, len(si.Cluster.Replicas), 1)
+	require_True(t, slices.Contains(pinned, si.Cluster.Replicas[0].Name))
+	require_True(t, si.Cluster.Replicas[0].Name != si.Cluster.Leader)
+
+	// Servers take precedence over tags.
+	resp = createStream(&StreamConfig{
+		Name:      "TAGS",
+		Subjects:  []string{"bar"},
+		Storage:   FileStorage,
+		Placement: &Placement{Servers: []string{"S-1"}, Tags: []string{"missing"}},
+	})
+	require_True(t, resp.Error == nil)
+	require_Equal(t, resp.StreamInfo.Cluster.Leader, "S-1")
+
+	// Unknown servers can not host the stream.
+	resp = createStream(&StreamConfig{
+		Name:      "UNKNOWN",
+		Subjects:  []string{"baz"},
+		Storage:   FileStorage,
+		Replicas:  2,
+		Placement: &Placement{Servers: []string{"S-1", "S-9"}},
+	})
+	require_NotNil(t, resp.Error)
+	require_Contains(t, resp.Error.Description, "placement servers not available ['S-9']")
+
+	// Unknown servers are reported even if the known ones could host the stream.
+	resp = createStream(&StreamConfig{
+		Name:      "UNKNOWN",
+		Subjects:  []string{"baz"},
+		Storage:   FileStorage,
+		Placement: &Placement{Servers: []string{"S-1", "S-9"}},
+	})
+	require_NotNil(t, resp.Error)
+	require_Contains(t, resp.Error.Description, "placement servers not available ['S-9']")
+
+	// Servers outside of the placement cluster are rejected.
+	resp = createStream(&StreamConfig{
+		Name:      "CONFLICT",
+		Subjects:  []string{"baz"},
+		Storage:   FileStorage,
+		Placement: &Placement{Cluster: "OTHER", Servers: []string{"S-1"}},
+	})
+	require_NotNil(t, resp.Error)
+	require_Equal(t, resp.Error.ErrCode, uint16(JSStreamInvalidConfigF))
+	require_Contains(t, resp.Error.Description, `placement server "S-1" not in placement cluster "OTHER"`)
+
+	// Invalid server lists are rejected.
+	for _, servers := range [][]string{{"S-1", "S-1"}, {"S-1", _EMPTY_}, {"S-1"}} {
+		resp = createStream(&StreamConfig{
+			Name:      "INVALID",
+			Subjects:  []string{"baz"},
+			Storage:   FileStorage,
+			Replicas:  2,
+			Placement: &Placement{Servers: servers},
+		})
+		require_NotNil(t, resp.Error)
+		require_Equal(t, resp.Error.ErrCode, uint16(JSStreamInvalidConfigF))
+	}
+}
+
+func TestJetStreamClusterConsumerUpdateMaxAckPendingBelowPending(t *testing.T) {
+	c := createJetStreamClusterExplicit(t, "R3S", 3)
+	defer c.shutdown()
+
+	nc, js := jsClientConnect(t, c.randomServer())
+	defer nc.Close()
+
+	// Keep the stream and consumer off the meta leader, so it does not know the pending count.
+	ml := c.leader()
+	sl := c.randomNonLeader()
+	req, err := json.Marshal(&StreamConfig{
+		Name:      "TEST",
+		Subjects:  []string{"foo"},
+		Storage:   FileStorage,
+		Placement: &Placement{Servers: []string{sl.Name()}},
+	})
+	require_NoError(t, err)
+	resp, err := nc.Request(fmt.Sprintf(JSApiStreamCreateT, "TEST"), req, 5*time.Second)
+	require_NoError(t, err)
+	var scResp JSApiStreamCreateResponse
+	require_NoError(t, json.Unmarshal(resp.Data, &scResp))
+	require_True(t, scResp.Error == nil)
+
+	for i := 0; i < 10; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	cfg := &nats.ConsumerConfig{Durable: "C", AckPolicy: nats.AckExplicitPolicy, MaxAckPending: 100}
+	_, err = js.AddConsumer("TEST", cfg)
+	require_NoError(t, err)
+	c.waitOnConsumerLeader(globalAccountName, "TEST", "C")
+	require_True(t, c.consumerLeader(globalAccountName, "TEST", "C") == sl)
+	require_True(t, c.leader() == ml)
+
+	sub, err := js.PullSubscribe("foo", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	msgs, err := sub.Fetch(10, nats.MaxWait(time.Second))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 10)
+
+	// Lowering below the 10 pending acks is rejected.
+	cfg.MaxAckPending = 5
+	_, err = js.UpdateConsumer("TEST", cfg)
+	require_Error(t, err, NewJSConsumerMaxAckPendingBelowPendingError(10))
+
+	// Lowering, but not below what is pending, is fine.
+	cfg.MaxAckPending = 10
+	ci, err := js.UpdateConsumer("TEST", cfg)
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.MaxAckPending, 10)
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"net/url"
 	os "os"
@@ -10954,7 +10955,7 @@ func TestJetStreamConsumerNoWaitNoMessagesOnEos(t *testing.T) {
 	req := &JSApiConsumerGetNextRequest{NoWait: true}
 	jreq, err := json.Marshal(req)
 	require_NoError(t, err)
-	o.processNextMsgRequest("reply", jreq)
+	o.processNextMsgRequest("reply", jreq, 0, 0)
 
 	msg, err := sub.NextMsg(time.Second)
 	require_NoError(t, err)
@@ -10991,7 +10992,7 @@ func TestJetStreamConsumerNoWaitNoMessagesOnEosWithDeliveredMsgs(t *testing.T) {
 	req := &JSApiConsumerGetNextRequest{NoWait: true, Batch: 2}
 	jreq, err := json.Marshal(req)
 	require_NoError(t, err)
-	o.processNextMsgRequest("reply", jreq)
+	o.processNextMsgRequest("reply", jreq, 0, 0)
 
 	msg, err := sub.NextMsg(time.Second)
 	require_NoError(t, err)
@@ -13015,3 +13016,2057 @@ func TestJetStreamConsumerResetResponseAcrossServiceImport(t *testing.T) {
 		})
 	})
 }
+
+func TestJetStreamConsumerAckTermReasonSanitized(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "C", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+
+	sub := natsSubSync(t, nc, JSAdvisoryConsumerMsgTerminatedPre+".>")
+	defer sub.Unsubscribe()
+
+	psub, err := js.PullSubscribe("foo", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+
+	for _, test := range []struct {
+		payload string
+		reason  string
+	}{
+		{"", ""},
+		{" with\x00 a\x1b reason\n", "with a reason"},
+		{" " + strings.Repeat("a", 2*maxAckTermReasonLen), strings.Repeat("a", maxAckTermReasonLen)},
+	} {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+		msgs, err := psub.Fetch(1)
+		require_NoError(t, err)
+		require_Len(t, len(msgs), 1)
+		require_NoError(t, msgs[0].Respond([]byte(string(AckTerm)+test.payload)))
+
+		am, err := sub.NextMsg(time.Second)
+		require_NoError(t, err)
+		var adv JSConsumerDeliveryTerminatedAdvisory
+		require_NoError(t, json.Unmarshal(am.Data, &adv))
+		require_Equal(t, adv.Reason, test.reason)
+	}
+}
+
+func TestJetStreamConsumerDeliverTrace(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	sub := natsSubSync(t, nc, JSConsumerDeliveryTracePre+".TEST.C")
+	defer sub.Unsubscribe()
+	natsFlush(t, nc)
+
+	mset, err := s.globalAccount().lookupStream("TEST")
+	require_NoError(t, err)
+	_, err = mset.addConsumer(&ConsumerConfig{
+		Durable:       "C",
+		AckPolicy:     AckExplicit,
+		MaxAckPending: 1,
+		DeliverTrace:  true,
+	})
+	require_NoError(t, err)
+
+	getEvent := func() *JSConsumerDeliveryTraceEvent {
+		t.Helper()
+		msg, err := sub.NextMsg(time.Second)
+		require_NoError(t, err)
+		var e JSConsumerDeliveryTraceEvent
+		require_NoError(t, json.Unmarshal(msg.Data, &e))
+		require_Equal(t, e.Type, JSConsumerDeliveryTraceEventType)
+		require_Equal(t, e.Stream, "TEST")
+		require_Equal(t, e.Consumer, "C")
+		return &e
+	}
+
+	// No pull requests yet.
+	e := getEvent()
+	require_False(t, e.Delivered)
+	require_Equal(t, e.Reason, deliverTraceNoPullRequests)
+
+	for range 2 {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	psub, err := js.PullSubscribe("foo", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	psub.Fetch(2, nats.MaxWait(250*time.Millisecond))
+
+	// Look for the delivery and the max ack pending events.
+	var delivered, maxAckPending bool
+	for !delivered || !maxAckPending {
+		e = getEvent()
+		switch e.Reason {
+		case deliverTraceDelivered:
+			require_True(t, e.Delivered)
+			require_Equal(t, e.Subject, "foo")
+			require_Equal(t, e.StreamSeq, 1)
+			require_Equal(t, e.Deliveries, 1)
+			delivered = true
+		case deliverTraceMaxAckPending:
+			require_False(t, e.Delivered)
+			maxAckPending = true
+		}
+	}
+}
+
+func TestJetStreamConsumerRequireExplicitDeliverPolicy(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, require_explicit_deliver_policy: true}
+	`, t.TempDir())))
+
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	createConsumer := func(req string) *ApiError {
+		t.Helper()
+		msg, err := nc.Request(fmt.Sprintf(JSApiConsumerCreateExT, "TEST", "C", "foo"), []byte(req), time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerCreateResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		return resp.Error
+	}
+
+	// Omitting the deliver policy is rejected.
+	apiErr := createConsumer(`{"stream_name":"TEST","config":{"name":"C","filter_subject":"foo","ack_policy":"explicit"}}`)
+	require_NotNil(t, apiErr)
+	require_Equal(t, apiErr.ErrCode, uint16(JSConsumerDeliverPolicyRequiredErr))
+
+	// Explicitly asking for everything is still allowed.
+	apiErr = createConsumer(`{"stream_name":"TEST","config":{"name":"C","filter_subject":"foo","ack_policy":"explicit","deliver_policy":"all"}}`)
+	require_True(t, apiErr == nil)
+
+	// Clients that always send the deliver policy are not affected.
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "D", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+}
+
+// ackPolicyWarning returns the warning about an ack policy change from a create response, if any.
+func ackPolicyWarning(resp *JSApiConsumerCreateResponse) string {
+	for _, w := range resp.Warnings {
+		if strings.HasPrefix(w, "ack policy changed") {
+			return w
+		}
+	}
+	return _EMPTY_
+}
+
+func TestJetStreamConsumerAckPolicyUpdate(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	updateConsumer := func(name string, ackPolicy AckPolicy) *JSApiConsumerCreateResponse {
+		t.Helper()
+		ccReq := CreateConsumerRequest{
+			Stream: "TEST",
+			Config: ConsumerConfig{Durable: name, AckPolicy: ackPolicy},
+		}
+		req, err := json.Marshal(ccReq)
+		require_NoError(t, err)
+		msg, err := nc.Request(fmt.Sprintf(JSApiDurableCreateT, "TEST", name), req, time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerCreateResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		return &resp
+	}
+
+	for _, test := range []struct {
+		from, to AckPolicy
+		allowed  bool
+	}{
+		{AckNone, AckAll, true},
+		{AckNone, AckExplicit, true},
+		{AckAll, AckExplicit, true},
+		{AckAll, AckNone, false},
+		{AckExplicit, AckAll, false},
+		{AckExplicit, AckNone, false},
+	} {
+		t.Run(fmt.Sprintf("%s-%s", test.from, test.to), func(t *testing.T) {
+			name := fmt.Sprintf("%s_%s", test.from, test.to)
+			resp := updateConsumer(name, test.from)
+			require_True(t, resp.Error == nil)
+			require_Equal(t, ackPolicyWarning(resp), _EMPTY_)
+
+			resp = updateConsumer(name, test.to)
+			if !test.allowed {
+				require_NotNil(t, resp.Error)
+				require_Contains(t, resp.Error.Description, "ack policy can not be loosened")
+				ci, err := js.ConsumerInfo("TEST", name)
+				require_NoError(t, err)
+				require_Equal(t, AckPolicy(ci.Config.AckPolicy), test.from)
+				return
+			}
+			require_True(t, resp.Error == nil)
+			require_Equal(t, resp.Config.AckPolicy, test.to)
+			require_Contains(t, ackPolicyWarning(resp), fmt.Sprintf("ack policy changed from %s to %s", test.from, test.to))
+
+			// Updating again without an ack policy change should not warn.
+			resp = updateConsumer(name, test.to)
+			require_True(t, resp.Error == nil)
+			require_Equal(t, ackPolicyWarning(resp), _EMPTY_)
+		})
+	}
+
+	// Messages delivered after tightening from AckNone need to be acked.
+	sub, err := js.PullSubscribe("foo", "none_explicit", nats.Bind("TEST", "none_explicit"))
+	require_NoError(t, err)
+	_, err = js.Publish("foo", nil)
+	require_NoError(t, err)
+	msgs, err := sub.Fetch(1)
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 1)
+	ci, err := js.ConsumerInfo("TEST", "none_explicit")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumAckPending, 1)
+	require_NoError(t, msgs[0].AckSync())
+	ci, err = js.ConsumerInfo("TEST", "none_explicit")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumAckPending, 0)
+}
+
+func TestJetStreamConsumerWaitingRequestIdleTimeout(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	mset, err := s.globalAccount().lookupStream("TEST")
+	require_NoError(t, err)
+
+	// Negative values and push consumers are rejected.
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "N", AckPolicy: AckExplicit, WaitingRequestIdleTimeout: -time.Second})
+	require_Error(t, err, NewJSConsumerWaitingRequestIdleTimeoutNegativeError())
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "P", AckPolicy: AckExplicit, DeliverSubject: "push", WaitingRequestIdleTimeout: time.Second})
+	require_Error(t, err, NewJSConsumerPushWaitingRequestIdleTimeoutError())
+
+	o, err := mset.addConsumer(&ConsumerConfig{
+		Durable:                   "C",
+		AckPolicy:                 AckExplicit,
+		MaxWaiting:                1,
+		WaitingRequestIdleTimeout: 250 * time.Millisecond,
+	})
+	require_NoError(t, err)
+
+	sub, err := nc.SubscribeSync("reply")
+	require_NoError(t, err)
+	defer sub.Drain()
+
+	// A request without expiration whose client is still around but never receives anything.
+	req, err := json.Marshal(JSApiConsumerGetNextRequest{Batch: 2})
+	require_NoError(t, err)
+	require_NoError(t, nc.PublishRequest(fmt.Sprintf(JSApiRequestNextT, "TEST", "C"), "reply", req))
+	checkFor(t, time.Second, 10*time.Millisecond, func() error {
+		if n := o.info().NumWaiting; n != 1 {
+			return fmt.Errorf("expected 1 waiting request, got %d", n)
+		}
+		return nil
+	})
+
+	// Deliveries count as activity, so the request should not be reaped while messages flow.
+	for range 2 {
+		time.Sleep(150 * time.Millisecond)
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+		msg, err := sub.NextMsg(time.Second)
+		require_NoError(t, err)
+		require_Equal(t, msg.Header.Get("Status"), _EMPTY_)
+	}
+
+	// A new request that sees no activity should be reaped after the idle timeout.
+	require_NoError(t, nc.PublishRequest(fmt.Sprintf(JSApiRequestNextT, "TEST", "C"), "reply", req))
+	start := time.Now()
+	msg, err := sub.NextMsg(time.Second)
+	require_NoError(t, err)
+	require_Equal(t, msg.Header.Get("Status"), "408")
+	require_True(t, time.Since(start) >= 200*time.Millisecond)
+	require_Equal(t, o.info().NumWaiting, 0)
+}
+
+func TestJetStreamConsumerDefaultMaxDeliverFromStream(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	// Only -1 or positive values are allowed.
+	_, err := jsStreamCreate(t, nc, &StreamConfig{
+		Name:           "BAD",
+		Storage:        FileStorage,
+		ConsumerLimits: StreamConsumerLimits{DefaultMaxDeliver: -2},
+	})
+	require_Error(t, err, NewJSStreamInvalidConfigError(errors.New("consumer limits default max deliver must be -1 or positive")))
+
+	cfg, err := jsStreamCreate(t, nc, &StreamConfig{
+		Name:           "TEST",
+		Subjects:       []string{"foo"},
+		Storage:        FileStorage,
+		ConsumerLimits: StreamConsumerLimits{DefaultMaxDeliver: 5},
+	})
+	require_NoError(t, err)
+	require_Equal(t, cfg.ConsumerLimits.DefaultMaxDeliver, 5)
+
+	// Consumers that don't specify MaxDeliver get the stream's default.
+	ci, err := js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "DEFAULT", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.MaxDeliver, 5)
+
+	// The explicit consumer value always wins.
+	ci, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "EXPLICIT", AckPolicy: nats.AckExplicitPolicy, MaxDeliver: 10})
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.MaxDeliver, 10)
+	ci, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "UNLIMITED", AckPolicy: nats.AckExplicitPolicy, MaxDeliver: -1})
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.MaxDeliver, -1)
+
+	// Streams without a default keep the unlimited default.
+	_, err = js.AddStream(&nats.StreamConfig{Name: "OTHER", Subjects: []string{"bar"}})
+	require_NoError(t, err)
+	ci, err = js.AddConsumer("OTHER", &nats.ConsumerConfig{Durable: "DEFAULT", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.MaxDeliver, -1)
+}
+
+func TestJetStreamConsumerAckWaitPerFilter(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo.*"}})
+	require_NoError(t, err)
+
+	mset, err := s.globalAccount().lookupStream("TEST")
+	require_NoError(t, err)
+
+	filters := []string{"foo.fast", "foo.slow"}
+	for _, test := range []struct {
+		name string
+		cfg  ConsumerConfig
+		err  string
+	}{
+		{"unknown filter", ConsumerConfig{AckWaitPerFilter: map[string]time.Duration{"foo.other": time.Second}}, `"foo.other" is not a filter subject of the consumer`},
+		{"not positive", ConsumerConfig{AckWaitPerFilter: map[string]time.Duration{"foo.fast": 0}}, `ack wait for "foo.fast" needs to be positive`},
+		{"with backoff", ConsumerConfig{AckWaitPerFilter: map[string]time.Duration{"foo.fast": time.Second}, BackOff: []time.Duration{time.Second}}, "can not be combined with backoff"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := test.cfg
+			cfg.Durable, cfg.AckPolicy, cfg.FilterSubjects = "BAD", AckExplicit, filters
+			_, err := mset.addConsumer(&cfg)
+			require_Error(t, err)
+			require_Contains(t, err.Error(), test.err)
+		})
+	}
+
+	o, err := mset.addConsumer(&ConsumerConfig{
+		Durable:          "C",
+		AckPolicy:        AckExplicit,
+		AckWait:          5 * time.Second,
+		FilterSubjects:   filters,
+		AckWaitPerFilter: map[string]time.Duration{"foo.fast": 250 * time.Millisecond},
+	})
+	require_NoError(t, err)
+
+	// Reflected in the consumer info.
+	ci, err := js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.AckWait, 5*time.Second)
+	require_Equal(t, o.info().Config.AckWaitPerFilter["foo.fast"], 250*time.Millisecond)
+
+	for _, subj := range filters {
+		_, err = js.Publish(subj, nil)
+		require_NoError(t, err)
+	}
+
+	sub, err := js.PullSubscribe(_EMPTY_, "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	msgs, err := sub.Fetch(2)
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 2)
+
+	// Only the message on the filter with the short ack wait should be redelivered.
+	msgs, err = sub.Fetch(2, nats.MaxWait(time.Second))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 1)
+	require_Equal(t, msgs[0].Subject, "foo.fast")
+	md, err := msgs[0].Metadata()
+	require_NoError(t, err)
+	require_Equal(t, md.NumDelivered, 2)
+}
+
+func TestJetStreamConsumerInfoDefaultedFields(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{
+		Durable:    "C",
+		AckPolicy:  nats.AckExplicitPolicy,
+		MaxDeliver: 5,
+	})
+	require_NoError(t, err)
+
+	consumerInfo := func(req string) *ConsumerInfo {
+		t.Helper()
+		msg, err := nc.Request(fmt.Sprintf(JSApiConsumerInfoT, "TEST", "C"), []byte(req), time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerInfoResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		require_True(t, resp.Error == nil)
+		return resp.ConsumerInfo
+	}
+
+	// Not included by default.
+	require_Len(t, len(consumerInfo(_EMPTY_).DefaultedFields), 0)
+	require_Len(t, len(consumerInfo(`{}`).DefaultedFields), 0)
+
+	ci := consumerInfo(`{"defaulted_fields":true}`)
+	require_True(t, slices.Contains(ci.DefaultedFields, "ack_wait"))
+	require_True(t, slices.Contains(ci.DefaultedFields, "max_ack_pending"))
+	require_True(t, slices.Contains(ci.DefaultedFields, "max_waiting"))
+	require_False(t, slices.Contains(ci.DefaultedFields, "max_deliver"))
+
+	// Updating with explicit values should no longer report them.
+	_, err = js.UpdateConsumer("TEST", &nats.ConsumerConfig{
+		Durable:       "C",
+		AckPolicy:     nats.AckExplicitPolicy,
+		AckWait:       ci.Config.AckWait,
+		MaxDeliver:    5,
+		MaxAckPending: ci.Config.MaxAckPending,
+		MaxWaiting:    ci.Config.MaxWaiting,
+	})
+	require_NoError(t, err)
+	require_Len(t, len(consumerInfo(`{"defaulted_fields":true}`).DefaultedFields), 0)
+
+	// Any other non-empty request is rejected.
+	for _, req := range []string{`{bad`, `{"foo":"bar"}`} {
+		msg, err := nc.Request(fmt.Sprintf(JSApiConsumerInfoT, "TEST", "C"), []byte(req), time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerInfoResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		require_NotNil(t, resp.Error)
+		require_Equal(t, resp.Error.ErrCode, uint16(JSNotEmptyRequestErr))
+	}
+}
+
+func TestJetStreamConsumerCreateWarnings(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {
+			store_dir: %q
+			limits: {max_ack_pending: 100}
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:           "LIMITS",
+		Subjects:       []string{"bar"},
+		ConsumerLimits: nats.StreamConsumerLimits{MaxAckPending: 50},
+	})
+	require_NoError(t, err)
+
+	create := func(stream, name string) *JSApiConsumerCreateResponse {
+		t.Helper()
+		req, err := json.Marshal(&CreateConsumerRequest{
+			Stream: stream,
+			Config: ConsumerConfig{Durable: name, AckPolicy: AckExplicit, MaxDeliver: 5},
+		})
+		require_NoError(t, err)
+		msg, err := nc.Request(fmt.Sprintf(JSApiDurableCreateT, stream, name), req, time.Second)
+		require_NoError(t, err)
+		var resp JSApiConsumerCreateResponse
+		require_NoError(t, json.Unmarshal(msg.Data, &resp))
+		require_True(t, resp.Error == nil)
+		return &resp
+	}
+
+	resp := create("TEST", "A")
+	require_True(t, slices.Contains(resp.Warnings, "max_ack_pending set to 100 by the server, capped by JetStream limits"))
+	require_True(t, slices.Contains(resp.Warnings, fmt.Sprintf("max_waiting set to %d by the server", JSWaitQueueDefaultMax)))
+	for _, w := range resp.Warnings {
+		require_False(t, strings.HasPrefix(w, "max_deliver"))
+	}
+
+	// Taken from the stream's consumer limits, so not capped.
+	resp = create("LIMITS", "B")
+	require_True(t, slices.Contains(resp.Warnings, "max_ack_pending set to 50 by the server"))
+}
+
+func TestJetStreamConsumerSignalCaughtUp(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	mset, err := s.globalAccount().lookupStream("TEST")
+	require_NoError(t, err)
+
+	// Only valid for push consumers.
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "PULL", AckPolicy: AckExplicit, SignalCaughtUp: true})
+	require_Error(t, err, NewJSConsumerSignalCaughtUpRequiresPushError())
+
+	for range 3 {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	sub := natsSubSync(t, nc, "deliver")
+	defer sub.Unsubscribe()
+
+	cfg := ConsumerConfig{Durable: "C", DeliverSubject: "deliver", AckPolicy: AckNone, SignalCaughtUp: true}
+	o, err := mset.addConsumer(&cfg)
+	require_NoError(t, err)
+
+	expectCaughtUp := func(dseq, sseq string) {
+		t.Helper()
+		msg := natsNexMsg(t, sub, time.Second)
+		require_Equal(t, msg.Header.Get("Status"), "100")
+		require_Equal(t, msg.Header.Get("Description"), "Caught Up")
+		require_Equal(t, msg.Header.Get(JSLastConsumerSeq), dseq)
+		require_Equal(t, msg.Header.Get(JSLastStreamSeq), sseq)
+		require_Len(t, len(msg.Data), 0)
+	}
+
+	for range 3 {
+		msg := natsNexMsg(t, sub, time.Second)
+		require_Equal(t, msg.Header.Get("Status"), _EMPTY_)
+	}
+	expectCaughtUp("3", "3")
+	require_True(t, o.info().CaughtUp)
+
+	// Not sent again on subsequent drains.
+	_, err = js.Publish("foo", nil)
+	require_NoError(t, err)
+	msg := natsNexMsg(t, sub, time.Second)
+	require_Equal(t, msg.Header.Get("Status"), _EMPTY_)
+	_, err = sub.NextMsg(250 * time.Millisecond)
+	require_Error(t, err, nats.ErrTimeout)
+
+	// Enabling it again requests a new one, sent right away since there is nothing pending.
+	cfg.SignalCaughtUp = false
+	require_NoError(t, o.updateConfig(&cfg))
+	cfg.SignalCaughtUp = true
+	require_NoError(t, o.updateConfig(&cfg))
+	expectCaughtUp("4", "4")
+}
+
+func TestJetStreamConsumerRedeliveryQuietWindows(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	mset, err := s.globalAccount().lookupStream("TEST")
+	require_NoError(t, err)
+
+	now := time.Now()
+	for _, windows := range [][]RedeliveryQuietWindow{
+		{{Start: now, End: now}},
+		{{Start: now, End: now.Add(-time.Second)}},
+		{{Start: now, End: now.Add(time.Hour)}, {Start: now.Add(30 * time.Minute), End: now.Add(2 * time.Hour)}},
+	} {
+		_, err = mset.addConsumer(&ConsumerConfig{Durable: "BAD", AckPolicy: AckExplicit, RedeliveryQuietWindows: windows})
+		require_Error(t, err)
+		require_Contains(t, err.Error(), "consumer redelivery quiet window is invalid")
+	}
+
+	sub := natsSubSync(t, nc, "deliver")
+	defer sub.Unsubscribe()
+
+	window := RedeliveryQuietWindow{Start: now, End: now.Add(time.Second)}
+	o, err := mset.addConsumer(&ConsumerConfig{
+		Durable:                "C",
+		DeliverSubject:         "deliver",
+		AckPolicy:              AckExplicit,
+		AckWait:                100 * time.Millisecond,
+		RedeliveryQuietWindows: []RedeliveryQuietWindow{window},
+	})
+	require_NoError(t, err)
+
+	_, err = js.Publish("foo", nil)
+	require_NoError(t, err)
+	msg := natsNexMsg(t, sub, time.Second)
+	meta, err := msg.Metadata()
+	require_NoError(t, err)
+	require_Equal(t, meta.NumDelivered, 1)
+
+	info := o.info()
+	require_True(t, info.RedeliveryQuiet)
+	require_True(t, info.RedeliveryQuietRemaining > 0)
+
+	// No redelivery while the window is active, even though the ack wait has passed.
+	_, err = sub.NextMsg(time.Until(window.End) - 100*time.Millisecond)
+	require_Error(t, err, nats.ErrTimeout)
+
+	// Redelivered once the window closes.
+	msg = natsNexMsg(t, sub, time.Second)
+	meta, err = msg.Metadata()
+	require_NoError(t, err)
+	require_Equal(t, meta.NumDelivered, 2)
+	require_False(t, o.info().RedeliveryQuiet)
+}
+
+func TestJetStreamConsumerDeliveryQuota(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	mset, err := s.globalAccount().lookupStream("TEST")
+	require_NoError(t, err)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "BAD", AckPolicy: AckNone, DeliveryQuotaMsgs: -1})
+	require_Error(t, err, NewJSConsumerDeliveryQuotaNegativeError())
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "BAD", AckPolicy: AckNone, DeliveryQuotaBytes: -1})
+	require_Error(t, err, NewJSConsumerDeliveryQuotaNegativeError())
+
+	for range 5 {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	adv := natsSubSync(t, nc, JSAdvisoryConsumerPausePre+".TEST.C")
+	sub := natsSubSync(t, nc, "deliver")
+	defer sub.Unsubscribe()
+
+	o, err := mset.addConsumer(&ConsumerConfig{Durable: "C", DeliverSubject: "deliver", AckPolicy: AckNone, DeliveryQuotaMsgs: 2})
+	require_NoError(t, err)
+
+	expectMsgs := func(n int) {
+		t.Helper()
+		for range n {
+			natsNexMsg(t, sub, time.Second)
+		}
+		_, err := sub.NextMsg(250 * time.Millisecond)
+		require_Error(t, err, nats.ErrTimeout)
+	}
+	expectAdvisory := func(paused bool) {
+		t.Helper()
+		var e JSConsumerPauseAdvisory
+		require_NoError(t, json.Unmarshal(natsNexMsg(t, adv, time.Second).Data, &e))
+		require_Equal(t, e.Paused, paused)
+	}
+
+	// Paused once the quota is reached.
+	expectMsgs(2)
+	expectAdvisory(true)
+	info := o.info()
+	require_True(t, info.Paused)
+	require_NotNil(t, info.DeliveryQuotaRemaining)
+	require_Equal(t, info.DeliveryQuotaRemaining.Msgs, 0)
+
+	// Resuming clears the quota.
+	msg, err := nc.Request(fmt.Sprintf(JSApiConsumerPauseT, "TEST", "C"), nil, time.Second)
+	require_NoError(t, err)
+	var resp JSApiConsumerPauseResponse
+	require_NoError(t, json.Unmarshal(msg.Data, &resp))
+	require_True(t, resp.Error == nil)
+	expectAdvisory(false)
+	expectMsgs(2)
+	expectAdvisory(true)
+}
+
+func TestJetStreamConsumerConfiguredNameAndDescriptionLimits(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {
+			store_dir: %q
+			limits: {max_consumer_name_len: 8, max_consumer_description_len: 16}
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "CONSUMER1", AckPolicy: nats.AckExplicitPolicy})
+	require_Error(t, err, NewJSConsumerNameTooLongError(8))
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Name: "CONSUMER1", AckPolicy: nats.AckExplicitPolicy})
+	require_Error(t, err, NewJSConsumerNameTooLongError(8))
+
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{
+		Durable:     "C",
+		Description: strings.Repeat("x", 17),
+		AckPolicy:   nats.AckExplicitPolicy,
+	})
+	require_Error(t, err, NewJSConsumerDescriptionTooLongError(16))
+
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{
+		Durable:     "CONSUMER",
+		Description: strings.Repeat("x", 16),
+		AckPolicy:   nats.AckExplicitPolicy,
+	})
+	require_NoError(t, err)
+}
+
+func TestJetStreamLogConsumerLeaderChangesSingleServer(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		log_consumer_leader_changes: true
+		jetstream: {store_dir: %q}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	l := &captureNoticeLogger{}
+	s.SetLogger(l, false, false)
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "C", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+
+	l.Lock()
+	defer l.Unlock()
+	for _, n := range l.notices {
+		require_False(t, strings.Contains(n, "JetStream consumer leader change"))
+	}
+}
+
+func TestJetStreamConsumerMaxPendingBytesLimit(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {
+			store_dir: %q
+			limits: {max_consumer_pending_bytes: %d}
+		}
+	`, t.TempDir(), 10*pendingAckEntrySize)))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	for i := 0; i < 50; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	sub, err := js.PullSubscribe("foo", "C", nats.AckExplicit())
+	require_NoError(t, err)
+
+	// Delivery pauses once the pending state reaches the limit.
+	msgs, err := sub.Fetch(50, nats.MaxWait(500*time.Millisecond))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 10)
+
+	ci, err := js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumAckPending, 10)
+	require_Equal(t, ci.NumPending, 40)
+
+	mset, err := s.GlobalAccount().lookupStream("TEST")
+	require_NoError(t, err)
+	o := mset.lookupConsumer("C")
+	require_NotNil(t, o)
+	info := o.info()
+	require_Equal(t, info.PendingAckBytes, 10*pendingAckEntrySize)
+
+	// Acking frees up room so delivery resumes.
+	for _, m := range msgs {
+		require_NoError(t, m.AckSync())
+	}
+	msgs, err = sub.Fetch(50, nats.MaxWait(500*time.Millisecond))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 10)
+}
+
+func TestJetStreamConsumerFilterMustOverlapStreamSubjects(t *testing.T) {
+	test := func(t *testing.T, s *Server) {
+		nc, js := jsClientConnect(t, s)
+		defer nc.Close()
+
+		_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo.*", "bar"}})
+		require_NoError(t, err)
+
+		create := func(name string, filters []string, allowUnmatched bool) *ApiError {
+			t.Helper()
+			req, err := json.Marshal(&CreateConsumerRequest{
+				Stream:               "TEST",
+				Config:               ConsumerConfig{Durable: name, AckPolicy: AckExplicit, FilterSubjects: filters},
+				AllowUnmatchedFilter: allowUnmatched,
+			})
+			require_NoError(t, err)
+			msg, err := nc.Request(fmt.Sprintf(JSApiDurableCreateT, "TEST", name), req, 2*time.Second)
+			require_NoError(t, err)
+			var resp JSApiConsumerCreateResponse
+			require_NoError(t, json.Unmarshal(msg.Data, &resp))
+			return resp.Error
+		}
+
+		// Overlapping filters, including wildcards, are allowed.
+		require_True(t, create("A", []string{"foo.1"}, false) == nil)
+		require_True(t, create("B", []string{"foo.>", "bar"}, false) == nil)
+		require_True(t, create("C", []string{">"}, false) == nil)
+
+		// A typo in any of the filters is rejected.
+		err = create("D", []string{"foo.1", "baz"}, false)
+		require_Error(t, err, NewJSConsumerFilterNoMatchError("baz"))
+		err = create("E", []string{"foo.1.2"}, false)
+		require_Error(t, err, NewJSConsumerFilterNoMatchError("foo.1.2"))
+
+		// Unless explicitly allowed, for subjects that will be added to the stream later.
+		require_True(t, create("F", []string{"baz"}, true) == nil)
+	}
+
+	t.Run("R1", func(t *testing.T) {
+		s := RunBasicJetStreamServer(t)
+		defer s.Shutdown()
+		test(t, s)
+	})
+
+	t.Run("R3", func(t *testing.T) {
+		c := createJetStreamClusterExplicit(t, "R3S", 3)
+		defer c.shutdown()
+		test(t, c.randomServer())
+	})
+}
+
+// Creates the durable consumer through the API, so that the config is sent
+// as is, and returns the API error, if any.
+func jsCreateDurableConsumer(t *testing.T, nc *nats.Conn, stream, name string, cfg ConsumerConfig) *ApiError {
+	t.Helper()
+	cfg.Durable = name
+	req, err := json.Marshal(&CreateConsumerRequest{Stream: stream, Config: cfg})
+	require_NoError(t, err)
+	msg, err := nc.Request(fmt.Sprintf(JSApiDurableCreateT, stream, name), req, time.Second)
+	require_NoError(t, err)
+	var resp JSApiConsumerCreateResponse
+	require_NoError(t, json.Unmarshal(msg.Data, &resp))
+	return resp.Error
+}
+
+func TestJetStreamConsumerReplaySpeed(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	err = jsCreateDurableConsumer(t, nc, "TEST", "A", ConsumerConfig{AckPolicy: AckExplicit, ReplayPolicy: ReplayOriginal, ReplaySpeed: -1})
+	require_Error(t, err, NewJSConsumerReplaySpeedInvalidError(errors.New("must be positive")))
+	err = jsCreateDurableConsumer(t, nc, "TEST", "A", ConsumerConfig{AckPolicy: AckExplicit, ReplayPolicy: ReplayInstant, ReplaySpeed: 2})
+	require_Error(t, err, NewJSConsumerReplaySpeedInvalidError(errors.New("requires replay policy original")))
+
+	// Messages originally published 300ms apart.
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			time.Sleep(300 * time.Millisecond)
+		}
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	sub := natsSubSync(t, nc, "deliver")
+	defer sub.Unsubscribe()
+	require_True(t, jsCreateDurableConsumer(t, nc, "TEST", "B", ConsumerConfig{
+		AckPolicy:      AckNone,
+		DeliverSubject: "deliver",
+		ReplayPolicy:   ReplayOriginal,
+		ReplaySpeed:    6,
+	}) == nil)
+
+	natsNexMsg(t, sub, time.Second)
+	start := time.Now()
+	natsNexMsg(t, sub, time.Second)
+	natsNexMsg(t, sub, time.Second)
+	// Replayed six times as fast, so ~100ms instead of ~600ms.
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Fatalf("Expected faster replay, took %v", elapsed)
+	}
+}
+
+func TestJetStreamConsumerSubjectAck(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	require_Error(t, jsCreateDurableConsumer(t, nc, "TEST", "A", ConsumerConfig{AckPolicy: AckNone, SubjectAck: true}), NewJSConsumerSubjectAckInvalidError())
+	require_True(t, jsCreateDurableConsumer(t, nc, "TEST", "C", ConsumerConfig{AckPolicy: AckExplicit, SubjectAck: true}) == nil)
+
+	for i := 0; i < 3; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	sub, err := js.PullSubscribe("foo", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+	msgs, err := sub.Fetch(3)
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 3)
+
+	checkAckPending := func(expected int) {
+		t.Helper()
+		checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+			ci, err := js.ConsumerInfo("TEST", "C")
+			if err != nil {
+				return err
+			}
+			if ci.NumAckPending != expected {
+				return fmt.Errorf("expected %d ack pending, got %d", expected, ci.NumAckPending)
+			}
+			return nil
+		})
+	}
+
+	ackSubj := fmt.Sprintf(JSConsumerSubjectAckT, "TEST", "C")
+
+	// Ack by stream sequence, unknown and invalid sequences are ignored.
+	natsPub(t, nc, ackSubj, []byte("2"))
+	natsPub(t, nc, ackSubj, []byte("22"))
+	natsPub(t, nc, ackSubj, []byte("foo"))
+	checkAckPending(2)
+
+	// Other ack types can follow the sequence.
+	natsPub(t, nc, ackSubj, []byte("1 +TERM"))
+	checkAckPending(1)
+	natsPub(t, nc, ackSubj, []byte("3 -NAK"))
+	msgs, err = sub.Fetch(1)
+	require_NoError(t, err)
+	meta, err := msgs[0].Metadata()
+	require_NoError(t, err)
+	require_Equal(t, meta.Sequence.Stream, 3)
+	require_Equal(t, meta.NumDelivered, 2)
+
+	// The original ack reply subject still works.
+	require_NoError(t, msgs[0].AckSync())
+	checkAckPending(0)
+
+	// Disabling it removes the subscription.
+	require_True(t, jsCreateDurableConsumer(t, nc, "TEST", "C", ConsumerConfig{AckPolicy: AckExplicit}) == nil)
+	_, err = js.Publish("foo", nil)
+	require_NoError(t, err)
+	msgs, err = sub.Fetch(1)
+	require_NoError(t, err)
+	natsPub(t, nc, ackSubj, []byte("4"))
+	time.Sleep(100 * time.Millisecond)
+	checkAckPending(1)
+}
+
+func TestJetStreamConsumerPinnedStealPolicy(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	// Only allowed with the pinned client priority policy.
+	_, err = mset.addConsumer(&ConsumerConfig{
+		Durable:        "A",
+		AckPolicy:      AckExplicit,
+		PinStealPolicy: PinStealAllowHigherPriority,
+	})
+	require_Error(t, err, NewJSConsumerPinStealPolicyInvalidError(errors.New("requires pinned client priority policy")))
+
+	_, err = mset.addConsumer(&ConsumerConfig{
+		Durable:        "C",
+		AckPolicy:      AckExplicit,
+		PriorityGroups: []string{"A"},
+		PriorityPolicy: PriorityPinnedClient,
+		PinnedTTL:      10 * time.Second,
+		PinStealPolicy: PinStealAllowHigherPriority,
+	})
+	require_NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	advisories := natsSubSync(t, nc, "$JS.EVENT.ADVISORY.CONSUMER.*.TEST.C")
+	defer advisories.Unsubscribe()
+
+	pull := func(reply, pinId, pinPri string) *nats.Subscription {
+		t.Helper()
+		sub := natsSubSync(t, nc, reply)
+		req, err := json.Marshal(JSApiConsumerGetNextRequest{Batch: 1, Expires: 5 * time.Second, PriorityGroup: PriorityGroup{
+			Id:    pinId,
+			Group: "A",
+		}})
+		require_NoError(t, err)
+		msg := nats.NewMsg("$JS.API.CONSUMER.MSG.NEXT.TEST.C")
+		msg.Reply, msg.Data = reply, req
+		if pinPri != _EMPTY_ {
+			msg.Header.Set(JSPullRequestPinPriority, pinPri)
+		}
+		require_NoError(t, nc.PublishMsg(msg))
+		return sub
+	}
+
+	// The first request gets pinned.
+	msg := natsNexMsg(t, pull("ONE", _EMPTY_, "1"), time.Second)
+	pinned := msg.Header.Get(JSPullRequestNatsPinId)
+	require_NotEqual(t, pinned, _EMPTY_)
+	advisory := natsNexMsg(t, advisories, time.Second)
+	require_Equal(t, advisory.Subject, fmt.Sprintf("%s.TEST.C", JSAdvisoryConsumerPinnedPre))
+
+	// A request with the same priority can't steal the pin.
+	two := pull("TWO", _EMPTY_, "1")
+	_, err = two.NextMsg(250 * time.Millisecond)
+	require_Error(t, err, nats.ErrTimeout)
+	msg = natsNexMsg(t, pull("THREE", "WRONG", "1"), time.Second)
+	require_Equal(t, msg.Header.Get("Status"), "423")
+
+	// A request with a higher priority steals the pin, even with a stale pin id.
+	msg = natsNexMsg(t, pull("FOUR", "WRONG", "5"), time.Second)
+	stolen := msg.Header.Get(JSPullRequestNatsPinId)
+	require_NotEqual(t, stolen, _EMPTY_)
+	require_NotEqual(t, stolen, pinned)
+
+	advisory = natsNexMsg(t, advisories, time.Second)
+	require_Equal(t, advisory.Subject, fmt.Sprintf("%s.TEST.C", JSAdvisoryConsumerUnpinnedPre))
+	var unpinned JSConsumerGroupUnpinnedAdvisory
+	require_NoError(t, json.Unmarshal(advisory.Data, &unpinned))
+	require_Equal(t, unpinned.Reason, "stolen")
+	advisory = natsNexMsg(t, advisories, time.Second)
+	require_Equal(t, advisory.Subject, fmt.Sprintf("%s.TEST.C", JSAdvisoryConsumerPinnedPre))
+	var pinnedAdv JSConsumerGroupPinnedAdvisory
+	require_NoError(t, json.Unmarshal(advisory.Data, &pinnedAdv))
+	require_Equal(t, pinnedAdv.PinnedClientId, stolen)
+
+	// The previous pinned client is now rejected.
+	msg = natsNexMsg(t, pull("FIVE", pinned, "1"), time.Second)
+	require_Equal(t, msg.Header.Get("Status"), "423")
+}
+
+func TestJetStreamConsumerNoRedeliverSubjects(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo.*"}})
+	require_NoError(t, err)
+
+	cfg := &ConsumerConfig{
+		Durable:             "C",
+		AckPolicy:           AckExplicit,
+		AckWait:             250 * time.Millisecond,
+		FilterSubjects:      []string{"foo.a", "foo.b"},
+		NoRedeliverSubjects: []string{"foo.c"},
+	}
+	_, err = mset.addConsumer(cfg)
+	require_Error(t, err, NewJSConsumerNoRedeliverSubjectsInvalidError(errors.New(`"foo.c" is not a subset of the consumer's filter subjects`)))
+
+	cfg.AckPolicy, cfg.NoRedeliverSubjects = AckNone, []string{"foo.b"}
+	_, err = mset.addConsumer(cfg)
+	require_Error(t, err, NewJSConsumerNoRedeliverSubjectsInvalidError(errors.New("ack policy none does not redeliver")))
+
+	cfg.AckPolicy = AckExplicit
+	_, err = mset.addConsumer(cfg)
+	require_NoError(t, err)
+
+	var info JSApiConsumerInfoResponse
+	resp, err := nc.Request(fmt.Sprintf(JSApiConsumerInfoT, "TEST", "C"), nil, time.Second)
+	require_NoError(t, err)
+	require_NoError(t, json.Unmarshal(resp.Data, &info))
+	require_True(t, slices.Equal(info.Config.NoRedeliverSubjects, []string{"foo.b"}))
+
+	terminated := natsSubSync(t, nc, JSAdvisoryConsumerMsgTerminatedPre+".TEST.C")
+	defer terminated.Unsubscribe()
+	checkTerminated := func(seq uint64) {
+		t.Helper()
+		msg := natsNexMsg(t, terminated, 2*time.Second)
+		var e JSConsumerDeliveryTerminatedAdvisory
+		require_NoError(t, json.Unmarshal(msg.Data, &e))
+		require_Equal(t, e.StreamSeq, seq)
+		require_Equal(t, e.Reason, ackTermNoRedeliverReason)
+	}
+
+	_, err = js.Publish("foo.a", nil)
+	require_NoError(t, err)
+	_, err = js.Publish("foo.b", nil)
+	require_NoError(t, err)
+
+	sub, err := js.PullSubscribe(_EMPTY_, "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+	msgs, err := sub.Fetch(2)
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 2)
+
+	// A nak'd message on foo.b is terminated, while foo.a is redelivered.
+	for _, msg := range msgs {
+		require_NoError(t, msg.Nak())
+	}
+	checkTerminated(2)
+	msgs, err = sub.Fetch(1)
+	require_NoError(t, err)
+	require_Equal(t, msgs[0].Subject, "foo.a")
+	require_NoError(t, msgs[0].AckSync())
+
+	// Same when the ack wait expires.
+	_, err = js.Publish("foo.b", nil)
+	require_NoError(t, err)
+	msgs, err = sub.Fetch(1)
+	require_NoError(t, err)
+	require_Equal(t, msgs[0].Subject, "foo.b")
+	checkTerminated(3)
+	_, err = sub.Fetch(1, nats.MaxWait(500*time.Millisecond))
+	require_Error(t, err, nats.ErrTimeout)
+
+	ci, err := js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumAckPending, 0)
+	require_Equal(t, ci.AckFloor.Stream, 3)
+}
+
+func TestJetStreamConsumerAckBatch(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckNone, AckBatchSize: 3})
+	require_Error(t, err, NewJSConsumerAckBatchInvalidError(errors.New("ack policy none does not support ack batches")))
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, AckBatchSize: -1})
+	require_Error(t, err, NewJSConsumerAckBatchInvalidError(errors.New("ack batch size can not be negative")))
+
+	for i := 0; i < 5; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	for _, test := range []struct {
+		name     string
+		cfg      ConsumerConfig
+		ack      int
+		expected []uint64
+	}{
+		{"size", ConsumerConfig{Durable: "SIZE", AckPolicy: AckExplicit, AckBatchSize: 3}, 3, []uint64{1, 5}},
+		{"window", ConsumerConfig{Durable: "WINDOW", AckPolicy: AckExplicit, AckBatchWindow: time.Minute}, 2, []uint64{4, 5}},
+		{"none", ConsumerConfig{Durable: "NONE", AckPolicy: AckExplicit}, 3, []uint64{1, 2, 3, 5}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			o, err := mset.addConsumer(&test.cfg)
+			require_NoError(t, err)
+
+			sub, err := js.PullSubscribe(_EMPTY_, test.cfg.Durable, nats.Bind("TEST", test.cfg.Durable))
+			require_NoError(t, err)
+			defer sub.Unsubscribe()
+			msgs, err := sub.Fetch(5)
+			require_NoError(t, err)
+			require_Len(t, len(msgs), 5)
+
+			// A single ack covers the messages delivered before it within the batch.
+			require_NoError(t, msgs[test.ack].AckSync())
+
+			o.mu.RLock()
+			pending := slices.Sorted(maps.Keys(o.pending))
+			o.mu.RUnlock()
+			require_True(t, slices.Equal(pending, test.expected))
+		})
+	}
+}
+
+func TestJetStreamConsumerMaxAckAge(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckNone, MaxAckAge: time.Second})
+	require_Error(t, err, NewJSConsumerMaxAckAgeInvalidError(errors.New("ack policy none has no pending messages")))
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, AckWait: time.Second, MaxAckAge: time.Second})
+	require_Error(t, err, NewJSConsumerMaxAckAgeInvalidError(errors.New("max ack age needs to be larger than ack wait")))
+
+	o, err := mset.addConsumer(&ConsumerConfig{
+		Durable:   "C",
+		AckPolicy: AckExplicit,
+		AckWait:   100 * time.Millisecond,
+		MaxAckAge: 500 * time.Millisecond,
+	})
+	require_NoError(t, err)
+
+	terminated := natsSubSync(t, nc, JSAdvisoryConsumerMsgTerminatedPre+".TEST.C")
+	defer terminated.Unsubscribe()
+
+	_, err = js.Publish("foo", nil)
+	require_NoError(t, err)
+
+	sub, err := js.PullSubscribe(_EMPTY_, "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// The message is redelivered while within the max ack age.
+	start := time.Now()
+	msgs, err := sub.Fetch(1)
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 1)
+	msgs, err = sub.Fetch(1)
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 1)
+	meta, err := msgs[0].Metadata()
+	require_NoError(t, err)
+	require_Equal(t, meta.NumDelivered, 2)
+
+	// Then terminated once it has been pending for the max ack age.
+	msg := natsNexMsg(t, terminated, 2*time.Second)
+	require_True(t, time.Since(start) >= 500*time.Millisecond)
+	var e JSConsumerDeliveryTerminatedAdvisory
+	require_NoError(t, json.Unmarshal(msg.Data, &e))
+	require_Equal(t, e.StreamSeq, 1)
+	require_Equal(t, e.Reason, ackTermMaxAckAgeReason)
+
+	checkFor(t, time.Second, 50*time.Millisecond, func() error {
+		o.mu.RLock()
+		defer o.mu.RUnlock()
+		if len(o.pending) > 0 || len(o.pfd) > 0 {
+			return fmt.Errorf("still pending: %d", len(o.pending))
+		}
+		return nil
+	})
+}
+
+func TestJetStreamConsumerOrderByTimestamp(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo.>"}})
+	require_NoError(t, err)
+
+	for _, test := range []struct {
+		cfg *ConsumerConfig
+		err string
+	}{
+		{&ConsumerConfig{DeliverSubject: "d", OrderByTimestamp: true, MaxRequestBatch: 10}, "only supported on pull consumers"},
+		{&ConsumerConfig{OrderByTimestamp: true, ReplayPolicy: ReplayOriginal, MaxRequestBatch: 10}, "only supported with instant replay policy"},
+		{&ConsumerConfig{OrderByTimestamp: true, AckPolicy: AckAll, MaxRequestBatch: 10}, "not supported with ack all policy"},
+		{&ConsumerConfig{OrderByTimestamp: true, AckPolicy: AckExplicit}, "max request batch needs to be set"},
+	} {
+		_, err = mset.addConsumer(test.cfg)
+		require_Error(t, err, NewJSConsumerOrderByTimestampInvalidError(errors.New(test.err)))
+	}
+
+	_, err = mset.addConsumer(&ConsumerConfig{
+		Durable:          "C",
+		AckPolicy:        AckExplicit,
+		FilterSubjects:   []string{"foo.a", "foo.b"},
+		MaxRequestBatch:  10,
+		OrderByTimestamp: true,
+	})
+	require_NoError(t, err)
+
+	// Publish with timestamps out of stream order, the last one relying on the stored timestamp.
+	now := time.Now().UTC()
+	publish := func(subj string, ts time.Time) {
+		t.Helper()
+		m := nats.NewMsg(subj)
+		if !ts.IsZero() {
+			m.Header.Set(JSTimeStamp, ts.Format(time.RFC3339Nano))
+		}
+		_, err := js.PublishMsg(m)
+		require_NoError(t, err)
+	}
+	publish("foo.a", now.Add(-time.Second))
+	publish("foo.b", now.Add(-3*time.Second))
+	publish("foo.c", now.Add(-4*time.Second))
+	publish("foo.a", now.Add(-2*time.Second))
+	publish("foo.b", time.Time{})
+
+	sub, err := js.PullSubscribe(_EMPTY_, "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+
+	msgs, err := sub.Fetch(4, nats.MaxWait(2*time.Second))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 4)
+	var seqs []uint64
+	for _, m := range msgs {
+		meta, err := m.Metadata()
+		require_NoError(t, err)
+		seqs = append(seqs, meta.Sequence.Stream)
+		require_NoError(t, m.AckSync())
+	}
+	require_True(t, slices.Equal(seqs, []uint64{2, 4, 1, 5}))
+}
+
+func TestJetStreamConsumerNamePatternFromStream(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	// The pattern needs to be a valid regular expression.
+	_, err := jsStreamCreate(t, nc, &StreamConfig{
+		Name:           "BAD",
+		Storage:        FileStorage,
+		ConsumerLimits: StreamConsumerLimits{NamePattern: "team-("},
+	})
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "consumer limits name pattern is invalid")
+
+	const pattern = "^team-a-"
+	_, err = jsStreamCreate(t, nc, &StreamConfig{
+		Name:           "TEST",
+		Subjects:       []string{"foo"},
+		Storage:        FileStorage,
+		ConsumerLimits: StreamConsumerLimits{NamePattern: pattern},
+	})
+	require_NoError(t, err)
+
+	// Conforming durable and named consumers are accepted.
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "team-a-durable", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Name: "team-a-named", AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+
+	// Non-conforming ones are rejected, with the pattern in the error.
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "team-b-durable", AckPolicy: nats.AckExplicitPolicy})
+	require_Error(t, err, NewJSConsumerNamePatternMismatchError("team-b-durable", pattern))
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Name: "other", AckPolicy: nats.AckExplicitPolicy})
+	require_Error(t, err, NewJSConsumerNamePatternMismatchError("other", pattern))
+
+	// Unnamed ephemerals get a generated name, so are not constrained.
+	_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{AckPolicy: nats.AckExplicitPolicy})
+	require_NoError(t, err)
+
+	// Internal sourcing consumers are exempt as well.
+	_, err = jsStreamCreate(t, nc, &StreamConfig{
+		Name:    "SOURCE",
+		Storage: FileStorage,
+		Sources: []*StreamSource{{Name: "TEST"}},
+	})
+	require_NoError(t, err)
+	_, err = js.Publish("foo", nil)
+	require_NoError(t, err)
+	checkFor(t, 2*time.Second, 100*time.Millisecond, func() error {
+		si, err := js.StreamInfo("SOURCE")
+		if err != nil {
+			return err
+		}
+		if si.State.Msgs != 1 {
+			return fmt.Errorf("expected 1 sourced message, got %d", si.State.Msgs)
+		}
+		return nil
+	})
+}
+
+func TestJetStreamConsumerDeadLetterSubject(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo.>"}})
+	require_NoError(t, err)
+	_, err = s.GlobalAccount().addStream(&StreamConfig{Name: "DLQ", Subjects: []string{"dlq"}})
+	require_NoError(t, err)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, DeadLetterSubject: "dlq.*"})
+	require_Error(t, err, NewJSConsumerDeadLetterSubjectInvalidError(errors.New("not a valid publish subject")))
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, DeadLetterSubject: "foo.dlq"})
+	require_Error(t, err, NewJSConsumerDeadLetterSubjectInvalidError(errors.New("forms a cycle with the stream subjects")))
+
+	_, err = mset.addConsumer(&ConsumerConfig{
+		Durable:           "C",
+		AckPolicy:         AckExplicit,
+		MaxDeliver:        2,
+		DeadLetterSubject: "dlq",
+	})
+	require_NoError(t, err)
+
+	m := nats.NewMsg("foo.bar")
+	m.Header.Set("X-Custom", "value")
+	m.Data = []byte("payload")
+	_, err = js.PublishMsg(m)
+	require_NoError(t, err)
+
+	sub, err := js.PullSubscribe(_EMPTY_, "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for i := 0; i < 2; i++ {
+		msgs, err := sub.Fetch(1)
+		require_NoError(t, err)
+		require_Len(t, len(msgs), 1)
+		require_NoError(t, msgs[0].Nak())
+	}
+	// Trigger the max deliveries check on the next delivery attempt.
+	_, err = sub.Fetch(1, nats.MaxWait(250*time.Millisecond))
+	require_Error(t, err, nats.ErrTimeout)
+
+	var sm *nats.RawStreamMsg
+	checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+		sm, err = js.GetMsg("DLQ", 1)
+		return err
+	})
+	require_Equal(t, string(sm.Data), "payload")
+	require_Equal(t, sm.Header.Get("X-Custom"), "value")
+	require_Equal(t, sm.Header.Get(JSStream), "TEST")
+	require_Equal(t, sm.Header.Get(JSSubject), "foo.bar")
+	require_Equal(t, sm.Header.Get(JSSequence), "1")
+	require_Equal(t, sm.Header.Get(JSDeadLetterConsumer), "C")
+	require_Equal(t, sm.Header.Get(JSDeadLetterDeliveries), "2")
+}
+
+func TestJetStreamConsumerExcludeSubjects(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"orders.>"}})
+	require_NoError(t, err)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, ExcludeSubjects: []string{"orders..bad"}})
+	require_Error(t, err, NewJSConsumerExcludeSubjectInvalidError(errors.New(`"orders..bad" is not a valid subject`)))
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, ExcludeSubjects: []string{"orders.paid", "orders.paid"}})
+	require_Error(t, err, NewJSConsumerExcludeSubjectInvalidError(errors.New(`duplicate exclude subject "orders.paid"`)))
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, ExcludeSubjects: []string{"orders.*", "orders.paid"}})
+	require_Error(t, err, NewJSConsumerExcludeSubjectInvalidError(errors.New(`exclude subject "orders.paid" overlaps "orders.*"`)))
+
+	for _, subj := range []string{"orders.new", "orders.internal.audit", "orders.paid", "orders.internal.sync", "orders.shipped"} {
+		_, err = js.Publish(subj, nil)
+		require_NoError(t, err)
+	}
+
+	o, err := mset.addConsumer(&ConsumerConfig{
+		Durable:         "C",
+		AckPolicy:       AckExplicit,
+		FilterSubject:   "orders.>",
+		ExcludeSubjects: []string{"orders.internal.>"},
+	})
+	require_NoError(t, err)
+
+	ci, err := js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumPending, 3)
+	o.mu.RLock()
+	cfg := o.cfg
+	o.mu.RUnlock()
+	require_True(t, slices.Equal(cfg.ExcludeSubjects, []string{"orders.internal.>"}))
+
+	sub, err := js.PullSubscribe("orders.>", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+
+	fetch := func(expected ...string) {
+		t.Helper()
+		msgs, err := sub.Fetch(10, nats.MaxWait(250*time.Millisecond))
+		require_NoError(t, err)
+		var subjects []string
+		for _, m := range msgs {
+			subjects = append(subjects, m.Subject)
+			require_NoError(t, m.AckSync())
+		}
+		require_True(t, slices.Equal(subjects, expected))
+	}
+	fetch("orders.new", "orders.paid", "orders.shipped")
+
+	// Trailing excluded messages are skipped as well.
+	_, err = js.Publish("orders.internal.audit", nil)
+	require_NoError(t, err)
+	_, err = js.Publish("orders.cancelled", nil)
+	require_NoError(t, err)
+	fetch("orders.cancelled")
+	ci, err = js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumPending, 0)
+
+	// Exclude subjects can be updated.
+	_, err = mset.addConsumerWithAction(&ConsumerConfig{
+		Durable:         "C",
+		AckPolicy:       AckExplicit,
+		FilterSubject:   "orders.>",
+		ExcludeSubjects: []string{"orders.paid"},
+	}, ActionUpdate, false)
+	require_NoError(t, err)
+	for _, subj := range []string{"orders.paid", "orders.internal.audit"} {
+		_, err = js.Publish(subj, nil)
+		require_NoError(t, err)
+	}
+	fetch("orders.internal.audit")
+
+	// Long runs of excluded messages are skipped.
+	for i := 0; i < 10*maxExcludedSkips; i++ {
+		_, err = js.Publish("orders.paid", nil)
+		require_NoError(t, err)
+	}
+	_, err = js.Publish("orders.refunded", nil)
+	require_NoError(t, err)
+	ci, err = js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumPending, 1)
+	fetch("orders.refunded")
+}
+
+func TestJetStreamConsumerSkipHeaders(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Retention: WorkQueuePolicy})
+	require_NoError(t, err)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, SkipHeaders: map[string]string{"Nats Skip": "true"}})
+	require_Error(t, err, NewJSConsumerSkipHeadersInvalidError(errors.New(`"Nats Skip" is not a valid header name`)))
+	tooMany := make(map[string]string, JSMaxSkipHeaders+1)
+	for i := 0; i <= JSMaxSkipHeaders; i++ {
+		tooMany[fmt.Sprintf("H-%d", i)] = "true"
+	}
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, SkipHeaders: tooMany})
+	require_Error(t, err, NewJSConsumerSkipHeadersInvalidError(fmt.Errorf("can not have more than %d entries", JSMaxSkipHeaders)))
+
+	publish := func(data, skip string) {
+		t.Helper()
+		m := nats.NewMsg("foo")
+		m.Data = []byte(data)
+		if skip != _EMPTY_ {
+			m.Header.Set("Nats-Skip", skip)
+		}
+		_, err := js.PublishMsg(m)
+		require_NoError(t, err)
+	}
+	publish("1", _EMPTY_)
+	publish("2", "true")
+	publish("3", "false")
+	publish("4", "true")
+
+	_, err = mset.addConsumer(&ConsumerConfig{
+		Durable:     "C",
+		AckPolicy:   AckExplicit,
+		SkipHeaders: map[string]string{"Nats-Skip": "true"},
+	})
+	require_NoError(t, err)
+
+	sub, err := js.PullSubscribe(_EMPTY_, "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+
+	msgs, err := sub.Fetch(10, nats.MaxWait(250*time.Millisecond))
+	require_NoError(t, err)
+	var data []string
+	for _, m := range msgs {
+		data = append(data, string(m.Data))
+		require_NoError(t, m.AckSync())
+	}
+	require_True(t, slices.Equal(data, []string{"1", "3"}))
+
+	// Skipped messages count as processed.
+	ci, err := js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumPending, 0)
+	require_Equal(t, ci.NumAckPending, 0)
+	var info ConsumerInfo
+	resp, err := nc.Request(fmt.Sprintf(JSApiConsumerInfoT, "TEST", "C"), nil, time.Second)
+	require_NoError(t, err)
+	require_NoError(t, json.Unmarshal(resp.Data, &info))
+	require_Equal(t, info.NumSkipped, 2)
+	require_Equal(t, info.Config.SkipHeaders["Nats-Skip"], "true")
+
+	// And so are removed from the work queue.
+	checkFor(t, time.Second, 50*time.Millisecond, func() error {
+		if state := mset.state(); state.Msgs != 0 {
+			return fmt.Errorf("expected no messages, got %d", state.Msgs)
+		}
+		return nil
+	})
+}
+
+func TestJetStreamConsumerAckSameConnection(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+	nc2, _ := jsClientConnect(t, s)
+	defer nc2.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckAll, AckSameConnection: true})
+	require_Error(t, err, NewJSConsumerAckSameConnectionInvalidError(errors.New("ack policy must be explicit")))
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, DeliverSubject: "bar", AckSameConnection: true})
+	require_Error(t, err, NewJSConsumerAckSameConnectionInvalidError(errors.New("consumer must be pull based")))
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, AckSameConnection: true})
+	require_NoError(t, err)
+
+	for range 2 {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	sub, err := js.PullSubscribe("foo", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	defer sub.Unsubscribe()
+	msgs, err := sub.Fetch(2, nats.MaxWait(time.Second))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 2)
+
+	// Acks from another connection are ignored.
+	_, err = nc2.Request(msgs[0].Reply, nil, 250*time.Millisecond)
+	require_Error(t, err, nats.ErrTimeout)
+	natsPub(t, nc2, msgs[1].Reply, nil)
+	natsFlush(t, nc2)
+	ci, err := js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumAckPending, 2)
+
+	// While acks from the connection the messages were delivered for are accepted.
+	for _, m := range msgs {
+		require_NoError(t, m.AckSync())
+	}
+	ci, err = js.ConsumerInfo("TEST", "C")
+	require_NoError(t, err)
+	require_Equal(t, ci.NumAckPending, 0)
+	require_Equal(t, ci.AckFloor.Stream, 2)
+}
+
+func TestJetStreamConsumerAnnounceGaps(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo", "bar"}})
+	require_NoError(t, err)
+
+	for _, subj := range []string{"foo", "bar", "bar", "foo", "foo", "foo"} {
+		_, err = js.Publish(subj, nil)
+		require_NoError(t, err)
+	}
+	require_NoError(t, js.DeleteMsg("TEST", 5))
+
+	for _, announce := range []bool{true, false} {
+		name := fmt.Sprintf("C-%t", announce)
+		_, err = mset.addConsumer(&ConsumerConfig{Durable: name, AckPolicy: AckExplicit, FilterSubject: "foo", AnnounceGaps: announce})
+		require_NoError(t, err)
+
+		sub, err := js.PullSubscribe("foo", name, nats.Bind("TEST", name))
+		require_NoError(t, err)
+		defer sub.Unsubscribe()
+
+		msgs, err := sub.Fetch(10, nats.MaxWait(250*time.Millisecond))
+		require_NoError(t, err)
+		require_Len(t, len(msgs), 3)
+		var gaps []string
+		for _, m := range msgs {
+			gaps = append(gaps, m.Header.Get(JSConsumerGap))
+		}
+		// Sequences 2 and 3 are filtered out, and 5 is deleted.
+		if announce {
+			require_Equal(t, strings.Join(gaps, ","), ",2,1")
+		} else {
+			require_Equal(t, strings.Join(gaps, ","), ",,")
+		}
+	}
+}
+
+func TestJetStreamConsumerExpireRequestsOnPause(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, _ := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	for _, expire := range []bool{true, false} {
+		name := fmt.Sprintf("C-%t", expire)
+		o, err := mset.addConsumer(&ConsumerConfig{Durable: name, AckPolicy: AckExplicit, ExpireRequestsOnPause: expire})
+		require_NoError(t, err)
+
+		sub := natsSubSync(t, nc, nats.NewInbox())
+		defer sub.Unsubscribe()
+		req := []byte(`{"batch":10,"expires":5000000000}`)
+		require_NoError(t, nc.PublishRequest(fmt.Sprintf(JSApiRequestNextT, "TEST", name), sub.Subject, req))
+		checkFor(t, time.Second, 10*time.Millisecond, func() error {
+			if n := o.info().NumWaiting; n != 1 {
+				return fmt.Errorf("expected 1 waiting request, got %d", n)
+			}
+			return nil
+		})
+
+		deadline := time.Now().Add(time.Hour)
+		jsTestPause_PauseConsumer(t, nc, "TEST", name, deadline)
+
+		if !expire {
+			_, err = sub.NextMsg(250 * time.Millisecond)
+			require_Error(t, err, nats.ErrTimeout)
+			require_Equal(t, o.info().NumWaiting, 1)
+			continue
+		}
+		msg, err := sub.NextMsg(time.Second)
+		require_NoError(t, err)
+		require_Equal(t, msg.Header.Get("Status"), "409")
+		require_Equal(t, msg.Header.Get("Description"), "Consumer Paused")
+		require_Equal(t, msg.Header.Get(JSPullRequestPendingMsgs), "10")
+		until, err := time.Parse(time.RFC3339Nano, msg.Header.Get(JSConsumerPauseUntil))
+		require_NoError(t, err)
+		require_True(t, until.Equal(deadline))
+		require_Equal(t, o.info().NumWaiting, 0)
+	}
+}
+
+func TestJetStreamConsumerDefaultFlowControlHeartbeat(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, default_flow_control_heartbeat: "2s", limits: {max_ack_pending: 100}}
+	`, t.TempDir())))
+	s, opts := RunServerWithConfig(conf)
+	defer s.Shutdown()
+	// Not reset by the limits block.
+	require_Equal(t, opts.JetStreamLimits.FlowControlHeartbeat, 2*time.Second)
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	ci, err := js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "C", DeliverSubject: "deliver", FlowControl: true})
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.Heartbeat, 2*time.Second)
+
+	// An explicit heartbeat is kept.
+	ci, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "D", DeliverSubject: "deliver", FlowControl: true, Heartbeat: time.Second})
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.Heartbeat, time.Second)
+}
+
+func TestJetStreamConsumerEphemeralDeleteJitter(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		jitter   string
+		min, max time.Duration
+	}{
+		{"Default", _EMPTY_, JsDeleteWaitTimeDefault + 100*time.Millisecond, JsDeleteWaitTimeDefault + time.Second},
+		{"None", "ephemeral_delete_jitter: 0", JsDeleteWaitTimeDefault, JsDeleteWaitTimeDefault},
+		{"Custom", `ephemeral_delete_jitter: "50ms"`, JsDeleteWaitTimeDefault + 5*time.Millisecond, JsDeleteWaitTimeDefault + 50*time.Millisecond},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			conf := createConfFile(t, []byte(fmt.Sprintf(`
+				listen: 127.0.0.1:-1
+				jetstream: {store_dir: %q, %s}
+			`, t.TempDir(), test.jitter)))
+			s, _ := RunServerWithConfig(conf)
+			defer s.Shutdown()
+
+			mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+			require_NoError(t, err)
+
+			for i := 0; i < 10; i++ {
+				o, err := mset.addConsumer(&ConsumerConfig{AckPolicy: AckExplicit})
+				require_NoError(t, err)
+				o.mu.RLock()
+				dthresh := o.dthresh
+				o.mu.RUnlock()
+				require_True(t, dthresh >= test.min && dthresh <= test.max)
+			}
+		})
+	}
+}
+
+func TestJetStreamConsumerUpdateMaxAckPendingBelowPending(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	cfg := &nats.ConsumerConfig{Durable: "C", AckPolicy: nats.AckExplicitPolicy, MaxAckPending: 100}
+	_, err = js.AddConsumer("TEST", cfg)
+	require_NoError(t, err)
+
+	sub, err := js.PullSubscribe("foo", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	msgs, err := sub.Fetch(10, nats.MaxWait(time.Second))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 10)
+
+	// Lowering below the 10 pending acks is rejected.
+	cfg.MaxAckPending = 5
+	_, err = js.UpdateConsumer("TEST", cfg)
+	require_Error(t, err, NewJSConsumerMaxAckPendingBelowPendingError(10))
+
+	// Lowering, but not below what is pending, is fine.
+	cfg.MaxAckPending = 10
+	ci, err := js.UpdateConsumer("TEST", cfg)
+	require_NoError(t, err)
+	require_Equal(t, ci.Config.MaxAckPending, 10)
+
+	// Unless forced.
+	req, err := json.Marshal(&CreateConsumerRequest{
+		Stream: "TEST",
+		Config: ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, MaxAckPending: 5},
+		Action: ActionUpdate,
+		Force:  true,
+	})
+	require_NoError(t, err)
+	resp, err := nc.Request(fmt.Sprintf(JSApiDurableCreateT, "TEST", "C"), req, time.Second)
+	require_NoError(t, err)
+	var ccResp JSApiConsumerCreateResponse
+	require_NoError(t, json.Unmarshal(resp.Data, &ccResp))
+	require_True(t, ccResp.Error == nil)
+	require_Equal(t, ccResp.Config.MaxAckPending, 5)
+}
+
+func TestJetStreamConsumerDeliveryReceipts(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	for i := 0; i < 5; i++ {
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+	}
+
+	for _, cfg := range []*ConsumerConfig{
+		{Durable: "I", DeliveryReceiptSubject: "receipts"},
+		{Durable: "I", DeliveryReceiptSubject: "receipts", DeliveryReceiptSampling: 101},
+		{Durable: "I", DeliveryReceiptSampling: 50},
+		{Durable: "I", DeliveryReceiptSubject: "foo", DeliveryReceiptSampling: 100},
+		{Durable: "I", DeliveryReceiptSubject: "receipts.*", DeliveryReceiptSampling: 100},
+	} {
+		_, err = mset.addConsumer(cfg)
+		require_Error(t, err)
+		require_True(t, IsNatsErr(err, JSConsumerDeliveryReceiptInvalidErrF))
+	}
+
+	receipts := natsSubSync(t, nc, "receipts")
+	natsFlush(t, nc)
+
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", AckPolicy: AckExplicit, DeliveryReceiptSubject: "receipts", DeliveryReceiptSampling: 100})
+	require_NoError(t, err)
+	sub, err := js.PullSubscribe("foo", "C", nats.Bind("TEST", "C"))
+	require_NoError(t, err)
+	msgs, err := sub.Fetch(5, nats.MaxWait(time.Second))
+	require_NoError(t, err)
+	require_Len(t, len(msgs), 5)
+
+	for i := uint64(1); i <= 5; i++ {
+		msg := natsNexMsg(t, receipts, time.Second)
+		var receipt ConsumerDeliveryReceipt
+		require_NoError(t, json.Unmarshal(msg.Data, &receipt))
+		require_Equal(t, receipt.Stream, "TEST")
+		require_Equal(t, receipt.Consumer, "C")
+		require_Equal(t, receipt.StreamSeq, i)
+		require_Equal(t, receipt.ConsumerSeq, i)
+		require_Equal(t, receipt.Deliveries, 1)
+		require_False(t, receipt.Timestamp.IsZero())
+	}
+}
+
+func TestJetStreamConsumerDeliveryConcurrency(t *testing.T) {
+	s := RunBasicJetStreamServer(t)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	mset, err := s.GlobalAccount().addStream(&StreamConfig{Name: "TEST", Subjects: []string{"foo.*"}})
+	require_NoError(t, err)
+
+	for _, cfg := range []*ConsumerConfig{
+		{Durable: "I", DeliverSubject: "deliver", DeliveryConcurrency: -1},
+		{Durable: "I", DeliverSubject: "deliver", DeliveryConcurrency: JsMaxDeliveryConcurrency + 1},
+		{Durable: "I", AckPolicy: AckExplicit, DeliveryConcurrency: 2},
+		{Durable: "I", DeliverSubject: "deliver", FlowControl: true, Heartbeat: time.Second, DeliveryConcurrency: 2},
+	} {
+		_, err = mset.addConsumer(cfg)
+		require_Error(t, err)
+		require_True(t, IsNatsErr(err, JSConsumerDeliveryConcurrencyInvalidErrF))
+	}
+
+	const subjects, perSubject = 10, 50
+	for i := 0; i < perSubject; i++ {
+		for j := 0; j < subjects; j++ {
+			_, err = js.Publish(fmt.Sprintf("foo.%d", j), nil)
+			require_NoError(t, err)
+		}
+	}
+
+	sub := natsSubSync(t, nc, "deliver")
+	natsFlush(t, nc)
+	o, err := mset.addConsumer(&ConsumerConfig{Durable: "C", DeliverSubject: "deliver", AckPolicy: AckNone, DeliveryConcurrency: 4})
+	require_NoError(t, err)
+	o.mu.RLock()
+	require_Len(t, len(o.dlanes), 4)
+	o.mu.RUnlock()
+
+	// Messages with the same subject are delivered in order.
+	last := make(map[string]uint64)
+	for i := 0; i < subjects*perSubject; i++ {
+		msg := natsNexMsg(t, sub, time.Second)
+		meta, err := msg.Metadata()
+		require_NoError(t, err)
+		require_True(t, meta.Sequence.Stream > last[msg.Subject])
+		last[msg.Subject] = meta.Sequence.Stream
+	}
+	require_Len(t, len(last), subjects)
+
+	// Can not be updated.
+	_, err = mset.addConsumer(&ConsumerConfig{Durable: "C", DeliverSubject: "deliver", AckPolicy: AckNone, DeliveryConcurrency: 2})
+	require_Error(t, err)
+
+	// Messages still queued on a lane when it quits are delivered.
+	qch := make(chan struct{})
+	close(qch)
+	for i := 0; i < 20; i++ {
+		lane := &jsOutQ{newIPQueue[*jsPubMsg](s, fmt.Sprintf("lane %d", i))}
+		lane.send(newJSPubMsg("deliver", _EMPTY_, _EMPTY_, nil, []byte("ok"), nil, 0))
+		o.deliveryLaneLoop(s.GlobalAccount(), lane, qch)
+		msg := natsNexMsg(t, sub, time.Second)
+		require_Equal(t, string(msg.Data), "ok")
+	}
+}
+
+func TestJetStreamConsumerAccountPushConsumersDisabled(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {max_mem_store: 64MB, max_file_store: 64MB, store_dir: %q}
+		accounts {
+			A { jetstream: enabled, allow_push_consumers: false, users: [ {user: a, password: pwd} ] }
+			B { jetstream: enabled, users: [ {user: b, password: pwd} ] }
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	for _, user := range []string{"a", "b"} {
+		nc, js := jsClientConnect(t, s, nats.UserInfo(user, "pwd"))
+		defer nc.Close()
+
+		_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+		require_NoError(t, err)
+
+		// Pull consumers are always allowed.
+		_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "PULL", AckPolicy: nats.AckExplicitPolicy})
+		require_NoError(t, err)
+
+		_, err = js.AddConsumer("TEST", &nats.ConsumerConfig{Durable: "PUSH", DeliverSubject: "deliver"})
+		if user == "a" {
+			require_Error(t, err, NewJSConsumerPushDisabledError())
+		} else {
+			require_NoError(t, err)
+		}
+
+		// Mirrors use internal push consumers, which are not restricted.
+		_, err = js.AddStream(&nats.StreamConfig{Name: "MIRROR", Mirror: &nats.StreamSource{Name: "TEST"}})
+		require_NoError(t, err)
+		_, err = js.Publish("foo", nil)
+		require_NoError(t, err)
+		checkFor(t, 2*time.Second, 100*time.Millisecond, func() error {
+			si, err := js.StreamInfo("MIRROR")
+			if err != nil {
+				return err
+			}
+			if si.State.Msgs != 1 {
+				return fmt.Errorf("expected 1 mirrored msg, got %d", si.State.Msgs)
+			}
+			return nil
+		})
+	}
+}
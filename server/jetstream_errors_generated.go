@@ -89,6 +89,9 @@ const (
 	// JSClusterUnSupportFeatureErr not currently supported in clustered mode
 	JSClusterUnSupportFeatureErr ErrorIdentifier = 10036
 
+	// JSConsumerAckBatchInvalidErrF consumer ack batch invalid: {err}
+	JSConsumerAckBatchInvalidErrF ErrorIdentifier = 10239
+
 	// JSConsumerAckFCRequiresFCErr flow control ack policy requires flow control
 	JSConsumerAckFCRequiresFCErr ErrorIdentifier = 10219
 
@@ -107,9 +110,15 @@ const (
 	// JSConsumerAckPolicyInvalidErr consumer ack policy invalid
 	JSConsumerAckPolicyInvalidErr ErrorIdentifier = 10181
 
+	// JSConsumerAckSameConnectionInvalidErrF consumer ack same connection invalid: {err}
+	JSConsumerAckSameConnectionInvalidErrF ErrorIdentifier = 10247
+
 	// JSConsumerAckWaitNegativeErr consumer ack wait needs to be positive
 	JSConsumerAckWaitNegativeErr ErrorIdentifier = 10183
 
+	// JSConsumerAckWaitPerFilterInvalidErrF consumer ack wait per filter invalid: {err}
+	JSConsumerAckWaitPerFilterInvalidErrF ErrorIdentifier = 10230
+
 	// JSConsumerAlreadyExists action CREATE is used for a existing consumer with a different config (consumer already exists)
 	JSConsumerAlreadyExists ErrorIdentifier = 10148
 
@@ -131,12 +140,27 @@ const (
 	// JSConsumerCreateFilterSubjectMismatchErr Consumer create request did not match filtered subject from create subject
 	JSConsumerCreateFilterSubjectMismatchErr ErrorIdentifier = 10131
 
+	// JSConsumerDeadLetterSubjectInvalidErrF consumer dead letter subject invalid: {err}
+	JSConsumerDeadLetterSubjectInvalidErrF ErrorIdentifier = 10244
+
 	// JSConsumerDeliverCycleErr consumer deliver subject forms a cycle
 	JSConsumerDeliverCycleErr ErrorIdentifier = 10081
 
+	// JSConsumerDeliverPolicyRequiredErr consumer deliver policy required, set deliver_policy explicitly
+	JSConsumerDeliverPolicyRequiredErr ErrorIdentifier = 10224
+
 	// JSConsumerDeliverToWildcardsErr consumer deliver subject has wildcards
 	JSConsumerDeliverToWildcardsErr ErrorIdentifier = 10079
 
+	// JSConsumerDeliveryConcurrencyInvalidErrF consumer delivery concurrency invalid: {err}
+	JSConsumerDeliveryConcurrencyInvalidErrF ErrorIdentifier = 10251
+
+	// JSConsumerDeliveryQuotaNegativeErr consumer delivery quota can not be negative
+	JSConsumerDeliveryQuotaNegativeErr ErrorIdentifier = 10233
+
+	// JSConsumerDeliveryReceiptInvalidErrF consumer delivery receipt configuration invalid: {err}
+	JSConsumerDeliveryReceiptInvalidErrF ErrorIdentifier = 10250
+
 	// JSConsumerDescriptionTooLongErrF consumer description is too long, maximum allowed is {max}
 	JSConsumerDescriptionTooLongErrF ErrorIdentifier = 10107
 
@@ -173,12 +197,18 @@ const (
 	// JSConsumerEphemeralWithDurableNameErr consumer expected to be ephemeral but a durable name was set in request
 	JSConsumerEphemeralWithDurableNameErr ErrorIdentifier = 10020
 
+	// JSConsumerExcludeSubjectInvalidErrF consumer exclude subject invalid: {err}
+	JSConsumerExcludeSubjectInvalidErrF ErrorIdentifier = 10245
+
 	// JSConsumerExistingActiveErr consumer already exists and is still active
 	JSConsumerExistingActiveErr ErrorIdentifier = 10105
 
 	// JSConsumerFCRequiresPushErr consumer flow control requires a push based consumer
 	JSConsumerFCRequiresPushErr ErrorIdentifier = 10089
 
+	// JSConsumerFilterNoMatchErr consumer filter subject {filter} does not overlap any stream subjects
+	JSConsumerFilterNoMatchErr ErrorIdentifier = 10234
+
 	// JSConsumerFilterNotSubsetErr consumer filter subject is not a valid subset of the interest subjects
 	JSConsumerFilterNotSubsetErr ErrorIdentifier = 10093
 
@@ -200,12 +230,21 @@ const (
 	// JSConsumerInvalidPriorityGroupErr Provided priority group does not exist for this consumer
 	JSConsumerInvalidPriorityGroupErr ErrorIdentifier = 10160
 
+	// JSConsumerInvalidRedeliveryQuietWindowErr consumer redelivery quiet window is invalid: {err}
+	JSConsumerInvalidRedeliveryQuietWindowErr ErrorIdentifier = 10232
+
 	// JSConsumerInvalidResetErr invalid reset: {err}
 	JSConsumerInvalidResetErr ErrorIdentifier = 10204
 
 	// JSConsumerInvalidSamplingErrF failed to parse consumer sampling configuration: {err}
 	JSConsumerInvalidSamplingErrF ErrorIdentifier = 10095
 
+	// JSConsumerMaxAckAgeInvalidErrF consumer max ack age invalid: {err}
+	JSConsumerMaxAckAgeInvalidErrF ErrorIdentifier = 10240
+
+	// JSConsumerMaxAckPendingBelowPendingErrF consumer max ack pending can not be lowered below the current ack pending count of {pending}
+	JSConsumerMaxAckPendingBelowPendingErrF ErrorIdentifier = 10249
+
 	// JSConsumerMaxDeliverBackoffErr max deliver is required to be > length of backoff values
 	JSConsumerMaxDeliverBackoffErr ErrorIdentifier = 10116
 
@@ -239,9 +278,15 @@ const (
 	// JSConsumerNameExistErr consumer name already in use
 	JSConsumerNameExistErr ErrorIdentifier = 10013
 
+	// JSConsumerNamePatternMismatchErrF consumer name {name} does not match the stream consumer name pattern {pattern}
+	JSConsumerNamePatternMismatchErrF ErrorIdentifier = 10243
+
 	// JSConsumerNameTooLongErrF consumer name is too long, maximum allowed is {max}
 	JSConsumerNameTooLongErrF ErrorIdentifier = 10102
 
+	// JSConsumerNoRedeliverSubjectsInvalidErrF consumer no redeliver subjects invalid: {err}
+	JSConsumerNoRedeliverSubjectsInvalidErrF ErrorIdentifier = 10238
+
 	// JSConsumerNotFoundErr consumer not found
 	JSConsumerNotFoundErr ErrorIdentifier = 10014
 
@@ -254,9 +299,15 @@ const (
 	// JSConsumerOnMappedErr consumer direct on a mapped consumer
 	JSConsumerOnMappedErr ErrorIdentifier = 10092
 
+	// JSConsumerOrderByTimestampInvalidErrF consumer order by timestamp invalid: {err}
+	JSConsumerOrderByTimestampInvalidErrF ErrorIdentifier = 10242
+
 	// JSConsumerOverlappingSubjectFilters consumer subject filters cannot overlap
 	JSConsumerOverlappingSubjectFilters ErrorIdentifier = 10138
 
+	// JSConsumerPinStealPolicyInvalidErrF consumer pin steal policy invalid: {err}
+	JSConsumerPinStealPolicyInvalidErrF ErrorIdentifier = 10237
+
 	// JSConsumerPinnedTTLWithoutPriorityPolicyNone PinnedTTL cannot be set when PriorityPolicy is none
 	JSConsumerPinnedTTLWithoutPriorityPolicyNone ErrorIdentifier = 10197
 
@@ -275,9 +326,15 @@ const (
 	// JSConsumerPullWithRateLimitErr consumer in pull mode can not have rate limit set
 	JSConsumerPullWithRateLimitErr ErrorIdentifier = 10086
 
+	// JSConsumerPushDisabledErr push consumers are disabled for the account
+	JSConsumerPushDisabledErr ErrorIdentifier = 10252
+
 	// JSConsumerPushMaxWaitingErr consumer in push mode can not set max waiting
 	JSConsumerPushMaxWaitingErr ErrorIdentifier = 10080
 
+	// JSConsumerPushWaitingRequestIdleTimeoutErr consumer in push mode can not set waiting request idle timeout
+	JSConsumerPushWaitingRequestIdleTimeoutErr ErrorIdentifier = 10229
+
 	// JSConsumerPushWithPriorityGroupErr priority groups can not be used with push consumers
 	JSConsumerPushWithPriorityGroupErr ErrorIdentifier = 10178
 
@@ -287,18 +344,30 @@ const (
 	// JSConsumerReplayPolicyInvalidErr consumer replay policy invalid
 	JSConsumerReplayPolicyInvalidErr ErrorIdentifier = 10182
 
+	// JSConsumerReplaySpeedInvalidErrF consumer replay speed invalid: {err}
+	JSConsumerReplaySpeedInvalidErrF ErrorIdentifier = 10235
+
 	// JSConsumerReplicasExceedsStream consumer config replica count exceeds parent stream
 	JSConsumerReplicasExceedsStream ErrorIdentifier = 10126
 
 	// JSConsumerReplicasShouldMatchStream consumer config replicas must match interest retention stream's replicas
 	JSConsumerReplicasShouldMatchStream ErrorIdentifier = 10134
 
+	// JSConsumerSignalCaughtUpRequiresPushErr consumer signal caught up requires a push based consumer
+	JSConsumerSignalCaughtUpRequiresPushErr ErrorIdentifier = 10231
+
+	// JSConsumerSkipHeadersInvalidErrF consumer skip headers invalid: {err}
+	JSConsumerSkipHeadersInvalidErrF ErrorIdentifier = 10246
+
 	// JSConsumerSmallHeartbeatErr consumer idle heartbeat needs to be >= 100ms
 	JSConsumerSmallHeartbeatErr ErrorIdentifier = 10083
 
 	// JSConsumerStoreFailedErrF error creating store for consumer: {err}
 	JSConsumerStoreFailedErrF ErrorIdentifier = 10104
 
+	// JSConsumerSubjectAckInvalidErr consumer subject ack requires ack policy explicit or all
+	JSConsumerSubjectAckInvalidErr ErrorIdentifier = 10236
+
 	// JSConsumerWQConsumerNotDeliverAllErr consumer must be deliver all on workqueue stream
 	JSConsumerWQConsumerNotDeliverAllErr ErrorIdentifier = 10101
 
@@ -311,6 +380,9 @@ const (
 	// JSConsumerWQRequiresExplicitAckErr workqueue stream requires explicit ack
 	JSConsumerWQRequiresExplicitAckErr ErrorIdentifier = 10098
 
+	// JSConsumerWaitingRequestIdleTimeoutNegativeErr consumer waiting request idle timeout needs to be positive
+	JSConsumerWaitingRequestIdleTimeoutNegativeErr ErrorIdentifier = 10228
+
 	// JSConsumerWithFlowControlNeedsHeartbeats consumer with flow control also needs heartbeats
 	JSConsumerWithFlowControlNeedsHeartbeats ErrorIdentifier = 10108
 
@@ -323,9 +395,15 @@ const (
 	// JSMaximumConsumersLimitErr maximum consumers limit reached
 	JSMaximumConsumersLimitErr ErrorIdentifier = 10026
 
+	// JSMaximumHAAssetsLimitErrF maximum number of replicated assets reached, {limit} limit is {max}
+	JSMaximumHAAssetsLimitErrF ErrorIdentifier = 10225
+
 	// JSMaximumStreamsLimitErr maximum number of streams reached
 	JSMaximumStreamsLimitErr ErrorIdentifier = 10027
 
+	// JSMemoryLowErr insufficient free memory for memory storage
+	JSMemoryLowErr ErrorIdentifier = 10248
+
 	// JSMemoryResourcesExceededErr insufficient memory resources available
 	JSMemoryResourcesExceededErr ErrorIdentifier = 10028
 
@@ -437,6 +515,9 @@ const (
 	// JSNoMessageFoundErr no message found
 	JSNoMessageFoundErr ErrorIdentifier = 10037
 
+	// JSNotAllowedForAccountErr JetStream not allowed for account
+	JSNotAllowedForAccountErr ErrorIdentifier = 10241
+
 	// JSNotEmptyRequestErr expected an empty request payload
 	JSNotEmptyRequestErr ErrorIdentifier = 10038
 
@@ -617,6 +698,9 @@ const (
 	// JSStreamRestoreErrF restore failed: {err}
 	JSStreamRestoreErrF ErrorIdentifier = 10062
 
+	// JSStreamRestoreTooManyConcurrentErr too many concurrent restores
+	JSStreamRestoreTooManyConcurrentErr ErrorIdentifier = 10227
+
 	// JSStreamRollupFailedF Generic stream rollup failure error string ({err})
 	JSStreamRollupFailedF ErrorIdentifier = 10111
 
@@ -629,6 +713,9 @@ const (
 	// JSStreamSnapshotErrF snapshot failed: {err}
 	JSStreamSnapshotErrF ErrorIdentifier = 10064
 
+	// JSStreamSnapshotTooManyConcurrentErr too many concurrent snapshots
+	JSStreamSnapshotTooManyConcurrentErr ErrorIdentifier = 10226
+
 	// JSStreamStoreFailedF Generic error when storing a message failed ({err})
 	JSStreamStoreFailedF ErrorIdentifier = 10077
 
@@ -674,228 +761,257 @@ const (
 
 var (
 	ApiErrors = map[ErrorIdentifier]*ApiError{
-		JSAccountResourcesExceededErr:                {Code: 400, ErrCode: 10002, Description: "resource limits exceeded for account"},
-		JSAtomicPublishContainsDuplicateMessageErr:   {Code: 400, ErrCode: 10201, Description: "atomic publish batch contains duplicate message id"},
-		JSAtomicPublishDisabledErr:                   {Code: 400, ErrCode: 10174, Description: "atomic publish is disabled"},
-		JSAtomicPublishIncompleteBatchErr:            {Code: 400, ErrCode: 10176, Description: "atomic publish batch is incomplete"},
-		JSAtomicPublishInvalidBatchCommitErr:         {Code: 400, ErrCode: 10200, Description: "atomic publish batch commit is invalid"},
-		JSAtomicPublishInvalidBatchIDErr:             {Code: 400, ErrCode: 10179, Description: "atomic publish batch ID is invalid"},
-		JSAtomicPublishMissingSeqErr:                 {Code: 400, ErrCode: 10175, Description: "atomic publish sequence is missing"},
-		JSAtomicPublishTooLargeBatchErrF:             {Code: 400, ErrCode: 10199, Description: "atomic publish batch is too large: {size}"},
-		JSAtomicPublishTooManyInflight:               {Code: 429, ErrCode: 10210, Description: "atomic publish too many inflight"},
-		JSAtomicPublishUnsupportedHeaderBatchErr:     {Code: 400, ErrCode: 10177, Description: "atomic publish unsupported header used: {header}"},
-		JSBadRequestErr:                              {Code: 400, ErrCode: 10003, Description: "bad request"},
-		JSBatchPublishDisabledErr:                    {Code: 400, ErrCode: 10205, Description: "batch publish is disabled"},
-		JSBatchPublishInvalidBatchIDErr:              {Code: 400, ErrCode: 10207, Description: "batch publish ID is invalid"},
-		JSBatchPublishInvalidPatternErr:              {Code: 400, ErrCode: 10206, Description: "batch publish pattern is invalid"},
-		JSBatchPublishTooManyInflight:                {Code: 429, ErrCode: 10211, Description: "batch publish too many inflight"},
-		JSBatchPublishUnknownBatchIDErr:              {Code: 400, ErrCode: 10208, Description: "batch publish ID unknown"},
-		JSClusterIncompleteErr:                       {Code: 503, ErrCode: 10004, Description: "incomplete results"},
-		JSClusterNoPeersErrF:                         {Code: 400, ErrCode: 10005, Description: "{err}"},
-		JSClusterNotActiveErr:                        {Code: 500, ErrCode: 10006, Description: "JetStream not in clustered mode"},
-		JSClusterNotAssignedErr:                      {Code: 500, ErrCode: 10007, Description: "JetStream cluster not assigned to this server"},
-		JSClusterNotAvailErr:                         {Code: 503, ErrCode: 10008, Description: "JetStream system temporarily unavailable"},
-		JSClusterNotLeaderErr:                        {Code: 500, ErrCode: 10009, Description: "JetStream cluster can not handle request"},
-		JSClusterPeerNotMemberErr:                    {Code: 400, ErrCode: 10040, Description: "peer not a member"},
-		JSClusterRequiredErr:                         {Code: 503, ErrCode: 10010, Description: "JetStream clustering support required"},
-		JSClusterServerMemberChangeInflightErr:       {Code: 400, ErrCode: 10202, Description: "cluster member change is in progress"},
-		JSClusterServerNotMemberErr:                  {Code: 400, ErrCode: 10044, Description: "server is not a member of the cluster"},
-		JSClusterTagsErr:                             {Code: 400, ErrCode: 10011, Description: "tags placement not supported for operation"},
-		JSClusterUnSupportFeatureErr:                 {Code: 503, ErrCode: 10036, Description: "not currently supported in clustered mode"},
-		JSConsumerAckFCRequiresFCErr:                 {Code: 400, ErrCode: 10219, Description: "flow control ack policy requires flow control"},
-		JSConsumerAckFCRequiresMaxAckPendingErr:      {Code: 400, ErrCode: 10220, Description: "flow control ack policy requires max ack pending"},
-		JSConsumerAckFCRequiresNoAckWaitErr:          {Code: 400, ErrCode: 10221, Description: "flow control ack policy requires unset ack wait"},
-		JSConsumerAckFCRequiresNoMaxDeliverErr:       {Code: 400, ErrCode: 10222, Description: "flow control ack policy requires unset max deliver"},
-		JSConsumerAckFCRequiresPushErr:               {Code: 400, ErrCode: 10218, Description: "flow control ack policy requires a push based consumer"},
-		JSConsumerAckPolicyInvalidErr:                {Code: 400, ErrCode: 10181, Description: "consumer ack policy invalid"},
-		JSConsumerAckWaitNegativeErr:                 {Code: 400, ErrCode: 10183, Description: "consumer ack wait needs to be positive"},
-		JSConsumerAlreadyExists:                      {Code: 400, ErrCode: 10148, Description: "consumer already exists"},
-		JSConsumerBackOffNegativeErr:                 {Code: 400, ErrCode: 10184, Description: "consumer backoff needs to be positive"},
-		JSConsumerBadDurableNameErr:                  {Code: 400, ErrCode: 10103, Description: "durable name can not contain '.', '*', '>'"},
-		JSConsumerConfigRequiredErr:                  {Code: 400, ErrCode: 10078, Description: "consumer config required"},
-		JSConsumerCreateDurableAndNameMismatch:       {Code: 400, ErrCode: 10132, Description: "Consumer Durable and Name have to be equal if both are provided"},
-		JSConsumerCreateErrF:                         {Code: 500, ErrCode: 10012, Description: "{err}"},
-		JSConsumerCreateFilterSubjectMismatchErr:     {Code: 400, ErrCode: 10131, Description: "Consumer create request did not match filtered subject from create subject"},
-		JSConsumerDeliverCycleErr:                    {Code: 400, ErrCode: 10081, Description: "consumer deliver subject forms a cycle"},
-		JSConsumerDeliverToWildcardsErr:              {Code: 400, ErrCode: 10079, Description: "consumer deliver subject has wildcards"},
-		JSConsumerDescriptionTooLongErrF:             {Code: 400, ErrCode: 10107, Description: "consumer description is too long, maximum allowed is {max}"},
-		JSConsumerDirectRequiresEphemeralErr:         {Code: 400, ErrCode: 10091, Description: "consumer direct requires an ephemeral consumer"},
-		JSConsumerDirectRequiresPushErr:              {Code: 400, ErrCode: 10090, Description: "consumer direct requires a push based consumer"},
-		JSConsumerDoesNotExist:                       {Code: 400, ErrCode: 10149, Description: "consumer does not exist"},
-		JSConsumerDuplicateFilterSubjects:            {Code: 400, ErrCode: 10136, Description: "consumer cannot have both FilterSubject and FilterSubjects specified"},
-		JSConsumerDurableNameNotInSubjectErr:         {Code: 400, ErrCode: 10016, Description: "consumer expected to be durable but no durable name set in subject"},
-		JSConsumerDurableNameNotMatchSubjectErr:      {Code: 400, ErrCode: 10017, Description: "consumer name in subject does not match durable name in request"},
-		JSConsumerDurableNameNotSetErr:               {Code: 400, ErrCode: 10018, Description: "consumer expected to be durable but a durable name was not set"},
-		JSConsumerEmptyFilter:                        {Code: 400, ErrCode: 10139, Description: "consumer filter in FilterSubjects cannot be empty"},
-		JSConsumerEmptyGroupName:                     {Code: 400, ErrCode: 10161, Description: "Group name cannot be an empty string"},
-		JSConsumerEphemeralWithDurableInSubjectErr:   {Code: 400, ErrCode: 10019, Description: "consumer expected to be ephemeral but detected a durable name set in subject"},
-		JSConsumerEphemeralWithDurableNameErr:        {Code: 400, ErrCode: 10020, Description: "consumer expected to be ephemeral but a durable name was set in request"},
-		JSConsumerExistingActiveErr:                  {Code: 400, ErrCode: 10105, Description: "consumer already exists and is still active"},
-		JSConsumerFCRequiresPushErr:                  {Code: 400, ErrCode: 10089, Description: "consumer flow control requires a push based consumer"},
-		JSConsumerFilterNotSubsetErr:                 {Code: 400, ErrCode: 10093, Description: "consumer filter subject is not a valid subset of the interest subjects"},
-		JSConsumerHBRequiresPushErr:                  {Code: 400, ErrCode: 10088, Description: "consumer idle heartbeat requires a push based consumer"},
-		JSConsumerInactiveThresholdExcess:            {Code: 400, ErrCode: 10153, Description: "consumer inactive threshold exceeds system limit of {limit}"},
-		JSConsumerInvalidDeliverSubject:              {Code: 400, ErrCode: 10112, Description: "invalid push consumer deliver subject"},
-		JSConsumerInvalidGroupNameErr:                {Code: 400, ErrCode: 10162, Description: "Valid priority group name must match A-Z, a-z, 0-9, -_/=)+ and may not exceed 16 characters"},
-		JSConsumerInvalidPolicyErrF:                  {Code: 400, ErrCode: 10094, Description: "{err}"},
-		JSConsumerInvalidPriorityGroupErr:            {Code: 400, ErrCode: 10160, Description: "Provided priority group does not exist for this consumer"},
-		JSConsumerInvalidResetErr:                    {Code: 400, ErrCode: 10204, Description: "invalid reset: {err}"},
-		JSConsumerInvalidSamplingErrF:                {Code: 400, ErrCode: 10095, Description: "failed to parse consumer sampling configuration: {err}"},
-		JSConsumerMaxDeliverBackoffErr:               {Code: 400, ErrCode: 10116, Description: "max deliver is required to be > length of backoff values"},
-		JSConsumerMaxPendingAckExcessErrF:            {Code: 400, ErrCode: 10121, Description: "consumer max ack pending exceeds system limit of {limit}"},
-		JSConsumerMaxPendingAckPolicyRequiredErr:     {Code: 400, ErrCode: 10082, Description: "consumer requires ack policy for max ack pending"},
-		JSConsumerMaxRequestBatchExceededF:           {Code: 400, ErrCode: 10125, Description: "consumer max request batch exceeds server limit of {limit}"},
-		JSConsumerMaxRequestBatchNegativeErr:         {Code: 400, ErrCode: 10114, Description: "consumer max request batch needs to be > 0"},
-		JSConsumerMaxRequestExpiresTooSmall:          {Code: 400, ErrCode: 10115, Description: "consumer max request expires needs to be >= 1ms"},
-		JSConsumerMaxWaitingNegativeErr:              {Code: 400, ErrCode: 10087, Description: "consumer max waiting needs to be positive"},
-		JSConsumerMetadataLengthErrF:                 {Code: 400, ErrCode: 10135, Description: "consumer metadata exceeds maximum size of {limit}"},
-		JSConsumerMultipleFiltersNotAllowed:          {Code: 400, ErrCode: 10137, Description: "consumer with multiple subject filters cannot use subject based API"},
-		JSConsumerNameContainsPathSeparatorsErr:      {Code: 400, ErrCode: 10127, Description: "Consumer name can not contain path separators"},
-		JSConsumerNameExistErr:                       {Code: 400, ErrCode: 10013, Description: "consumer name already in use"},
-		JSConsumerNameTooLongErrF:                    {Code: 400, ErrCode: 10102, Description: "consumer name is too long, maximum allowed is {max}"},
-		JSConsumerNotFoundErr:                        {Code: 404, ErrCode: 10014, Description: "consumer not found"},
-		JSConsumerOfflineErr:                         {Code: 500, ErrCode: 10119, Description: "consumer is offline"},
-		JSConsumerOfflineReasonErrF:                  {Code: 500, ErrCode: 10195, Description: "consumer is offline: {err}"},
-		JSConsumerOnMappedErr:                        {Code: 400, ErrCode: 10092, Description: "consumer direct on a mapped consumer"},
-		JSConsumerOverlappingSubjectFilters:          {Code: 400, ErrCode: 10138, Description: "consumer subject filters cannot overlap"},
-		JSConsumerPinnedTTLWithoutPriorityPolicyNone: {Code: 400, ErrCode: 10197, Description: "PinnedTTL cannot be set when PriorityPolicy is none"},
-		JSConsumerPriorityGroupWithPolicyNone:        {Code: 400, ErrCode: 10196, Description: "consumer can not have priority groups when policy is none"},
-		JSConsumerPriorityPolicyWithoutGroup:         {Code: 400, ErrCode: 10159, Description: "Setting PriorityPolicy requires at least one PriorityGroup to be set"},
-		JSConsumerPullNotDurableErr:                  {Code: 400, ErrCode: 10085, Description: "consumer in pull mode requires a durable name"},
-		JSConsumerPullRequiresAckErr:                 {Code: 400, ErrCode: 10084, Description: "consumer in pull mode requires explicit ack policy on workqueue stream"},
-		JSConsumerPullWithRateLimitErr:               {Code: 400, ErrCode: 10086, Description: "consumer in pull mode can not have rate limit set"},
-		JSConsumerPushMaxWaitingErr:                  {Code: 400, ErrCode: 10080, Description: "consumer in push mode can not set max waiting"},
-		JSConsumerPushWithPriorityGroupErr:           {Code: 400, ErrCode: 10178, Description: "priority groups can not be used with push consumers"},
-		JSConsumerReplacementWithDifferentNameErr:    {Code: 400, ErrCode: 10106, Description: "consumer replacement durable config not the same"},
-		JSConsumerReplayPolicyInvalidErr:             {Code: 400, ErrCode: 10182, Description: "consumer replay policy invalid"},
-		JSConsumerReplicasExceedsStream:              {Code: 400, ErrCode: 10126, Description: "consumer config replica count exceeds parent stream"},
-		JSConsumerReplicasShouldMatchStream:          {Code: 400, ErrCode: 10134, Description: "consumer config replicas must match interest retention stream's replicas"},
-		JSConsumerSmallHeartbeatErr:                  {Code: 400, ErrCode: 10083, Description: "consumer idle heartbeat needs to be >= 100ms"},
-		JSConsumerStoreFailedErrF:                    {Code: 500, ErrCode: 10104, Description: "error creating store for consumer: {err}"},
-		JSConsumerWQConsumerNotDeliverAllErr:         {Code: 400, ErrCode: 10101, Description: "consumer must be deliver all on workqueue stream"},
-		JSConsumerWQConsumerNotUniqueErr:             {Code: 400, ErrCode: 10100, Description: "filtered consumer not unique on workqueue stream"},
-		JSConsumerWQMultipleUnfilteredErr:            {Code: 400, ErrCode: 10099, Description: "multiple non-filtered consumers not allowed on workqueue stream"},
-		JSConsumerWQRequiresExplicitAckErr:           {Code: 400, ErrCode: 10098, Description: "workqueue stream requires explicit ack"},
-		JSConsumerWithFlowControlNeedsHeartbeats:     {Code: 400, ErrCode: 10108, Description: "consumer with flow control also needs heartbeats"},
-		JSInsufficientResourcesErr:                   {Code: 503, ErrCode: 10023, Description: "insufficient resources"},
-		JSInvalidJSONErr:                             {Code: 400, ErrCode: 10025, Description: "invalid JSON: {err}"},
-		JSMaximumConsumersLimitErr:                   {Code: 400, ErrCode: 10026, Description: "maximum consumers limit reached"},
-		JSMaximumStreamsLimitErr:                     {Code: 400, ErrCode: 10027, Description: "maximum number of streams reached"},
-		JSMemoryResourcesExceededErr:                 {Code: 500, ErrCode: 10028, Description: "insufficient memory resources available"},
-		JSMessageCounterBrokenErr:                    {Code: 400, ErrCode: 10172, Description: "message counter is broken"},
-		JSMessageIncrDisabledErr:                     {Code: 400, ErrCode: 10168, Description: "message counters is disabled"},
-		JSMessageIncrInvalidErr:                      {Code: 400, ErrCode: 10171, Description: "message counter increment is invalid"},
-		JSMessageIncrMissingErr:                      {Code: 400, ErrCode: 10169, Description: "message counter increment is missing"},
-		JSMessageIncrPayloadErr:                      {Code: 400, ErrCode: 10170, Description: "message counter has payload"},
-		JSMessageSchedulesDisabledErr:                {Code: 400, ErrCode: 10188, Description: "message schedules is disabled"},
-		JSMessageSchedulesPatternInvalidErr:          {Code: 400, ErrCode: 10189, Description: "message schedules pattern is invalid"},
-		JSMessageSchedulesRollupInvalidErr:           {Code: 400, ErrCode: 10192, Description: "message schedules invalid rollup"},
-		JSMessageSchedulesSchedulerInvalidErr:        {Code: 400, ErrCode: 10212, Description: "message schedules invalid scheduler"},
-		JSMessageSchedulesSourceInvalidErr:           {Code: 400, ErrCode: 10203, Description: "message schedules source is invalid"},
-		JSMessageSchedulesTTLInvalidErr:              {Code: 400, ErrCode: 10191, Description: "message schedules invalid per-message TTL"},
-		JSMessageSchedulesTargetInvalidErr:           {Code: 400, ErrCode: 10190, Description: "message schedules target is invalid"},
-		JSMessageSchedulesTimeZoneInvalidErr:         {Code: 400, ErrCode: 10223, Description: "message schedules time zone is invalid"},
-		JSMessageTTLDisabledErr:                      {Code: 400, ErrCode: 10166, Description: "per-message TTL is disabled"},
-		JSMessageTTLInvalidErr:                       {Code: 400, ErrCode: 10165, Description: "invalid per-message TTL"},
-		JSMirrorConsumerRequiresAckFCErr:             {Code: 400, ErrCode: 10214, Description: "stream mirror consumer requires flow control ack policy"},
-		JSMirrorConsumerSetupFailedErrF:              {Code: 500, ErrCode: 10029, Description: "{err}"},
-		JSMirrorDurableConsumerCfgInvalid:            {Code: 400, ErrCode: 10213, Description: "stream mirror consumer config is invalid"},
-		JSMirrorInvalidStreamName:                    {Code: 400, ErrCode: 10142, Description: "mirrored stream name is invalid"},
-		JSMirrorInvalidSubjectFilter:                 {Code: 400, ErrCode: 10151, Description: "mirror transform source: {err}"},
-		JSMirrorInvalidTransformDestination:          {Code: 400, ErrCode: 10154, Description: "mirror transform: {err}"},
-		JSMirrorMaxMessageSizeTooBigErr:              {Code: 400, ErrCode: 10030, Description: "stream mirror must have max message size >= source"},
-		JSMirrorMultipleFiltersNotAllowed:            {Code: 400, ErrCode: 10150, Description: "mirror with multiple subject transforms cannot also have a single subject filter"},
-		JSMirrorOverlappingSubjectFilters:            {Code: 400, ErrCode: 10152, Description: "mirror subject filters can not overlap"},
-		JSMirrorWithAtomicPublishErr:                 {Code: 400, ErrCode: 10198, Description: "stream mirrors can not also use atomic publishing"},
-		JSMirrorWithBatchPublishErr:                  {Code: 400, ErrCode: 10209, Description: "stream mirrors can not also use batch publishing"},
-		JSMirrorWithCountersErr:                      {Code: 400, ErrCode: 10173, Description: "stream mirrors can not also calculate counters"},
-		JSMirrorWithFirstSeqErr:                      {Code: 400, ErrCode: 10143, Description: "stream mirrors can not have first sequence configured"},
-		JSMirrorWithMsgSchedulesErr:                  {Code: 400, ErrCode: 10186, Description: "stream mirrors can not also schedule messages"},
-		JSMirrorWithSourcesErr:                       {Code: 400, ErrCode: 10031, Description: "stream mirrors can not also contain other sources"},
-		JSMirrorWithStartSeqAndTimeErr:               {Code: 400, ErrCode: 10032, Description: "stream mirrors can not have both start seq and start time configured"},
-		JSMirrorWithSubjectFiltersErr:                {Code: 400, ErrCode: 10033, Description: "stream mirrors can not contain filtered subjects"},
-		JSMirrorWithSubjectsErr:                      {Code: 400, ErrCode: 10034, Description: "stream mirrors can not contain subjects"},
-		JSNoAccountErr:                               {Code: 503, ErrCode: 10035, Description: "account not found"},
-		JSNoLimitsErr:                                {Code: 400, ErrCode: 10120, Description: "no JetStream default or applicable tiered limit present"},
-		JSNoMessageFoundErr:                          {Code: 404, ErrCode: 10037, Description: "no message found"},
-		JSNotEmptyRequestErr:                         {Code: 400, ErrCode: 10038, Description: "expected an empty request payload"},
-		JSNotEnabledErr:                              {Code: 503, ErrCode: 10076, Description: "JetStream not enabled"},
-		JSNotEnabledForAccountErr:                    {Code: 503, ErrCode: 10039, Description: "JetStream not enabled for account"},
-		JSPedanticErrF:                               {Code: 400, ErrCode: 10157, Description: "pedantic mode: {err}"},
-		JSPeerRemapErr:                               {Code: 503, ErrCode: 10075, Description: "peer remap failed"},
-		JSRaftGeneralErrF:                            {Code: 500, ErrCode: 10041, Description: "{err}"},
-		JSReplicasCountCannotBeNegative:              {Code: 400, ErrCode: 10133, Description: "replicas count cannot be negative"},
-		JSRequiredApiLevelErr:                        {Code: 412, ErrCode: 10185, Description: "JetStream minimum api level required"},
-		JSRestoreSubscribeFailedErrF:                 {Code: 500, ErrCode: 10042, Description: "JetStream unable to subscribe to restore snapshot {subject}: {err}"},
-		JSSequenceNotFoundErrF:                       {Code: 400, ErrCode: 10043, Description: "sequence {seq} not found"},
-		JSSnapshotDeliverSubjectInvalidErr:           {Code: 400, ErrCode: 10015, Description: "deliver subject not valid"},
-		JSSourceConsumerRequiresAckFCErr:             {Code: 400, ErrCode: 10217, Description: "stream source consumer requires flow control ack policy"},
-		JSSourceConsumerSetupFailedErrF:              {Code: 500, ErrCode: 10045, Description: "{err}"},
-		JSSourceDuplicateDetected:                    {Code: 400, ErrCode: 10140, Description: "duplicate source configuration detected"},
-		JSSourceDurableConsumerCfgInvalid:            {Code: 400, ErrCode: 10215, Description: "stream source consumer config is invalid"},
-		JSSourceDurableConsumerDuplicateDetected:     {Code: 400, ErrCode: 10216, Description: "duplicate stream source consumer detected"},
-		JSSourceInvalidStreamName:                    {Code: 400, ErrCode: 10141, Description: "sourced stream name is invalid"},
-		JSSourceInvalidSubjectFilter:                 {Code: 400, ErrCode: 10145, Description: "source transform source: {err}"},
-		JSSourceInvalidTransformDestination:          {Code: 400, ErrCode: 10146, Description: "source transform: {err}"},
-		JSSourceMaxMessageSizeTooBigErr:              {Code: 400, ErrCode: 10046, Description: "stream source must have max message size >= target"},
-		JSSourceMultipleFiltersNotAllowed:            {Code: 400, ErrCode: 10144, Description: "source with multiple subject transforms cannot also have a single subject filter"},
-		JSSourceOverlappingSubjectFilters:            {Code: 400, ErrCode: 10147, Description: "source filters can not overlap"},
-		JSSourceWithMsgSchedulesErr:                  {Code: 400, ErrCode: 10187, Description: "stream source can not also schedule messages"},
-		JSStorageResourcesExceededErr:                {Code: 500, ErrCode: 10047, Description: "insufficient storage resources available"},
-		JSStreamAssignmentErrF:                       {Code: 500, ErrCode: 10048, Description: "{err}"},
-		JSStreamCreateErrF:                           {Code: 500, ErrCode: 10049, Description: "{err}"},
-		JSStreamDeleteErrF:                           {Code: 500, ErrCode: 10050, Description: "{err}"},
-		JSStreamDuplicateMessageConflict:             {Code: 409, ErrCode: 10158, Description: "duplicate message id is in process"},
-		JSStreamExpectedLastSeqPerSubjectInvalid:     {Code: 400, ErrCode: 10193, Description: "missing sequence for expected last sequence per subject"},
-		JSStreamExpectedLastSeqPerSubjectNotReady:    {Code: 503, ErrCode: 10163, Description: "expected last sequence per subject temporarily unavailable"},
-		JSStreamExternalApiOverlapErrF:               {Code: 400, ErrCode: 10021, Description: "stream external api prefix {prefix} must not overlap with {subject}"},
-		JSStreamExternalDelPrefixOverlapsErrF:        {Code: 400, ErrCode: 10022, Description: "stream external delivery prefix {prefix} overlaps with stream subject {subject}"},
-		JSStreamGeneralErrorF:                        {Code: 500, ErrCode: 10051, Description: "{err}"},
-		JSStreamHeaderExceedsMaximumErr:              {Code: 400, ErrCode: 10097, Description: "header size exceeds maximum allowed of 64k"},
-		JSStreamInfoMaxSubjectsErr:                   {Code: 500, ErrCode: 10117, Description: "subject details would exceed maximum allowed"},
-		JSStreamInvalidConfigF:                       {Code: 500, ErrCode: 10052, Description: "{err}"},
-		JSStreamInvalidErr:                           {Code: 500, ErrCode: 10096, Description: "stream not valid"},
-		JSStreamInvalidExternalDeliverySubjErrF:      {Code: 400, ErrCode: 10024, Description: "stream external delivery prefix {prefix} must not contain wildcards"},
-		JSStreamLimitsErrF:                           {Code: 500, ErrCode: 10053, Description: "{err}"},
-		JSStreamMaxBytesRequired:                     {Code: 400, ErrCode: 10113, Description: "account requires a stream config to have max bytes set"},
-		JSStreamMaxStreamBytesExceeded:               {Code: 400, ErrCode: 10122, Description: "stream max bytes exceeds account limit max stream bytes"},
-		JSStreamMessageExceedsMaximumErr:             {Code: 400, ErrCode: 10054, Description: "message size exceeds maximum allowed"},
-		JSStreamMinLastSeqErr:                        {Code: 412, ErrCode: 10180, Description: "min last sequence"},
-		JSStreamMirrorNotUpdatableErr:                {Code: 400, ErrCode: 10055, Description: "stream mirror configuration can not be updated"},
-		JSStreamMismatchErr:                          {Code: 400, ErrCode: 10056, Description: "stream name in subject does not match request"},
-		JSStreamMoveAndScaleErr:                      {Code: 400, ErrCode: 10123, Description: "can not move and scale a stream in a single update"},
-		JSStreamMoveInProgressF:                      {Code: 400, ErrCode: 10124, Description: "stream move already in progress: {msg}"},
-		JSStreamMoveNotInProgress:                    {Code: 400, ErrCode: 10129, Description: "stream move not in progress"},
-		JSStreamMsgDeleteFailedF:                     {Code: 500, ErrCode: 10057, Description: "{err}"},
-		JSStreamNameContainsPathSeparatorsErr:        {Code: 400, ErrCode: 10128, Description: "Stream name can not contain path separators"},
-		JSStreamNameExistErr:                         {Code: 400, ErrCode: 10058, Description: "stream name already in use with a different configuration"},
-		JSStreamNameExistRestoreFailedErr:            {Code: 400, ErrCode: 10130, Description: "stream name already in use, cannot restore"},
-		JSStreamNotFoundErr:                          {Code: 404, ErrCode: 10059, Description: "stream not found"},
-		JSStreamNotMatchErr:                          {Code: 400, ErrCode: 10060, Description: "expected stream does not match"},
-		JSStreamOfflineErr:                           {Code: 500, ErrCode: 10118, Description: "stream is offline"},
-		JSStreamOfflineReasonErrF:                    {Code: 500, ErrCode: 10194, Description: "stream is offline: {err}"},
-		JSStreamPurgeFailedF:                         {Code: 500, ErrCode: 10110, Description: "{err}"},
-		JSStreamReplicasNotSupportedErr:              {Code: 500, ErrCode: 10074, Description: "replicas > 1 not supported in non-clustered mode"},
-		JSStreamReplicasNotUpdatableErr:              {Code: 400, ErrCode: 10061, Description: "Replicas configuration can not be updated"},
-		JSStreamRestoreErrF:                          {Code: 500, ErrCode: 10062, Description: "restore failed: {err}"},
-		JSStreamRollupFailedF:                        {Code: 500, ErrCode: 10111, Description: "{err}"},
-		JSStreamSealedErr:                            {Code: 400, ErrCode: 10109, Description: "invalid operation on sealed stream"},
-		JSStreamSequenceNotMatchErr:                  {Code: 503, ErrCode: 10063, Description: "expected stream sequence does not match"},
-		JSStreamSnapshotErrF:                         {Code: 500, ErrCode: 10064, Description: "snapshot failed: {err}"},
-		JSStreamStoreFailedF:                         {Code: 503, ErrCode: 10077, Description: "{err}"},
-		JSStreamSubjectOverlapErr:                    {Code: 400, ErrCode: 10065, Description: "subjects overlap with an existing stream"},
-		JSStreamTemplateCreateErrF:                   {Code: 500, ErrCode: 10066, Description: "{err}"},
-		JSStreamTemplateDeleteErrF:                   {Code: 500, ErrCode: 10067, Description: "{err}"},
-		JSStreamTemplateNotFoundErr:                  {Code: 404, ErrCode: 10068, Description: "template not found"},
-		JSStreamTooManyRequests:                      {Code: 429, ErrCode: 10167, Description: "too many requests"},
-		JSStreamTransformInvalidDestination:          {Code: 400, ErrCode: 10156, Description: "stream transform: {err}"},
-		JSStreamTransformInvalidSource:               {Code: 400, ErrCode: 10155, Description: "stream transform source: {err}"},
-		JSStreamUpdateErrF:                           {Code: 500, ErrCode: 10069, Description: "{err}"},
-		JSStreamWrongLastMsgIDErrF:                   {Code: 400, ErrCode: 10070, Description: "wrong last msg ID: {id}"},
-		JSStreamWrongLastSequenceConstantErr:         {Code: 400, ErrCode: 10164, Description: "wrong last sequence"},
-		JSStreamWrongLastSequenceErrF:                {Code: 400, ErrCode: 10071, Description: "wrong last sequence: {seq}"},
-		JSTempStorageFailedErr:                       {Code: 500, ErrCode: 10072, Description: "JetStream unable to open temp storage for restore"},
-		JSTemplateNameNotMatchSubjectErr:             {Code: 400, ErrCode: 10073, Description: "template name in subject does not match request"},
+		JSAccountResourcesExceededErr:                  {Code: 400, ErrCode: 10002, Description: "resource limits exceeded for account"},
+		JSAtomicPublishContainsDuplicateMessageErr:     {Code: 400, ErrCode: 10201, Description: "atomic publish batch contains duplicate message id"},
+		JSAtomicPublishDisabledErr:                     {Code: 400, ErrCode: 10174, Description: "atomic publish is disabled"},
+		JSAtomicPublishIncompleteBatchErr:              {Code: 400, ErrCode: 10176, Description: "atomic publish batch is incomplete"},
+		JSAtomicPublishInvalidBatchCommitErr:           {Code: 400, ErrCode: 10200, Description: "atomic publish batch commit is invalid"},
+		JSAtomicPublishInvalidBatchIDErr:               {Code: 400, ErrCode: 10179, Description: "atomic publish batch ID is invalid"},
+		JSAtomicPublishMissingSeqErr:                   {Code: 400, ErrCode: 10175, Description: "atomic publish sequence is missing"},
+		JSAtomicPublishTooLargeBatchErrF:               {Code: 400, ErrCode: 10199, Description: "atomic publish batch is too large: {size}"},
+		JSAtomicPublishTooManyInflight:                 {Code: 429, ErrCode: 10210, Description: "atomic publish too many inflight"},
+		JSAtomicPublishUnsupportedHeaderBatchErr:       {Code: 400, ErrCode: 10177, Description: "atomic publish unsupported header used: {header}"},
+		JSBadRequestErr:                                {Code: 400, ErrCode: 10003, Description: "bad request"},
+		JSBatchPublishDisabledErr:                      {Code: 400, ErrCode: 10205, Description: "batch publish is disabled"},
+		JSBatchPublishInvalidBatchIDErr:                {Code: 400, ErrCode: 10207, Description: "batch publish ID is invalid"},
+		JSBatchPublishInvalidPatternErr:                {Code: 400, ErrCode: 10206, Description: "batch publish pattern is invalid"},
+		JSBatchPublishTooManyInflight:                  {Code: 429, ErrCode: 10211, Description: "batch publish too many inflight"},
+		JSBatchPublishUnknownBatchIDErr:                {Code: 400, ErrCode: 10208, Description: "batch publish ID unknown"},
+		JSClusterIncompleteErr:                         {Code: 503, ErrCode: 10004, Description: "incomplete results"},
+		JSClusterNoPeersErrF:                           {Code: 400, ErrCode: 10005, Description: "{err}"},
+		JSClusterNotActiveErr:                          {Code: 500, ErrCode: 10006, Description: "JetStream not in clustered mode"},
+		JSClusterNotAssignedErr:                        {Code: 500, ErrCode: 10007, Description: "JetStream cluster not assigned to this server"},
+		JSClusterNotAvailErr:                           {Code: 503, ErrCode: 10008, Description: "JetStream system temporarily unavailable"},
+		JSClusterNotLeaderErr:                          {Code: 500, ErrCode: 10009, Description: "JetStream cluster can not handle request"},
+		JSClusterPeerNotMemberErr:                      {Code: 400, ErrCode: 10040, Description: "peer not a member"},
+		JSClusterRequiredErr:                           {Code: 503, ErrCode: 10010, Description: "JetStream clustering support required"},
+		JSClusterServerMemberChangeInflightErr:         {Code: 400, ErrCode: 10202, Description: "cluster member change is in progress"},
+		JSClusterServerNotMemberErr:                    {Code: 400, ErrCode: 10044, Description: "server is not a member of the cluster"},
+		JSClusterTagsErr:                               {Code: 400, ErrCode: 10011, Description: "tags placement not supported for operation"},
+		JSClusterUnSupportFeatureErr:                   {Code: 503, ErrCode: 10036, Description: "not currently supported in clustered mode"},
+		JSConsumerAckBatchInvalidErrF:                  {Code: 400, ErrCode: 10239, Description: "consumer ack batch invalid: {err}"},
+		JSConsumerAckFCRequiresFCErr:                   {Code: 400, ErrCode: 10219, Description: "flow control ack policy requires flow control"},
+		JSConsumerAckFCRequiresMaxAckPendingErr:        {Code: 400, ErrCode: 10220, Description: "flow control ack policy requires max ack pending"},
+		JSConsumerAckFCRequiresNoAckWaitErr:            {Code: 400, ErrCode: 10221, Description: "flow control ack policy requires unset ack wait"},
+		JSConsumerAckFCRequiresNoMaxDeliverErr:         {Code: 400, ErrCode: 10222, Description: "flow control ack policy requires unset max deliver"},
+		JSConsumerAckFCRequiresPushErr:                 {Code: 400, ErrCode: 10218, Description: "flow control ack policy requires a push based consumer"},
+		JSConsumerAckPolicyInvalidErr:                  {Code: 400, ErrCode: 10181, Description: "consumer ack policy invalid"},
+		JSConsumerAckSameConnectionInvalidErrF:         {Code: 400, ErrCode: 10247, Description: "consumer ack same connection invalid: {err}"},
+		JSConsumerAckWaitNegativeErr:                   {Code: 400, ErrCode: 10183, Description: "consumer ack wait needs to be positive"},
+		JSConsumerAckWaitPerFilterInvalidErrF:          {Code: 400, ErrCode: 10230, Description: "consumer ack wait per filter invalid: {err}"},
+		JSConsumerAlreadyExists:                        {Code: 400, ErrCode: 10148, Description: "consumer already exists"},
+		JSConsumerBackOffNegativeErr:                   {Code: 400, ErrCode: 10184, Description: "consumer backoff needs to be positive"},
+		JSConsumerBadDurableNameErr:                    {Code: 400, ErrCode: 10103, Description: "durable name can not contain '.', '*', '>'"},
+		JSConsumerConfigRequiredErr:                    {Code: 400, ErrCode: 10078, Description: "consumer config required"},
+		JSConsumerCreateDurableAndNameMismatch:         {Code: 400, ErrCode: 10132, Description: "Consumer Durable and Name have to be equal if both are provided"},
+		JSConsumerCreateErrF:                           {Code: 500, ErrCode: 10012, Description: "{err}"},
+		JSConsumerCreateFilterSubjectMismatchErr:       {Code: 400, ErrCode: 10131, Description: "Consumer create request did not match filtered subject from create subject"},
+		JSConsumerDeadLetterSubjectInvalidErrF:         {Code: 400, ErrCode: 10244, Description: "consumer dead letter subject invalid: {err}"},
+		JSConsumerDeliverCycleErr:                      {Code: 400, ErrCode: 10081, Description: "consumer deliver subject forms a cycle"},
+		JSConsumerDeliverPolicyRequiredErr:             {Code: 400, ErrCode: 10224, Description: "consumer deliver policy required, set deliver_policy explicitly"},
+		JSConsumerDeliverToWildcardsErr:                {Code: 400, ErrCode: 10079, Description: "consumer deliver subject has wildcards"},
+		JSConsumerDeliveryConcurrencyInvalidErrF:       {Code: 400, ErrCode: 10251, Description: "consumer delivery concurrency invalid: {err}"},
+		JSConsumerDeliveryQuotaNegativeErr:             {Code: 400, ErrCode: 10233, Description: "consumer delivery quota can not be negative"},
+		JSConsumerDeliveryReceiptInvalidErrF:           {Code: 400, ErrCode: 10250, Description: "consumer delivery receipt configuration invalid: {err}"},
+		JSConsumerDescriptionTooLongErrF:               {Code: 400, ErrCode: 10107, Description: "consumer description is too long, maximum allowed is {max}"},
+		JSConsumerDirectRequiresEphemeralErr:           {Code: 400, ErrCode: 10091, Description: "consumer direct requires an ephemeral consumer"},
+		JSConsumerDirectRequiresPushErr:                {Code: 400, ErrCode: 10090, Description: "consumer direct requires a push based consumer"},
+		JSConsumerDoesNotExist:                         {Code: 400, ErrCode: 10149, Description: "consumer does not exist"},
+		JSConsumerDuplicateFilterSubjects:              {Code: 400, ErrCode: 10136, Description: "consumer cannot have both FilterSubject and FilterSubjects specified"},
+		JSConsumerDurableNameNotInSubjectErr:           {Code: 400, ErrCode: 10016, Description: "consumer expected to be durable but no durable name set in subject"},
+		JSConsumerDurableNameNotMatchSubjectErr:        {Code: 400, ErrCode: 10017, Description: "consumer name in subject does not match durable name in request"},
+		JSConsumerDurableNameNotSetErr:                 {Code: 400, ErrCode: 10018, Description: "consumer expected to be durable but a durable name was not set"},
+		JSConsumerEmptyFilter:                          {Code: 400, ErrCode: 10139, Description: "consumer filter in FilterSubjects cannot be empty"},
+		JSConsumerEmptyGroupName:                       {Code: 400, ErrCode: 10161, Description: "Group name cannot be an empty string"},
+		JSConsumerEphemeralWithDurableInSubjectErr:     {Code: 400, ErrCode: 10019, Description: "consumer expected to be ephemeral but detected a durable name set in subject"},
+		JSConsumerEphemeralWithDurableNameErr:          {Code: 400, ErrCode: 10020, Description: "consumer expected to be ephemeral but a durable name was set in request"},
+		JSConsumerExcludeSubjectInvalidErrF:            {Code: 400, ErrCode: 10245, Description: "consumer exclude subject invalid: {err}"},
+		JSConsumerExistingActiveErr:                    {Code: 400, ErrCode: 10105, Description: "consumer already exists and is still active"},
+		JSConsumerFCRequiresPushErr:                    {Code: 400, ErrCode: 10089, Description: "consumer flow control requires a push based consumer"},
+		JSConsumerFilterNoMatchErr:                     {Code: 400, ErrCode: 10234, Description: "consumer filter subject {filter} does not overlap any stream subjects"},
+		JSConsumerFilterNotSubsetErr:                   {Code: 400, ErrCode: 10093, Description: "consumer filter subject is not a valid subset of the interest subjects"},
+		JSConsumerHBRequiresPushErr:                    {Code: 400, ErrCode: 10088, Description: "consumer idle heartbeat requires a push based consumer"},
+		JSConsumerInactiveThresholdExcess:              {Code: 400, ErrCode: 10153, Description: "consumer inactive threshold exceeds system limit of {limit}"},
+		JSConsumerInvalidDeliverSubject:                {Code: 400, ErrCode: 10112, Description: "invalid push consumer deliver subject"},
+		JSConsumerInvalidGroupNameErr:                  {Code: 400, ErrCode: 10162, Description: "Valid priority group name must match A-Z, a-z, 0-9, -_/=)+ and may not exceed 16 characters"},
+		JSConsumerInvalidPolicyErrF:                    {Code: 400, ErrCode: 10094, Description: "{err}"},
+		JSConsumerInvalidPriorityGroupErr:              {Code: 400, ErrCode: 10160, Description: "Provided priority group does not exist for this consumer"},
+		JSConsumerInvalidRedeliveryQuietWindowErr:      {Code: 400, ErrCode: 10232, Description: "consumer redelivery quiet window is invalid: {err}"},
+		JSConsumerInvalidResetErr:                      {Code: 400, ErrCode: 10204, Description: "invalid reset: {err}"},
+		JSConsumerInvalidSamplingErrF:                  {Code: 400, ErrCode: 10095, Description: "failed to parse consumer sampling configuration: {err}"},
+		JSConsumerMaxAckAgeInvalidErrF:                 {Code: 400, ErrCode: 10240, Description: "consumer max ack age invalid: {err}"},
+		JSConsumerMaxAckPendingBelowPendingErrF:        {Code: 400, ErrCode: 10249, Description: "consumer max ack pending can not be lowered below the current ack pending count of {pending}"},
+		JSConsumerMaxDeliverBackoffErr:                 {Code: 400, ErrCode: 10116, Description: "max deliver is required to be > length of backoff values"},
+		JSConsumerMaxPendingAckExcessErrF:              {Code: 400, ErrCode: 10121, Description: "consumer max ack pending exceeds system limit of {limit}"},
+		JSConsumerMaxPendingAckPolicyRequiredErr:       {Code: 400, ErrCode: 10082, Description: "consumer requires ack policy for max ack pending"},
+		JSConsumerMaxRequestBatchExceededF:             {Code: 400, ErrCode: 10125, Description: "consumer max request batch exceeds server limit of {limit}"},
+		JSConsumerMaxRequestBatchNegativeErr:           {Code: 400, ErrCode: 10114, Description: "consumer max request batch needs to be > 0"},
+		JSConsumerMaxRequestExpiresTooSmall:            {Code: 400, ErrCode: 10115, Description: "consumer max request expires needs to be >= 1ms"},
+		JSConsumerMaxWaitingNegativeErr:                {Code: 400, ErrCode: 10087, Description: "consumer max waiting needs to be positive"},
+		JSConsumerMetadataLengthErrF:                   {Code: 400, ErrCode: 10135, Description: "consumer metadata exceeds maximum size of {limit}"},
+		JSConsumerMultipleFiltersNotAllowed:            {Code: 400, ErrCode: 10137, Description: "consumer with multiple subject filters cannot use subject based API"},
+		JSConsumerNameContainsPathSeparatorsErr:        {Code: 400, ErrCode: 10127, Description: "Consumer name can not contain path separators"},
+		JSConsumerNameExistErr:                         {Code: 400, ErrCode: 10013, Description: "consumer name already in use"},
+		JSConsumerNamePatternMismatchErrF:              {Code: 400, ErrCode: 10243, Description: "consumer name {name} does not match the stream consumer name pattern {pattern}"},
+		JSConsumerNameTooLongErrF:                      {Code: 400, ErrCode: 10102, Description: "consumer name is too long, maximum allowed is {max}"},
+		JSConsumerNoRedeliverSubjectsInvalidErrF:       {Code: 400, ErrCode: 10238, Description: "consumer no redeliver subjects invalid: {err}"},
+		JSConsumerNotFoundErr:                          {Code: 404, ErrCode: 10014, Description: "consumer not found"},
+		JSConsumerOfflineErr:                           {Code: 500, ErrCode: 10119, Description: "consumer is offline"},
+		JSConsumerOfflineReasonErrF:                    {Code: 500, ErrCode: 10195, Description: "consumer is offline: {err}"},
+		JSConsumerOnMappedErr:                          {Code: 400, ErrCode: 10092, Description: "consumer direct on a mapped consumer"},
+		JSConsumerOrderByTimestampInvalidErrF:          {Code: 400, ErrCode: 10242, Description: "consumer order by timestamp invalid: {err}"},
+		JSConsumerOverlappingSubjectFilters:            {Code: 400, ErrCode: 10138, Description: "consumer subject filters cannot overlap"},
+		JSConsumerPinStealPolicyInvalidErrF:            {Code: 400, ErrCode: 10237, Description: "consumer pin steal policy invalid: {err}"},
+		JSConsumerPinnedTTLWithoutPriorityPolicyNone:   {Code: 400, ErrCode: 10197, Description: "PinnedTTL cannot be set when PriorityPolicy is none"},
+		JSConsumerPriorityGroupWithPolicyNone:          {Code: 400, ErrCode: 10196, Description: "consumer can not have priority groups when policy is none"},
+		JSConsumerPriorityPolicyWithoutGroup:           {Code: 400, ErrCode: 10159, Description: "Setting PriorityPolicy requires at least one PriorityGroup to be set"},
+		JSConsumerPullNotDurableErr:                    {Code: 400, ErrCode: 10085, Description: "consumer in pull mode requires a durable name"},
+		JSConsumerPullRequiresAckErr:                   {Code: 400, ErrCode: 10084, Description: "consumer in pull mode requires explicit ack policy on workqueue stream"},
+		JSConsumerPullWithRateLimitErr:                 {Code: 400, ErrCode: 10086, Description: "consumer in pull mode can not have rate limit set"},
+		JSConsumerPushDisabledErr:                      {Code: 400, ErrCode: 10252, Description: "push consumers are disabled for the account"},
+		JSConsumerPushMaxWaitingErr:                    {Code: 400, ErrCode: 10080, Description: "consumer in push mode can not set max waiting"},
+		JSConsumerPushWaitingRequestIdleTimeoutErr:     {Code: 400, ErrCode: 10229, Description: "consumer in push mode can not set waiting request idle timeout"},
+		JSConsumerPushWithPriorityGroupErr:             {Code: 400, ErrCode: 10178, Description: "priority groups can not be used with push consumers"},
+		JSConsumerReplacementWithDifferentNameErr:      {Code: 400, ErrCode: 10106, Description: "consumer replacement durable config not the same"},
+		JSConsumerReplayPolicyInvalidErr:               {Code: 400, ErrCode: 10182, Description: "consumer replay policy invalid"},
+		JSConsumerReplaySpeedInvalidErrF:               {Code: 400, ErrCode: 10235, Description: "consumer replay speed invalid: {err}"},
+		JSConsumerReplicasExceedsStream:                {Code: 400, ErrCode: 10126, Description: "consumer config replica count exceeds parent stream"},
+		JSConsumerReplicasShouldMatchStream:            {Code: 400, ErrCode: 10134, Description: "consumer config replicas must match interest retention stream's replicas"},
+		JSConsumerSignalCaughtUpRequiresPushErr:        {Code: 400, ErrCode: 10231, Description: "consumer signal caught up requires a push based consumer"},
+		JSConsumerSkipHeadersInvalidErrF:               {Code: 400, ErrCode: 10246, Description: "consumer skip headers invalid: {err}"},
+		JSConsumerSmallHeartbeatErr:                    {Code: 400, ErrCode: 10083, Description: "consumer idle heartbeat needs to be >= 100ms"},
+		JSConsumerStoreFailedErrF:                      {Code: 500, ErrCode: 10104, Description: "error creating store for consumer: {err}"},
+		JSConsumerSubjectAckInvalidErr:                 {Code: 400, ErrCode: 10236, Description: "consumer subject ack requires ack policy explicit or all"},
+		JSConsumerWQConsumerNotDeliverAllErr:           {Code: 400, ErrCode: 10101, Description: "consumer must be deliver all on workqueue stream"},
+		JSConsumerWQConsumerNotUniqueErr:               {Code: 400, ErrCode: 10100, Description: "filtered consumer not unique on workqueue stream"},
+		JSConsumerWQMultipleUnfilteredErr:              {Code: 400, ErrCode: 10099, Description: "multiple non-filtered consumers not allowed on workqueue stream"},
+		JSConsumerWQRequiresExplicitAckErr:             {Code: 400, ErrCode: 10098, Description: "workqueue stream requires explicit ack"},
+		JSConsumerWaitingRequestIdleTimeoutNegativeErr: {Code: 400, ErrCode: 10228, Description: "consumer waiting request idle timeout needs to be positive"},
+		JSConsumerWithFlowControlNeedsHeartbeats:       {Code: 400, ErrCode: 10108, Description: "consumer with flow control also needs heartbeats"},
+		JSInsufficientResourcesErr:                     {Code: 503, ErrCode: 10023, Description: "insufficient resources"},
+		JSInvalidJSONErr:                               {Code: 400, ErrCode: 10025, Description: "invalid JSON: {err}"},
+		JSMaximumConsumersLimitErr:                     {Code: 400, ErrCode: 10026, Description: "maximum consumers limit reached"},
+		JSMaximumHAAssetsLimitErrF:                     {Code: 400, ErrCode: 10225, Description: "maximum number of replicated assets reached, {limit} limit is {max}"},
+		JSMaximumStreamsLimitErr:                       {Code: 400, ErrCode: 10027, Description: "maximum number of streams reached"},
+		JSMemoryLowErr:                                 {Code: 503, ErrCode: 10248, Description: "insufficient free memory for memory storage"},
+		JSMemoryResourcesExceededErr:                   {Code: 500, ErrCode: 10028, Description: "insufficient memory resources available"},
+		JSMessageCounterBrokenErr:                      {Code: 400, ErrCode: 10172, Description: "message counter is broken"},
+		JSMessageIncrDisabledErr:                       {Code: 400, ErrCode: 10168, Description: "message counters is disabled"},
+		JSMessageIncrInvalidErr:                        {Code: 400, ErrCode: 10171, Description: "message counter increment is invalid"},
+		JSMessageIncrMissingErr:                        {Code: 400, ErrCode: 10169, Description: "message counter increment is missing"},
+		JSMessageIncrPayloadErr:                        {Code: 400, ErrCode: 10170, Description: "message counter has payload"},
+		JSMessageSchedulesDisabledErr:                  {Code: 400, ErrCode: 10188, Description: "message schedules is disabled"},
+		JSMessageSchedulesPatternInvalidErr:            {Code: 400, ErrCode: 10189, Description: "message schedules pattern is invalid"},
+		JSMessageSchedulesRollupInvalidErr:             {Code: 400, ErrCode: 10192, Description: "message schedules invalid rollup"},
+		JSMessageSchedulesSchedulerInvalidErr:          {Code: 400, ErrCode: 10212, Description: "message schedules invalid scheduler"},
+		JSMessageSchedulesSourceInvalidErr:             {Code: 400, ErrCode: 10203, Description: "message schedules source is invalid"},
+		JSMessageSchedulesTTLInvalidErr:                {Code: 400, ErrCode: 10191, Description: "message schedules invalid per-message TTL"},
+		JSMessageSchedulesTargetInvalidErr:             {Code: 400, ErrCode: 10190, Description: "message schedules target is invalid"},
+		JSMessageSchedulesTimeZoneInvalidErr:           {Code: 400, ErrCode: 10223, Description: "message schedules time zone is invalid"},
+		JSMessageTTLDisabledErr:                        {Code: 400, ErrCode: 10166, Description: "per-message TTL is disabled"},
+		JSMessageTTLInvalidErr:                         {Code: 400, ErrCode: 10165, Description: "invalid per-message TTL"},
+		JSMirrorConsumerRequiresAckFCErr:               {Code: 400, ErrCode: 10214, Description: "stream mirror consumer requires flow control ack policy"},
+		JSMirrorConsumerSetupFailedErrF:                {Code: 500, ErrCode: 10029, Description: "{err}"},
+		JSMirrorDurableConsumerCfgInvalid:              {Code: 400, ErrCode: 10213, Description: "stream mirror consumer config is invalid"},
+		JSMirrorInvalidStreamName:                      {Code: 400, ErrCode: 10142, Description: "mirrored stream name is invalid"},
+		JSMirrorInvalidSubjectFilter:                   {Code: 400, ErrCode: 10151, Description: "mirror transform source: {err}"},
+		JSMirrorInvalidTransformDestination:            {Code: 400, ErrCode: 10154, Description: "mirror transform: {err}"},
+		JSMirrorMaxMessageSizeTooBigErr:                {Code: 400, ErrCode: 10030, Description: "stream mirror must have max message size >= source"},
+		JSMirrorMultipleFiltersNotAllowed:              {Code: 400, ErrCode: 10150, Description: "mirror with multiple subject transforms cannot also have a single subject filter"},
+		JSMirrorOverlappingSubjectFilters:              {Code: 400, ErrCode: 10152, Description: "mirror subject filters can not overlap"},
+		JSMirrorWithAtomicPublishErr:                   {Code: 400, ErrCode: 10198, Description: "stream mirrors can not also use atomic publishing"},
+		JSMirrorWithBatchPublishErr:                    {Code: 400, ErrCode: 10209, Description: "stream mirrors can not also use batch publishing"},
+		JSMirrorWithCountersErr:                        {Code: 400, ErrCode: 10173, Description: "stream mirrors can not also calculate counters"},
+		JSMirrorWithFirstSeqErr:                        {Code: 400, ErrCode: 10143, Description: "stream mirrors can not have first sequence configured"},
+		JSMirrorWithMsgSchedulesErr:                    {Code: 400, ErrCode: 10186, Description: "stream mirrors can not also schedule messages"},
+		JSMirrorWithSourcesErr:                         {Code: 400, ErrCode: 10031, Description: "stream mirrors can not also contain other sources"},
+		JSMirrorWithStartSeqAndTimeErr:                 {Code: 400, ErrCode: 10032, Description: "stream mirrors can not have both start seq and start time configured"},
+		JSMirrorWithSubjectFiltersErr:                  {Code: 400, ErrCode: 10033, Description: "stream mirrors can not contain filtered subjects"},
+		JSMirrorWithSubjectsErr:                        {Code: 400, ErrCode: 10034, Description: "stream mirrors can not contain subjects"},
+		JSNoAccountErr:                                 {Code: 503, ErrCode: 10035, Description: "account not found"},
+		JSNoLimitsErr:                                  {Code: 400, ErrCode: 10120, Description: "no JetStream default or applicable tiered limit present"},
+		JSNoMessageFoundErr:                            {Code: 404, ErrCode: 10037, Description: "no message found"},
+		JSNotAllowedForAccountErr:                      {Code: 403, ErrCode: 10241, Description: "JetStream not allowed for account"},
+		JSNotEmptyRequestErr:                           {Code: 400, ErrCode: 10038, Description: "expected an empty request payload"},
+		JSNotEnabledErr:                                {Code: 503, ErrCode: 10076, Description: "JetStream not enabled"},
+		JSNotEnabledForAccountErr:                      {Code: 503, ErrCode: 10039, Description: "JetStream not enabled for account"},
+		JSPedanticErrF:                                 {Code: 400, ErrCode: 10157, Description: "pedantic mode: {err}"},
+		JSPeerRemapErr:                                 {Code: 503, ErrCode: 10075, Description: "peer remap failed"},
+		JSRaftGeneralErrF:                              {Code: 500, ErrCode: 10041, Description: "{err}"},
+		JSReplicasCountCannotBeNegative:                {Code: 400, ErrCode: 10133, Description: "replicas count cannot be negative"},
+		JSRequiredApiLevelErr:                          {Code: 412, ErrCode: 10185, Description: "JetStream minimum api level required"},
+		JSRestoreSubscribeFailedErrF:                   {Code: 500, ErrCode: 10042, Description: "JetStream unable to subscribe to restore snapshot {subject}: {err}"},
+		JSSequenceNotFoundErrF:                         {Code: 400, ErrCode: 10043, Description: "sequence {seq} not found"},
+		JSSnapshotDeliverSubjectInvalidErr:             {Code: 400, ErrCode: 10015, Description: "deliver subject not valid"},
+		JSSourceConsumerRequiresAckFCErr:               {Code: 400, ErrCode: 10217, Description: "stream source consumer requires flow control ack policy"},
+		JSSourceConsumerSetupFailedErrF:                {Code: 500, ErrCode: 10045, Description: "{err}"},
+		JSSourceDuplicateDetected:                      {Code: 400, ErrCode: 10140, Description: "duplicate source configuration detected"},
+		JSSourceDurableConsumerCfgInvalid:              {Code: 400, ErrCode: 10215, Description: "stream source consumer config is invalid"},
+		JSSourceDurableConsumerDuplicateDetected:       {Code: 400, ErrCode: 10216, Description: "duplicate stream source consumer detected"},
+		JSSourceInvalidStreamName:                      {Code: 400, ErrCode: 10141, Description: "sourced stream name is invalid"},
+		JSSourceInvalidSubjectFilter:                   {Code: 400, ErrCode: 10145, Description: "source transform source: {err}"},
+		JSSourceInvalidTransformDestination:            {Code: 400, ErrCode: 10146, Description: "source transform: {err}"},
+		JSSourceMaxMessageSizeTooBigErr:                {Code: 400, ErrCode: 10046, Description: "stream source must have max message size >= target"},
+		JSSourceMultipleFiltersNotAllowed:              {Code: 400, ErrCode: 10144, Description: "source with multiple subject transforms cannot also have a single subject filter"},
+		JSSourceOverlappingSubjectFilters:              {Code: 400, ErrCode: 10147, Description: "source filters can not overlap"},
+		JSSourceWithMsgSchedulesErr:                    {Code: 400, ErrCode: 10187, Description: "stream source can not also schedule messages"},
+		JSStorageResourcesExceededErr:                  {Code: 500, ErrCode: 10047, Description: "insufficient storage resources available"},
+		JSStreamAssignmentErrF:                         {Code: 500, ErrCode: 10048, Description: "{err}"},
+		JSStreamCreateErrF:                             {Code: 500, ErrCode: 10049, Description: "{err}"},
+		JSStreamDeleteErrF:                             {Code: 500, ErrCode: 10050, Description: "{err}"},
+		JSStreamDuplicateMessageConflict:               {Code: 409, ErrCode: 10158, Description: "duplicate message id is in process"},
+		JSStreamExpectedLastSeqPerSubjectInvalid:       {Code: 400, ErrCode: 10193, Description: "missing sequence for expected last sequence per subject"},
+		JSStreamExpectedLastSeqPerSubjectNotReady:      {Code: 503, ErrCode: 10163, Description: "expected last sequence per subject temporarily unavailable"},
+		JSStreamExternalApiOverlapErrF:                 {Code: 400, ErrCode: 10021, Description: "stream external api prefix {prefix} must not overlap with {subject}"},
+		JSStreamExternalDelPrefixOverlapsErrF:          {Code: 400, ErrCode: 10022, Description: "stream external delivery prefix {prefix} overlaps with stream subject {subject}"},
+		JSStreamGeneralErrorF:                          {Code: 500, ErrCode: 10051, Description: "{err}"},
+		JSStreamHeaderExceedsMaximumErr:                {Code: 400, ErrCode: 10097, Description: "header size exceeds maximum allowed of 64k"},
+		JSStreamInfoMaxSubjectsErr:                     {Code: 500, ErrCode: 10117, Description: "subject details would exceed maximum allowed"},
+		JSStreamInvalidConfigF:                         {Code: 500, ErrCode: 10052, Description: "{err}"},
+		JSStreamInvalidErr:                             {Code: 500, ErrCode: 10096, Description: "stream not valid"},
+		JSStreamInvalidExternalDeliverySubjErrF:        {Code: 400, ErrCode: 10024, Description: "stream external delivery prefix {prefix} must not contain wildcards"},
+		JSStreamLimitsErrF:                             {Code: 500, ErrCode: 10053, Description: "{err}"},
+		JSStreamMaxBytesRequired:                       {Code: 400, ErrCode: 10113, Description: "account requires a stream config to have max bytes set"},
+		JSStreamMaxStreamBytesExceeded:                 {Code: 400, ErrCode: 10122, Description: "stream max bytes exceeds account limit max stream bytes"},
+		JSStreamMessageExceedsMaximumErr:               {Code: 400, ErrCode: 10054, Description: "message size exceeds maximum allowed"},
+		JSStreamMinLastSeqErr:                          {Code: 412, ErrCode: 10180, Description: "min last sequence"},
+		JSStreamMirrorNotUpdatableErr:                  {Code: 400, ErrCode: 10055, Description: "stream mirror configuration can not be updated"},
+		JSStreamMismatchErr:                            {Code: 400, ErrCode: 10056, Description: "stream name in subject does not match request"},
+		JSStreamMoveAndScaleErr:                        {Code: 400, ErrCode: 10123, Description: "can not move and scale a stream in a single update"},
+		JSStreamMoveInProgressF:                        {Code: 400, ErrCode: 10124, Description: "stream move already in progress: {msg}"},
+		JSStreamMoveNotInProgress:                      {Code: 400, ErrCode: 10129, Description: "stream move not in progress"},
+		JSStreamMsgDeleteFailedF:                       {Code: 500, ErrCode: 10057, Description: "{err}"},
+		JSStreamNameContainsPathSeparatorsErr:          {Code: 400, ErrCode: 10128, Description: "Stream name can not contain path separators"},
+		JSStreamNameExistErr:                           {Code: 400, ErrCode: 10058, Description: "stream name already in use with a different configuration"},
+		JSStreamNameExistRestoreFailedErr:              {Code: 400, ErrCode: 10130, Description: "stream name already in use, cannot restore"},
+		JSStreamNotFoundErr:                            {Code: 404, ErrCode: 10059, Description: "stream not found"},
+		JSStreamNotMatchErr:                            {Code: 400, ErrCode: 10060, Description: "expected stream does not match"},
+		JSStreamOfflineErr:                             {Code: 500, ErrCode: 10118, Description: "stream is offline"},
+		JSStreamOfflineReasonErrF:                      {Code: 500, ErrCode: 10194, Description: "stream is offline: {err}"},
+		JSStreamPurgeFailedF:                           {Code: 500, ErrCode: 10110, Description: "{err}"},
+		JSStreamReplicasNotSupportedErr:                {Code: 500, ErrCode: 10074, Description: "replicas > 1 not supported in non-clustered mode"},
+		JSStreamReplicasNotUpdatableErr:                {Code: 400, ErrCode: 10061, Description: "Replicas configuration can not be updated"},
+		JSStreamRestoreErrF:                            {Code: 500, ErrCode: 10062, Description: "restore failed: {err}"},
+		JSStreamRestoreTooManyConcurrentErr:            {Code: 429, ErrCode: 10227, Description: "too many concurrent restores"},
+		JSStreamRollupFailedF:                          {Code: 500, ErrCode: 10111, Description: "{err}"},
+		JSStreamSealedErr:                              {Code: 400, ErrCode: 10109, Description: "invalid operation on sealed stream"},
+		JSStreamSequenceNotMatchErr:                    {Code: 503, ErrCode: 10063, Description: "expected stream sequence does not match"},
+		JSStreamSnapshotErrF:                           {Code: 500, ErrCode: 10064, Description: "snapshot failed: {err}"},
+		JSStreamSnapshotTooManyConcurrentErr:           {Code: 429, ErrCode: 10226, Description: "too many concurrent snapshots"},
+		JSStreamStoreFailedF:                           {Code: 503, ErrCode: 10077, Description: "{err}"},
+		JSStreamSubjectOverlapErr:                      {Code: 400, ErrCode: 10065, Description: "subjects overlap with an existing stream"},
+		JSStreamTemplateCreateErrF:                     {Code: 500, ErrCode: 10066, Description: "{err}"},
+		JSStreamTemplateDeleteErrF:                     {Code: 500, ErrCode: 10067, Description: "{err}"},
+		JSStreamTemplateNotFoundErr:                    {Code: 404, ErrCode: 10068, Description: "template not found"},
+		JSStreamTooManyRequests:                        {Code: 429, ErrCode: 10167, Description: "too many requests"},
+		JSStreamTransformInvalidDestination:            {Code: 400, ErrCode: 10156, Description: "stream transform: {err}"},
+		JSStreamTransformInvalidSource:                 {Code: 400, ErrCode: 10155, Description: "stream transform source: {err}"},
+		JSStreamUpdateErrF:                             {Code: 500, ErrCode: 10069, Description: "{err}"},
+		JSStreamWrongLastMsgIDErrF:                     {Code: 400, ErrCode: 10070, Description: "wrong last msg ID: {id}"},
+		JSStreamWrongLastSequenceConstantErr:           {Code: 400, ErrCode: 10164, Description: "wrong last sequence"},
+		JSStreamWrongLastSequenceErrF:                  {Code: 400, ErrCode: 10071, Description: "wrong last sequence: {seq}"},
+		JSTempStorageFailedErr:                         {Code: 500, ErrCode: 10072, Description: "JetStream unable to open temp storage for restore"},
+		JSTemplateNameNotMatchSubjectErr:               {Code: 400, ErrCode: 10073, Description: "template name in subject does not match request"},
 	}
 	// ErrJetStreamNotClustered Deprecated by JSClusterNotActiveErr ApiError, use IsNatsError() for comparisons
 	ErrJetStreamNotClustered = ApiErrors[JSClusterNotActiveErr]
@@ -1219,6 +1335,22 @@ func NewJSClusterUnSupportFeatureError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSClusterUnSupportFeatureErr]
 }
 
+// NewJSConsumerAckBatchInvalidError creates a new JSConsumerAckBatchInvalidErrF error: "consumer ack batch invalid: {err}"
+func NewJSConsumerAckBatchInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerAckBatchInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerAckFCRequiresFCError creates a new JSConsumerAckFCRequiresFCErr error: "flow control ack policy requires flow control"
 func NewJSConsumerAckFCRequiresFCError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1279,6 +1411,22 @@ func NewJSConsumerAckPolicyInvalidError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerAckPolicyInvalidErr]
 }
 
+// NewJSConsumerAckSameConnectionInvalidError creates a new JSConsumerAckSameConnectionInvalidErrF error: "consumer ack same connection invalid: {err}"
+func NewJSConsumerAckSameConnectionInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerAckSameConnectionInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerAckWaitNegativeError creates a new JSConsumerAckWaitNegativeErr error: "consumer ack wait needs to be positive"
 func NewJSConsumerAckWaitNegativeError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1289,6 +1437,22 @@ func NewJSConsumerAckWaitNegativeError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerAckWaitNegativeErr]
 }
 
+// NewJSConsumerAckWaitPerFilterInvalidError creates a new JSConsumerAckWaitPerFilterInvalidErrF error: "consumer ack wait per filter invalid: {err}"
+func NewJSConsumerAckWaitPerFilterInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerAckWaitPerFilterInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerAlreadyExistsError creates a new JSConsumerAlreadyExists error: "consumer already exists"
 func NewJSConsumerAlreadyExistsError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1365,6 +1529,22 @@ func NewJSConsumerCreateFilterSubjectMismatchError(opts ...ErrorOption) *ApiErro
 	return ApiErrors[JSConsumerCreateFilterSubjectMismatchErr]
 }
 
+// NewJSConsumerDeadLetterSubjectInvalidError creates a new JSConsumerDeadLetterSubjectInvalidErrF error: "consumer dead letter subject invalid: {err}"
+func NewJSConsumerDeadLetterSubjectInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerDeadLetterSubjectInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerDeliverCycleError creates a new JSConsumerDeliverCycleErr error: "consumer deliver subject forms a cycle"
 func NewJSConsumerDeliverCycleError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1375,6 +1555,16 @@ func NewJSConsumerDeliverCycleError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerDeliverCycleErr]
 }
 
+// NewJSConsumerDeliverPolicyRequiredError creates a new JSConsumerDeliverPolicyRequiredErr error: "consumer deliver policy required, set deliver_policy explicitly"
+func NewJSConsumerDeliverPolicyRequiredError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSConsumerDeliverPolicyRequiredErr]
+}
+
 // NewJSConsumerDeliverToWildcardsError creates a new JSConsumerDeliverToWildcardsErr error: "consumer deliver subject has wildcards"
 func NewJSConsumerDeliverToWildcardsError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1385,6 +1575,48 @@ func NewJSConsumerDeliverToWildcardsError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerDeliverToWildcardsErr]
 }
 
+// NewJSConsumerDeliveryConcurrencyInvalidError creates a new JSConsumerDeliveryConcurrencyInvalidErrF error: "consumer delivery concurrency invalid: {err}"
+func NewJSConsumerDeliveryConcurrencyInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerDeliveryConcurrencyInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
+// NewJSConsumerDeliveryQuotaNegativeError creates a new JSConsumerDeliveryQuotaNegativeErr error: "consumer delivery quota can not be negative"
+func NewJSConsumerDeliveryQuotaNegativeError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSConsumerDeliveryQuotaNegativeErr]
+}
+
+// NewJSConsumerDeliveryReceiptInvalidError creates a new JSConsumerDeliveryReceiptInvalidErrF error: "consumer delivery receipt configuration invalid: {err}"
+func NewJSConsumerDeliveryReceiptInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerDeliveryReceiptInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerDescriptionTooLongError creates a new JSConsumerDescriptionTooLongErrF error: "consumer description is too long, maximum allowed is {max}"
 func NewJSConsumerDescriptionTooLongError(max interface{}, opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1511,6 +1743,22 @@ func NewJSConsumerEphemeralWithDurableNameError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerEphemeralWithDurableNameErr]
 }
 
+// NewJSConsumerExcludeSubjectInvalidError creates a new JSConsumerExcludeSubjectInvalidErrF error: "consumer exclude subject invalid: {err}"
+func NewJSConsumerExcludeSubjectInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerExcludeSubjectInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerExistingActiveError creates a new JSConsumerExistingActiveErr error: "consumer already exists and is still active"
 func NewJSConsumerExistingActiveError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1531,6 +1779,22 @@ func NewJSConsumerFCRequiresPushError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerFCRequiresPushErr]
 }
 
+// NewJSConsumerFilterNoMatchError creates a new JSConsumerFilterNoMatchErr error: "consumer filter subject {filter} does not overlap any stream subjects"
+func NewJSConsumerFilterNoMatchError(filter interface{}, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerFilterNoMatchErr]
+	args := e.toReplacerArgs([]interface{}{"{filter}", filter})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerFilterNotSubsetError creates a new JSConsumerFilterNotSubsetErr error: "consumer filter subject is not a valid subset of the interest subjects"
 func NewJSConsumerFilterNotSubsetError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1613,6 +1877,22 @@ func NewJSConsumerInvalidPriorityGroupError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerInvalidPriorityGroupErr]
 }
 
+// NewJSConsumerInvalidRedeliveryQuietWindowError creates a new JSConsumerInvalidRedeliveryQuietWindowErr error: "consumer redelivery quiet window is invalid: {err}"
+func NewJSConsumerInvalidRedeliveryQuietWindowError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerInvalidRedeliveryQuietWindowErr]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerInvalidResetError creates a new JSConsumerInvalidResetErr error: "invalid reset: {err}"
 func NewJSConsumerInvalidResetError(err error, opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1645,6 +1925,38 @@ func NewJSConsumerInvalidSamplingError(err error, opts ...ErrorOption) *ApiError
 	}
 }
 
+// NewJSConsumerMaxAckAgeInvalidError creates a new JSConsumerMaxAckAgeInvalidErrF error: "consumer max ack age invalid: {err}"
+func NewJSConsumerMaxAckAgeInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerMaxAckAgeInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
+// NewJSConsumerMaxAckPendingBelowPendingError creates a new JSConsumerMaxAckPendingBelowPendingErrF error: "consumer max ack pending can not be lowered below the current ack pending count of {pending}"
+func NewJSConsumerMaxAckPendingBelowPendingError(pending interface{}, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerMaxAckPendingBelowPendingErrF]
+	args := e.toReplacerArgs([]interface{}{"{pending}", pending})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerMaxDeliverBackoffError creates a new JSConsumerMaxDeliverBackoffErr error: "max deliver is required to be > length of backoff values"
 func NewJSConsumerMaxDeliverBackoffError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1773,6 +2085,22 @@ func NewJSConsumerNameExistError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerNameExistErr]
 }
 
+// NewJSConsumerNamePatternMismatchError creates a new JSConsumerNamePatternMismatchErrF error: "consumer name {name} does not match the stream consumer name pattern {pattern}"
+func NewJSConsumerNamePatternMismatchError(name interface{}, pattern interface{}, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerNamePatternMismatchErrF]
+	args := e.toReplacerArgs([]interface{}{"{name}", name, "{pattern}", pattern})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerNameTooLongError creates a new JSConsumerNameTooLongErrF error: "consumer name is too long, maximum allowed is {max}"
 func NewJSConsumerNameTooLongError(max interface{}, opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1789,6 +2117,22 @@ func NewJSConsumerNameTooLongError(max interface{}, opts ...ErrorOption) *ApiErr
 	}
 }
 
+// NewJSConsumerNoRedeliverSubjectsInvalidError creates a new JSConsumerNoRedeliverSubjectsInvalidErrF error: "consumer no redeliver subjects invalid: {err}"
+func NewJSConsumerNoRedeliverSubjectsInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerNoRedeliverSubjectsInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerNotFoundError creates a new JSConsumerNotFoundErr error: "consumer not found"
 func NewJSConsumerNotFoundError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1835,6 +2179,22 @@ func NewJSConsumerOnMappedError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerOnMappedErr]
 }
 
+// NewJSConsumerOrderByTimestampInvalidError creates a new JSConsumerOrderByTimestampInvalidErrF error: "consumer order by timestamp invalid: {err}"
+func NewJSConsumerOrderByTimestampInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerOrderByTimestampInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerOverlappingSubjectFiltersError creates a new JSConsumerOverlappingSubjectFilters error: "consumer subject filters cannot overlap"
 func NewJSConsumerOverlappingSubjectFiltersError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1845,6 +2205,22 @@ func NewJSConsumerOverlappingSubjectFiltersError(opts ...ErrorOption) *ApiError
 	return ApiErrors[JSConsumerOverlappingSubjectFilters]
 }
 
+// NewJSConsumerPinStealPolicyInvalidError creates a new JSConsumerPinStealPolicyInvalidErrF error: "consumer pin steal policy invalid: {err}"
+func NewJSConsumerPinStealPolicyInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerPinStealPolicyInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerPinnedTTLWithoutPriorityPolicyNoneError creates a new JSConsumerPinnedTTLWithoutPriorityPolicyNone error: "PinnedTTL cannot be set when PriorityPolicy is none"
 func NewJSConsumerPinnedTTLWithoutPriorityPolicyNoneError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1905,6 +2281,16 @@ func NewJSConsumerPullWithRateLimitError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerPullWithRateLimitErr]
 }
 
+// NewJSConsumerPushDisabledError creates a new JSConsumerPushDisabledErr error: "push consumers are disabled for the account"
+func NewJSConsumerPushDisabledError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSConsumerPushDisabledErr]
+}
+
 // NewJSConsumerPushMaxWaitingError creates a new JSConsumerPushMaxWaitingErr error: "consumer in push mode can not set max waiting"
 func NewJSConsumerPushMaxWaitingError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1915,6 +2301,16 @@ func NewJSConsumerPushMaxWaitingError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerPushMaxWaitingErr]
 }
 
+// NewJSConsumerPushWaitingRequestIdleTimeoutError creates a new JSConsumerPushWaitingRequestIdleTimeoutErr error: "consumer in push mode can not set waiting request idle timeout"
+func NewJSConsumerPushWaitingRequestIdleTimeoutError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSConsumerPushWaitingRequestIdleTimeoutErr]
+}
+
 // NewJSConsumerPushWithPriorityGroupError creates a new JSConsumerPushWithPriorityGroupErr error: "priority groups can not be used with push consumers"
 func NewJSConsumerPushWithPriorityGroupError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1945,6 +2341,22 @@ func NewJSConsumerReplayPolicyInvalidError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerReplayPolicyInvalidErr]
 }
 
+// NewJSConsumerReplaySpeedInvalidError creates a new JSConsumerReplaySpeedInvalidErrF error: "consumer replay speed invalid: {err}"
+func NewJSConsumerReplaySpeedInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerReplaySpeedInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerReplicasExceedsStreamError creates a new JSConsumerReplicasExceedsStream error: "consumer config replica count exceeds parent stream"
 func NewJSConsumerReplicasExceedsStreamError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1965,6 +2377,32 @@ func NewJSConsumerReplicasShouldMatchStreamError(opts ...ErrorOption) *ApiError
 	return ApiErrors[JSConsumerReplicasShouldMatchStream]
 }
 
+// NewJSConsumerSignalCaughtUpRequiresPushError creates a new JSConsumerSignalCaughtUpRequiresPushErr error: "consumer signal caught up requires a push based consumer"
+func NewJSConsumerSignalCaughtUpRequiresPushError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSConsumerSignalCaughtUpRequiresPushErr]
+}
+
+// NewJSConsumerSkipHeadersInvalidError creates a new JSConsumerSkipHeadersInvalidErrF error: "consumer skip headers invalid: {err}"
+func NewJSConsumerSkipHeadersInvalidError(err error, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSConsumerSkipHeadersInvalidErrF]
+	args := e.toReplacerArgs([]interface{}{"{err}", err})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSConsumerSmallHeartbeatError creates a new JSConsumerSmallHeartbeatErr error: "consumer idle heartbeat needs to be >= 100ms"
 func NewJSConsumerSmallHeartbeatError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -1991,6 +2429,16 @@ func NewJSConsumerStoreFailedError(err error, opts ...ErrorOption) *ApiError {
 	}
 }
 
+// NewJSConsumerSubjectAckInvalidError creates a new JSConsumerSubjectAckInvalidErr error: "consumer subject ack requires ack policy explicit or all"
+func NewJSConsumerSubjectAckInvalidError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSConsumerSubjectAckInvalidErr]
+}
+
 // NewJSConsumerWQConsumerNotDeliverAllError creates a new JSConsumerWQConsumerNotDeliverAllErr error: "consumer must be deliver all on workqueue stream"
 func NewJSConsumerWQConsumerNotDeliverAllError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -2031,6 +2479,16 @@ func NewJSConsumerWQRequiresExplicitAckError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSConsumerWQRequiresExplicitAckErr]
 }
 
+// NewJSConsumerWaitingRequestIdleTimeoutNegativeError creates a new JSConsumerWaitingRequestIdleTimeoutNegativeErr error: "consumer waiting request idle timeout needs to be positive"
+func NewJSConsumerWaitingRequestIdleTimeoutNegativeError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSConsumerWaitingRequestIdleTimeoutNegativeErr]
+}
+
 // NewJSConsumerWithFlowControlNeedsHeartbeatsError creates a new JSConsumerWithFlowControlNeedsHeartbeats error: "consumer with flow control also needs heartbeats"
 func NewJSConsumerWithFlowControlNeedsHeartbeatsError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -2077,6 +2535,22 @@ func NewJSMaximumConsumersLimitError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSMaximumConsumersLimitErr]
 }
 
+// NewJSMaximumHAAssetsLimitError creates a new JSMaximumHAAssetsLimitErrF error: "maximum number of replicated assets reached, {limit} limit is {max}"
+func NewJSMaximumHAAssetsLimitError(limit interface{}, max interface{}, opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	e := ApiErrors[JSMaximumHAAssetsLimitErrF]
+	args := e.toReplacerArgs([]interface{}{"{limit}", limit, "{max}", max})
+	return &ApiError{
+		Code:        e.Code,
+		ErrCode:     e.ErrCode,
+		Description: strings.NewReplacer(args...).Replace(e.Description),
+	}
+}
+
 // NewJSMaximumStreamsLimitError creates a new JSMaximumStreamsLimitErr error: "maximum number of streams reached"
 func NewJSMaximumStreamsLimitError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -2087,6 +2561,16 @@ func NewJSMaximumStreamsLimitError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSMaximumStreamsLimitErr]
 }
 
+// NewJSMemoryLowError creates a new JSMemoryLowErr error: "insufficient free memory for memory storage"
+func NewJSMemoryLowError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSMemoryLowErr]
+}
+
 // NewJSMemoryResourcesExceededError creates a new JSMemoryResourcesExceededErr error: "insufficient memory resources available"
 func NewJSMemoryResourcesExceededError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -2475,6 +2959,16 @@ func NewJSNoMessageFoundError(opts ...ErrorOption) *ApiError {
 	return ApiErrors[JSNoMessageFoundErr]
 }
 
+// NewJSNotAllowedForAccountError creates a new JSNotAllowedForAccountErr error: "JetStream not allowed for account"
+func NewJSNotAllowedForAccountError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSNotAllowedForAccountErr]
+}
+
 // NewJSNotEmptyRequestError creates a new JSNotEmptyRequestErr error: "expected an empty request payload"
 func NewJSNotEmptyRequestError(opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -3201,6 +3695,16 @@ func NewJSStreamRestoreError(err error, opts ...ErrorOption) *ApiError {
 	}
 }
 
+// NewJSStreamRestoreTooManyConcurrentError creates a new JSStreamRestoreTooManyConcurrentErr error: "too many concurrent restores"
+func NewJSStreamRestoreTooManyConcurrentError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSStreamRestoreTooManyConcurrentErr]
+}
+
 // NewJSStreamRollupFailedError creates a new JSStreamRollupFailedF error: "{err}"
 func NewJSStreamRollupFailedError(err error, opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
@@ -3253,6 +3757,16 @@ func NewJSStreamSnapshotError(err error, opts ...ErrorOption) *ApiError {
 	}
 }
 
+// NewJSStreamSnapshotTooManyConcurrentError creates a new JSStreamSnapshotTooManyConcurrentErr error: "too many concurrent snapshots"
+func NewJSStreamSnapshotTooManyConcurrentError(opts ...ErrorOption) *ApiError {
+	eopts := parseOpts(opts)
+	if ae, ok := eopts.err.(*ApiError); ok {
+		return ae
+	}
+
+	return ApiErrors[JSStreamSnapshotTooManyConcurrentErr]
+}
+
 // NewJSStreamStoreFailedError creates a new JSStreamStoreFailedF error: "{err}"
 func NewJSStreamStoreFailedError(err error, opts ...ErrorOption) *ApiError {
 	eopts := parseOpts(opts)
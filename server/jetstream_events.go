@@ -59,6 +59,22 @@ type JSAPIAudit struct {
 
 const JSAPIAuditType = "io.nats.jetstream.advisory.v1.api_audit"
 
+// JSAuditEvent is published to the system account on the configured audit
+// subject when a stream or consumer is created, updated or deleted.
+type JSAuditEvent struct {
+	TypedEvent
+	Server         string             `json:"server"`
+	Client         *ClientInfo        `json:"client"`
+	Account        string             `json:"account"`
+	Stream         string             `json:"stream"`
+	Consumer       string             `json:"consumer,omitempty"`
+	Action         ActionAdvisoryType `json:"action"`
+	ConsumerAction string             `json:"consumer_action,omitempty"`
+	Domain         string             `json:"domain,omitempty"`
+}
+
+const JSAuditEventType = "io.nats.jetstream.advisory.v1.audit"
+
 // ActionAdvisoryType indicates which action against a stream, consumer or template triggered an advisory
 type ActionAdvisoryType string
 
@@ -162,6 +178,24 @@ type JSConsumerDeliveryTerminatedAdvisory struct {
 // JSConsumerDeliveryTerminatedAdvisoryType is the schema type for JSConsumerDeliveryTerminatedAdvisory
 const JSConsumerDeliveryTerminatedAdvisoryType = "io.nats.jetstream.advisory.v1.terminated"
 
+// JSConsumerDeliveryTraceEvent is an event, published only when a consumer has
+// DeliverTrace enabled, explaining why a message was delivered or why the
+// consumer did not deliver.
+type JSConsumerDeliveryTraceEvent struct {
+	TypedEvent
+	Stream     string `json:"stream"`
+	Consumer   string `json:"consumer"`
+	Delivered  bool   `json:"delivered"`
+	Reason     string `json:"reason"`
+	Subject    string `json:"subject,omitempty"`
+	StreamSeq  uint64 `json:"stream_seq,omitempty"`
+	Deliveries uint64 `json:"deliveries,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+}
+
+// JSConsumerDeliveryTraceEventType is the schema type for JSConsumerDeliveryTraceEvent
+const JSConsumerDeliveryTraceEventType = "io.nats.jetstream.trace.v1.consumer_delivery"
+
 // JSSnapshotCreateAdvisory is an advisory sent after a snapshot is successfully started
 type JSSnapshotCreateAdvisory struct {
 	TypedEvent
@@ -4909,3 +4909,72 @@ func TestJetStreamSuperClusterConsumerAckSubjectWithStreamImportProtocolError(t
 	require_Equal(t, msg.Subject, "TEST")
 	require_NoError(t, msg.AckSync())
 }
+
+func TestJetStreamSuperClusterStreamPlacementServers(t *testing.T) {
+	sc := createJetStreamSuperCluster(t, 3, 2)
+	defer sc.shutdown()
+
+	nc, _ := jsClientConnect(t, sc.clusterForName("C1").randomServer())
+	defer nc.Close()
+
+	streamRequest := func(subj string, cfg *StreamConfig) *JSApiStreamCreateResponse {
+		t.Helper()
+		req, err := json.Marshal(cfg)
+		require_NoError(t, err)
+		resp, err := nc.Request(fmt.Sprintf(subj, cfg.Name), req, 5*time.Second)
+		require_NoError(t, err)
+		var scResp JSApiStreamCreateResponse
+		require_NoError(t, json.Unmarshal(resp.Data, &scResp))
+		return &scResp
+	}
+
+	c1, c2 := sc.clusterForName("C1"), sc.clusterForName("C2")
+	cfg := &StreamConfig{
+		Name:      "TEST",
+		Subjects:  []string{"foo"},
+		Storage:   FileStorage,
+		Replicas:  2,
+		Placement: &Placement{Cluster: "C1"},
+	}
+	resp := streamRequest(JSApiStreamCreateT, cfg)
+	require_True(t, resp.Error == nil)
+	require_Equal(t, resp.StreamInfo.Cluster.Name, "C1")
+
+	// Pinning the replicas to servers of another cluster moves the stream there.
+	pinned := []string{c2.servers[0].Name(), c2.servers[1].Name()}
+	cfg.Placement = &Placement{Servers: pinned}
+	resp = streamRequest(JSApiStreamUpdateT, cfg)
+	require_True(t, resp.Error == nil)
+	checkFor(t, 20*time.Second, 250*time.Millisecond, func() error {
+		resp, err := nc.Request(fmt.Sprintf(JSApiStreamInfoT, "TEST"), nil, time.Second)
+		if err != nil {
+			return err
+		}
+		var si JSApiStreamInfoResponse
+		if err := json.Unmarshal(resp.Data, &si); err != nil {
+			return err
+		}
+		if si.Error != nil {
+			return si.Error
+		}
+		if si.Cluster == nil || si.Cluster.Name != "C2" || !slices.Contains(pinned, si.Cluster.Leader) {
+			return fmt.Errorf("stream not moved yet: %+v", si.Cluster)
+		}
+		if len(si.Cluster.Replicas) != 1 || !slices.Contains(pinned, si.Cluster.Replicas[0].Name) {
+			return fmt.Errorf("stream replicas not moved yet: %+v", si.Cluster.Replicas)
+		}
+		return nil
+	})
+
+	// Replicas can not span clusters.
+	resp = streamRequest(JSApiStreamCreateT, &StreamConfig{
+		Name:      "SPAN",
+		Subjects:  []string{"bar"},
+		Storage:   FileStorage,
+		Replicas:  2,
+		Placement: &Placement{Servers: []string{c1.servers[0].Name(), c2.servers[0].Name()}},
+	})
+	require_NotNil(t, resp.Error)
+	require_Equal(t, resp.Error.ErrCode, uint16(JSStreamInvalidConfigF))
+	require_Contains(t, resp.Error.Description, "placement servers span clusters")
+}
@@ -10159,6 +10159,122 @@ func TestJetStreamServerDomainConfigButDisabled(t *testing.T) {
 	}
 }
 
+func TestJetStreamServerDomainAliases(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {domain: "HUB", domain_aliases: ["OLD", "LEGACY"], store_dir: %q}
+	`, t.TempDir())))
+
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	config := s.JetStreamConfig()
+	require_Equal(t, config.Domain, "HUB")
+	require_Equal(t, strings.Join(config.DomainAliases, ","), "OLD,LEGACY")
+
+	nc := natsConnect(t, s.ClientURL())
+	defer nc.Close()
+
+	// Requests addressed to an alias are handled by the domain.
+	for _, domain := range []string{"HUB", "OLD", "LEGACY"} {
+		js, err := nc.JetStream(nats.Domain(domain))
+		require_NoError(t, err)
+		ai, err := js.AccountInfo()
+		require_NoError(t, err)
+		require_Equal(t, ai.Domain, "HUB")
+	}
+	js, err := nc.JetStream(nats.Domain("OLD"))
+	require_NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	js, err = nc.JetStream(nats.Domain("HUB"))
+	require_NoError(t, err)
+	_, err = js.StreamInfo("TEST")
+	require_NoError(t, err)
+
+	for _, test := range []struct {
+		name    string
+		domain  string
+		aliases []string
+		err     string
+	}{
+		{"no domain", _EMPTY_, []string{"OLD"}, "domain aliases require a domain"},
+		{"empty", "HUB", []string{_EMPTY_}, "can not be empty"},
+		{"invalid", "HUB", []string{"O.LD"}, "may not contain"},
+		{"same as domain", "HUB", []string{"HUB"}, "same as the domain"},
+		{"duplicate", "HUB", []string{"OLD", "OLD"}, "duplicate"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			opts := DefaultTestOptions
+			opts.JetStreamDomain = test.domain
+			opts.JetStreamDomainAliases = test.aliases
+			err := validateOptions(&opts)
+			require_Error(t, err)
+			require_Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestJetStreamMinFreeMemory(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("free memory is only sampled on linux")
+	}
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {min_free_memory: "1K", store_dir: %q}
+	`, t.TempDir())))
+
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+	require_Equal(t, s.getOpts().JetStreamMinFreeMemory, 1024)
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "MEM", Subjects: []string{"mem"}, Storage: nats.MemoryStorage})
+	require_NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{Name: "FILE", Subjects: []string{"file"}})
+	require_NoError(t, err)
+
+	_, err = js.Publish("mem", nil)
+	require_NoError(t, err)
+
+	jsz, err := s.Jsz(nil)
+	require_NoError(t, err)
+	require_True(t, jsz.FreeMemory > 0)
+	require_False(t, jsz.MemoryLow)
+
+	// Pretend the system is low on memory.
+	sjs := s.getJetStream()
+	sjs.checkFreeMemory(math.MaxInt64)
+
+	jsz, err = s.Jsz(nil)
+	require_NoError(t, err)
+	require_True(t, jsz.MemoryLow)
+
+	_, err = js.Publish("mem", nil)
+	require_Error(t, err, NewJSMemoryLowError())
+	_, err = js.Publish("file", nil)
+	require_NoError(t, err)
+
+	// Writes are accepted again once there is enough free memory.
+	sjs.checkFreeMemory(1024)
+	_, err = js.Publish("mem", nil)
+	require_NoError(t, err)
+
+	si, err := js.StreamInfo("MEM")
+	require_NoError(t, err)
+	require_Equal(t, si.State.Msgs, 2)
+
+	conf = createConfFile(t, []byte(`
+		listen: 127.0.0.1:-1
+		jetstream: {min_free_memory: -1}
+	`))
+	_, err = ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "min_free_memory can not be negative")
+}
+
 func TestJetStreamDomainInPubAck(t *testing.T) {
 	conf := createConfFile(t, []byte(fmt.Sprintf(`
 		listen: 127.0.0.1:-1
@@ -13026,6 +13142,33 @@ func TestJetStreamLimits(t *testing.T) {
 	})
 }
 
+func TestJetStreamMaxStreamSubjectsLimit(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, limits: {max_stream_subjects: 2}}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo", "bar"}})
+	require_NoError(t, err)
+
+	_, err = js.AddStream(&nats.StreamConfig{Name: "TOO_MANY", Subjects: []string{"a", "b", "c"}})
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "stream has 3 subjects, exceeding the server limit of 2")
+
+	_, err = js.UpdateStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo", "bar", "baz"}})
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "exceeding the server limit of 2")
+
+	// Streams without subjects default to their name.
+	_, err = js.AddStream(&nats.StreamConfig{Name: "DEFAULT"})
+	require_NoError(t, err)
+}
+
 func TestJetStreamImportReload(t *testing.T) {
 	storeDir := t.TempDir()
 
@@ -21643,22 +21786,22 @@ func TestJetStreamInvalidConfigValues(t *testing.T) {
 	}
 
 	ccfg := &ConsumerConfig{AckPolicy: -1}
-	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false)
+	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false, false)
 	require_True(t, err != nil)
 	require_Error(t, err, NewJSConsumerAckPolicyInvalidError())
 
 	ccfg = &ConsumerConfig{ReplayPolicy: -1}
-	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false)
+	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false, false)
 	require_True(t, err != nil)
 	require_Error(t, err, NewJSConsumerReplayPolicyInvalidError())
 
 	ccfg = &ConsumerConfig{AckWait: -time.Second}
-	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false)
+	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false, false)
 	require_True(t, err != nil)
 	require_Error(t, err, NewJSConsumerAckWaitNegativeError())
 
 	ccfg = &ConsumerConfig{BackOff: []time.Duration{-time.Second}}
-	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false)
+	err = checkConsumerCfg(ccfg, &JSLimitOpts{}, &scfg, nil, &JetStreamAccountLimits{}, false, false)
 	require_True(t, err != nil)
 	require_Error(t, err, NewJSConsumerBackOffNegativeError())
 
@@ -21786,12 +21929,12 @@ func TestJetStreamInvalidStreamOrConsumerName(t *testing.T) {
 		require_Contains(t, err.Description, "can not contain")
 
 		ccfg := &ConsumerConfig{Name: name}
-		err = checkConsumerCfg(ccfg, nil, nil, nil, nil, false)
+		err = checkConsumerCfg(ccfg, nil, nil, nil, nil, false, false)
 		require_NotNil(t, err)
 		require_Contains(t, err.Description, "can not contain")
 
 		ccfg = &ConsumerConfig{Durable: name}
-		err = checkConsumerCfg(ccfg, nil, nil, nil, nil, false)
+		err = checkConsumerCfg(ccfg, nil, nil, nil, nil, false, false)
 		require_NotNil(t, err)
 		require_Contains(t, err.Description, "can not contain")
 	}
@@ -24869,3 +25012,452 @@ func TestJetStreamStreamSubjectsOverlapDataRace(t *testing.T) {
 	close(stop)
 	wg.Wait()
 }
+
+func TestJetStreamMaxConcurrentSnapshotsAndRestores(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, max_concurrent_snapshots: 1, max_concurrent_restores: 1}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	_, err = js.Publish("foo", []byte("ok"))
+	require_NoError(t, err)
+
+	snapshot := func() *ApiError {
+		t.Helper()
+		// Nobody listens on the deliver subject, so the snapshot will
+		// hold its slot until giving up waiting for interest.
+		req, _ := json.Marshal(&JSApiStreamSnapshotRequest{DeliverSubject: nats.NewInbox()})
+		rmsg, err := nc.Request(fmt.Sprintf(JSApiStreamSnapshotT, "TEST"), req, time.Second)
+		require_NoError(t, err)
+		var resp JSApiStreamSnapshotResponse
+		require_NoError(t, json.Unmarshal(rmsg.Data, &resp))
+		return resp.Error
+	}
+	require_True(t, snapshot() == nil)
+	apiErr := snapshot()
+	require_NotNil(t, apiErr)
+	require_Equal(t, apiErr.ErrCode, uint16(JSStreamSnapshotTooManyConcurrentErr))
+	checkFor(t, 5*time.Second, 250*time.Millisecond, func() error {
+		if apiErr := snapshot(); apiErr != nil {
+			return apiErr
+		}
+		return nil
+	})
+
+	restore := func(name string) *JSApiStreamRestoreResponse {
+		t.Helper()
+		req, _ := json.Marshal(&JSApiStreamRestoreRequest{Config: StreamConfig{Name: name, Storage: FileStorage}})
+		rmsg, err := nc.Request(fmt.Sprintf(JSApiStreamRestoreT, name), req, time.Second)
+		require_NoError(t, err)
+		var resp JSApiStreamRestoreResponse
+		require_NoError(t, json.Unmarshal(rmsg.Data, &resp))
+		return &resp
+	}
+	resp := restore("R1")
+	require_True(t, resp.Error == nil)
+	resp2 := restore("R2")
+	require_NotNil(t, resp2.Error)
+	require_Equal(t, resp2.Error.ErrCode, uint16(JSStreamRestoreTooManyConcurrentErr))
+
+	// Ending the first restore, even if it fails, releases its slot.
+	_, err = nc.Request(resp.DeliverSubject, nil, time.Second)
+	require_NoError(t, err)
+	checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+		if resp = restore("R3"); resp.Error != nil {
+			return resp.Error
+		}
+		return nil
+	})
+	_, err = nc.Request(resp.DeliverSubject, nil, time.Second)
+	require_NoError(t, err)
+}
+
+func TestJetStreamMaxDuplicateEntries(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {
+			store_dir: %q
+			limits: {max_duplicate_entries: 2}
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}, Duplicates: time.Minute})
+	require_NoError(t, err)
+
+	publish := func(id string) *nats.PubAck {
+		t.Helper()
+		pa, err := js.Publish("foo", nil, nats.MsgId(id))
+		require_NoError(t, err)
+		return pa
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		require_False(t, publish(id).Duplicate)
+	}
+
+	// Only the latest two ids are tracked even though still in the window.
+	si, err := js.StreamInfo("TEST")
+	require_NoError(t, err)
+	require_Equal(t, si.State.Msgs, 3)
+
+	var resp JSApiStreamInfoResponse
+	msg, err := nc.Request(fmt.Sprintf(JSApiStreamInfoT, "TEST"), nil, time.Second)
+	require_NoError(t, err)
+	require_NoError(t, json.Unmarshal(msg.Data, &resp))
+	require_Equal(t, resp.DedupEntries, 2)
+
+	require_True(t, publish("3").Duplicate)
+	require_False(t, publish("1").Duplicate)
+
+	// Must not be negative.
+	conf = createConfFile(t, []byte(`
+		jetstream: {
+			limits: {max_duplicate_entries: -1}
+		}
+	`))
+	_, err = ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "max_duplicate_entries must be positive")
+}
+
+func TestJetStreamAccountMaxBufferedOverride(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {
+			store_dir: %q
+			max_buffered_msgs: 500
+			max_buffered_size: 2MB
+		}
+		accounts {
+			A { jetstream: {max_buffered_msgs: 100, max_buffered_size: 1MB}, users: [{user: a, password: pwd}] }
+			B { jetstream: enabled, users: [{user: b, password: pwd}] }
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	for _, test := range []struct {
+		user string
+		msgs int
+		size int64
+	}{
+		{"a", 100, 1024 * 1024},
+		{"b", 500, 2 * 1024 * 1024},
+	} {
+		t.Run(test.user, func(t *testing.T) {
+			nc, js := jsClientConnect(t, s, nats.UserInfo(test.user, "pwd"))
+			defer nc.Close()
+
+			_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+			require_NoError(t, err)
+
+			msg, err := nc.Request(fmt.Sprintf(JSApiStreamInfoT, "TEST"), nil, time.Second)
+			require_NoError(t, err)
+			var resp JSApiStreamInfoResponse
+			require_NoError(t, json.Unmarshal(msg.Data, &resp))
+			require_True(t, resp.Error == nil)
+			require_Equal(t, resp.MaxBufferedMsgs, test.msgs)
+			require_Equal(t, resp.MaxBufferedSize, test.size)
+		})
+	}
+}
+
+func TestJetStreamDisallowNetworkStorage(t *testing.T) {
+	switch runtime.GOOS {
+	case "linux", "darwin", "freebsd":
+	default:
+		t.Skip("Filesystem type detection not supported on this platform")
+	}
+	storeDir := t.TempDir()
+	fstype, err := networkFilesystemType(storeDir)
+	require_NoError(t, err)
+	if fstype != _EMPTY_ {
+		t.Skipf("Test directory is on a network filesystem: %s", fstype)
+	}
+
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, disallow_network_storage: true}
+	`, storeDir)))
+	s, opts := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	require_True(t, opts.JetStreamDisallowNetworkStorage)
+	require_True(t, s.JetStreamEnabled())
+}
+
+func TestJetStreamAuditSubject(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, audit_subject: "audit.js"}
+		accounts {
+			A { jetstream: enabled, users = [ { user: "a", pass: "pwd" } ] }
+			$SYS { users = [ { user: "admin", pass: "s3cr3t!" } ] }
+		}
+	`, t.TempDir())))
+	s, opts := RunServerWithConfig(conf)
+	defer s.Shutdown()
+	require_Equal(t, opts.JetStreamAuditSubject, "audit.js")
+
+	snc := natsConnect(t, s.ClientURL(), nats.UserInfo("admin", "s3cr3t!"))
+	defer snc.Close()
+	sub := natsSubSync(t, snc, "audit.js")
+	require_NoError(t, snc.Flush())
+
+	nc, js := jsClientConnect(t, s, nats.UserInfo("a", "pwd"))
+	defer nc.Close()
+
+	checkEvent := func(action ActionAdvisoryType, stream, consumer string) JSAuditEvent {
+		t.Helper()
+		var ev JSAuditEvent
+		require_NoError(t, json.Unmarshal(natsNexMsg(t, sub, time.Second).Data, &ev))
+		require_Equal(t, ev.Type, JSAuditEventType)
+		require_Equal(t, ev.Action, action)
+		require_Equal(t, ev.Account, "A")
+		require_Equal(t, ev.Stream, stream)
+		require_Equal(t, ev.Consumer, consumer)
+		require_True(t, ev.Client != nil)
+		require_Equal(t, ev.Client.User, "a")
+		require_False(t, ev.Time.IsZero())
+		return ev
+	}
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	checkEvent(CreateEvent, "TEST", _EMPTY_)
+
+	_, err = js.UpdateStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo", "bar"}})
+	require_NoError(t, err)
+	checkEvent(ModifyEvent, "TEST", _EMPTY_)
+
+	// A failed request is not audited.
+	_, err = js.AddStream(&nats.StreamConfig{Name: "OTHER", Subjects: []string{"foo"}})
+	require_Error(t, err)
+
+	req := CreateConsumerRequest{Stream: "TEST", Config: ConsumerConfig{Durable: "C", AckPolicy: AckExplicit}, Action: ActionCreate}
+	data, err := json.Marshal(req)
+	require_NoError(t, err)
+	_, err = nc.Request(fmt.Sprintf(JSApiDurableCreateT, "TEST", "C"), data, time.Second)
+	require_NoError(t, err)
+	ev := checkEvent(CreateEvent, "TEST", "C")
+	require_Equal(t, ev.ConsumerAction, "create")
+
+	require_NoError(t, js.DeleteConsumer("TEST", "C"))
+	checkEvent(DeleteEvent, "TEST", "C")
+
+	require_NoError(t, js.DeleteStream("TEST"))
+	checkEvent(DeleteEvent, "TEST", _EMPTY_)
+
+	// Non lifecycle requests are not audited.
+	_, err = js.AccountInfo()
+	require_NoError(t, err)
+	_, err = sub.NextMsg(250 * time.Millisecond)
+	require_Error(t, err, nats.ErrTimeout)
+
+	conf = createConfFile(t, []byte(`jetstream: {audit_subject: "audit.*"}`))
+	_, err = ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "audit_subject")
+}
+
+func TestJetStreamPreloadStreams(t *testing.T) {
+	storeDir := t.TempDir()
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, preload_streams: ["A:TEST", "A:MISSING"]}
+		accounts { A { jetstream: enabled, users = [ { user: "a", pass: "pwd" } ] } }
+	`, storeDir)))
+	s, opts := RunServerWithConfig(conf)
+	defer s.Shutdown()
+	require_Equal(t, len(opts.JetStreamPreloadStreams), 2)
+
+	nc, js := jsClientConnect(t, s, nats.UserInfo("a", "pwd"))
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo.*"}})
+	require_NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = js.Publish(fmt.Sprintf("foo.%d", i), nil)
+		require_NoError(t, err)
+	}
+	nc.Close()
+	s.Shutdown()
+
+	s, _ = RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	jsz, err := s.Jsz(nil)
+	require_NoError(t, err)
+	require_Equal(t, jsz.PreloadStreams["A:TEST"], streamPreloadLoaded)
+	require_Equal(t, jsz.PreloadStreams["A:MISSING"], streamPreloadNotFound)
+
+	acc, err := s.LookupAccount("A")
+	require_NoError(t, err)
+	mset, err := acc.lookupStream("TEST")
+	require_NoError(t, err)
+	require_Equal(t, mset.state().Msgs, 10)
+
+	for _, v := range []string{`"TEST"`, `":TEST"`, `"A:"`, `"A:TE.ST"`} {
+		conf := createConfFile(t, []byte(fmt.Sprintf(`jetstream: {preload_streams: [%s]}`, v)))
+		_, err := ProcessConfigFile(conf)
+		require_Error(t, err)
+		require_Contains(t, err.Error(), "account:stream")
+	}
+}
+
+func TestJetStreamAllowedAccounts(t *testing.T) {
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		jetstream: {store_dir: %q, allowed_accounts: ["A"]}
+		accounts {
+			A { jetstream: enabled, users = [ { user: "a", pass: "pwd" } ] }
+			B { jetstream: enabled, users = [ { user: "b", pass: "pwd" } ] }
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	accA, err := s.LookupAccount("A")
+	require_NoError(t, err)
+	require_True(t, accA.JetStreamEnabled())
+	accB, err := s.LookupAccount("B")
+	require_NoError(t, err)
+	require_False(t, accB.JetStreamEnabled())
+	require_Error(t, accB.EnableJetStream(nil, nil), NewJSNotAllowedForAccountError())
+
+	nc, js := jsClientConnect(t, s, nats.UserInfo("a", "pwd"))
+	defer nc.Close()
+	_, err = js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+
+	ncb := natsConnect(t, s.ClientURL(), nats.UserInfo("b", "pwd"))
+	defer ncb.Close()
+	resp, err := ncb.Request(JSApiAccountInfo, nil, time.Second)
+	require_NoError(t, err)
+	var info JSApiAccountInfoResponse
+	require_NoError(t, json.Unmarshal(resp.Data, &info))
+	require_Error(t, info.Error, NewJSNotAllowedForAccountError())
+
+	conf = createConfFile(t, []byte(`jetstream: {allowed_accounts: ["A", "B*"]}`))
+	_, err = ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "Expected a valid account name")
+}
+
+func TestJetStreamOnCorruptRecovery(t *testing.T) {
+	for _, action := range []string{_EMPTY_, JsCorruptRecoverySkip, JsCorruptRecoveryQuarantine, JsCorruptRecoveryFail} {
+		name := action
+		if name == _EMPTY_ {
+			name = "default"
+		}
+		t.Run(name, func(t *testing.T) {
+			s := RunBasicJetStreamServer(t)
+			defer s.Shutdown()
+
+			nc, js := jsClientConnect(t, s)
+			defer nc.Close()
+
+			for _, name := range []string{"TEST", "OK"} {
+				_, err := js.AddStream(&nats.StreamConfig{Name: name, Subjects: []string{name}})
+				require_NoError(t, err)
+			}
+
+			sd := s.JetStreamConfig().StoreDir
+			sdir := filepath.Join(sd, "$G", "streams", "TEST")
+			nc.Close()
+			s.Shutdown()
+
+			// Corrupt the checksum of the stream metafile.
+			err := os.WriteFile(filepath.Join(sdir, JetStreamMetaFileSum), []byte("bad"), defaultFilePerms)
+			require_NoError(t, err)
+
+			opts := DefaultTestOptions
+			opts.Port = -1
+			opts.JetStream = true
+			opts.StoreDir = filepath.Dir(sd)
+			opts.JetStreamOnCorruptRecovery = action
+
+			// Failing stops the server from starting.
+			if action == JsCorruptRecoveryFail {
+				s, err = NewServer(&opts)
+				require_NoError(t, err)
+				defer s.Shutdown()
+				l := &captureFatalLogger{fatalCh: make(chan string, 1)}
+				s.SetLogger(l, false, false)
+				s.Start()
+				select {
+				case e := <-l.fatalCh:
+					require_Contains(t, e, "Can't start JetStream", "corrupted stream '$G > TEST'")
+				case <-time.After(5 * time.Second):
+					t.Fatal("Should have got a fatal error")
+				}
+				return
+			}
+
+			s = RunServer(&opts)
+			defer s.Shutdown()
+
+			nc, js = jsClientConnect(t, s)
+			defer nc.Close()
+
+			_, err = js.StreamInfo("OK")
+			require_NoError(t, err)
+			_, err = js.StreamInfo("TEST")
+			require_Error(t, err)
+
+			quarantined, err := filepath.Glob(filepath.Join(sd, "$G", quarantineDir, streamsDir, "TEST.*"))
+			require_NoError(t, err)
+			_, err = os.Stat(sdir)
+			if action != JsCorruptRecoveryQuarantine {
+				require_NoError(t, err)
+				require_Len(t, len(quarantined), 0)
+			} else {
+				require_True(t, os.IsNotExist(err))
+				require_Len(t, len(quarantined), 1)
+			}
+		})
+	}
+}
+
+func TestJetStreamOnCorruptRecoveryQuarantineSkipsUndecryptable(t *testing.T) {
+	tmpl := `
+		listen: 127.0.0.1:-1
+		jetstream: {key: %s, store_dir: '%s', on_corrupt_recovery: quarantine}
+	`
+	storeDir := t.TempDir()
+
+	conf := createConfFile(t, []byte(fmt.Sprintf(tmpl, "s3cr3t", storeDir)))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, js := jsClientConnect(t, s)
+	defer nc.Close()
+
+	_, err := js.AddStream(&nats.StreamConfig{Name: "TEST", Subjects: []string{"foo"}})
+	require_NoError(t, err)
+	nc.Close()
+	s.Shutdown()
+
+	// Restarting with the wrong key must not move the stream aside.
+	conf = createConfFile(t, []byte(fmt.Sprintf(tmpl, "wr0ng", storeDir)))
+	s, _ = RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	sd := s.JetStreamConfig().StoreDir
+	_, err = os.Stat(filepath.Join(sd, "$G", streamsDir, "TEST"))
+	require_NoError(t, err)
+	quarantined, err := filepath.Glob(filepath.Join(sd, "$G", quarantineDir, streamsDir, "TEST.*"))
+	require_NoError(t, err)
+	require_Len(t, len(quarantined), 0)
+}
@@ -329,6 +329,58 @@ func TestJWTUserExpiresAfterConnect(t *testing.T) {
 	}
 }
 
+func TestJWTUserMaxJWTAge(t *testing.T) {
+	s := opTrustBasicSetup()
+	defer s.Shutdown()
+	buildMemAccResolver(s)
+	// JWT issue times have one-second resolution. Leave a full second for setup.
+	s.optsMu.Lock()
+	s.opts.MaxUserJWTAge = 2 * time.Second
+	s.optsMu.Unlock()
+
+	akp, _ := nkeys.CreateAccount()
+	apub, _ := akp.PublicKey()
+	nac := jwt.NewAccountClaims(apub)
+	ajwt, err := nac.Encode(oKp)
+	if err != nil {
+		t.Fatalf("Error generating account JWT: %v", err)
+	}
+	addAccountToMemResolver(s, apub, ajwt)
+
+	nkp, _ := nkeys.CreateUser()
+	pub, _ := nkp.PublicKey()
+	nuc := jwt.NewUserClaims(pub)
+	ujwt, err := nuc.Encode(akp)
+	if err != nil {
+		t.Fatalf("Error generating user JWT: %v", err)
+	}
+
+	connect := func(expected string) {
+		t.Helper()
+		c, cr, l := newClientForServer(s)
+		defer c.close()
+
+		var info nonceInfo
+		json.Unmarshal([]byte(l[5:]), &info)
+		sigraw, _ := nkp.Sign([]byte(info.Nonce))
+		sig := base64.RawURLEncoding.EncodeToString(sigraw)
+
+		cs := fmt.Sprintf("CONNECT {\"jwt\":%q,\"sig\":\"%s\"}\r\nPING\r\n", ujwt, sig)
+		go c.parse([]byte(cs))
+		l, _ = cr.ReadString('\n')
+		if !strings.HasPrefix(l, expected) {
+			t.Fatalf("Expected %q, got %q", expected, l)
+		}
+	}
+
+	// A recently issued JWT is accepted.
+	connect("PONG")
+
+	// Once past the max age the same JWT is rejected, even though it does not expire.
+	time.Sleep(3 * time.Second)
+	connect("-ERR ")
+}
+
 func TestJWTUserPermissionClaims(t *testing.T) {
 	nuc := newJWTTestUserClaims()
 	nuc.Permissions.Pub.Allow.Add("foo")
@@ -622,6 +674,94 @@ func TestJWTAccountExpiresAfterConnect(t *testing.T) {
 	}
 }
 
+func TestJWTAccountExpiresAfterConnectWithGrace(t *testing.T) {
+	nac := newJWTTestAccountClaims()
+	now := time.Now()
+	nac.IssuedAt = now.Add(-10 * time.Second).Unix()
+	nac.Expires = now.Round(time.Second).Add(time.Second).Unix()
+	s, akp, c, cr := setupJWTTestWitAccountClaims(t, nac, "+OK")
+	defer s.Shutdown()
+	defer c.close()
+
+	s.optsMu.Lock()
+	s.opts.AccountExpirationGrace = 1500 * time.Millisecond
+	s.optsMu.Unlock()
+
+	apub, _ := akp.PublicKey()
+	acc, err := s.LookupAccount(apub)
+	if acc == nil || err != nil {
+		t.Fatalf("Expected to retrieve the account")
+	}
+
+	if l, _ := cr.ReadString('\n'); !strings.HasPrefix(l, "PONG") {
+		t.Fatalf("Expected PONG, got %q", l)
+	}
+
+	// Wait for the account to be expired.
+	checkFor(t, 3*time.Second, 50*time.Millisecond, func() error {
+		if acc.IsExpired() {
+			return nil
+		}
+		return fmt.Errorf("Account not expired yet")
+	})
+
+	// The existing client should still be connected.
+	time.Sleep(250 * time.Millisecond)
+	if n := acc.NumLocalConnections(); n != 1 {
+		t.Fatalf("Expected existing client to still be connected, got %v connections", n)
+	}
+
+	// But new connections should be rejected.
+	nc, ncr, cs := createClient(t, s, akp)
+	defer nc.close()
+	nc.parseAsync(cs)
+	if l, _ := ncr.ReadString('\n'); !strings.HasPrefix(l, "-ERR ") {
+		t.Fatalf("Expected an error, got %q", l)
+	}
+
+	// After the grace period, the existing client should be disconnected.
+	l, _ := cr.ReadString('\n')
+	if !strings.HasPrefix(l, "-ERR ") || !strings.Contains(l, "Expired") {
+		t.Fatalf("Expected an expired error, got %q", l)
+	}
+	checkFor(t, time.Second, 50*time.Millisecond, func() error {
+		if n := acc.NumLocalConnections(); n != 0 {
+			return fmt.Errorf("Expected no connection, got %v", n)
+		}
+		return nil
+	})
+}
+
+func TestJWTAccountExpirationGraceConfig(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		grace string
+		err   string
+	}{
+		{"valid", "5m", _EMPTY_},
+		{"negative", "-5m", "cannot be negative"},
+		{"invalid", "abc", "error parsing expiration_grace"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			conf := createConfFile(t, []byte(fmt.Sprintf(`
+				resolver {
+					type: full
+					dir: %q
+					expiration_grace: %q
+				}
+			`, t.TempDir(), test.grace)))
+			opts, err := ProcessConfigFile(conf)
+			if test.err != _EMPTY_ {
+				require_Error(t, err)
+				require_Contains(t, err.Error(), test.err)
+				return
+			}
+			require_NoError(t, err)
+			require_Equal(t, opts.AccountExpirationGrace, 5*time.Minute)
+		})
+	}
+}
+
 func TestJWTAccountRenew(t *testing.T) {
 	nac := newJWTTestAccountClaims()
 	// Create an account that has expired.
@@ -1740,6 +1880,67 @@ func TestJWTAccountURLResolverTimeout(t *testing.T) {
 	}
 }
 
+func TestJWTAccountURLResolverUseLastKnownGood(t *testing.T) {
+	for _, use := range []bool{true, false} {
+		t.Run(fmt.Sprintf("%t", use), func(t *testing.T) {
+			kp, _ := nkeys.FromSeed(oSeed)
+			akp, _ := nkeys.CreateAccount()
+			apub, _ := akp.PublicKey()
+			nac := jwt.NewAccountClaims(apub)
+			ajwt, err := nac.Encode(kp)
+			require_NoError(t, err)
+
+			var fail atomic.Bool
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if fail.Load() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(ajwt))
+			}))
+			defer ts.Close()
+
+			conf := createConfFile(t, []byte(fmt.Sprintf(`
+				operator: %s
+				listen: 127.0.0.1:-1
+				resolver: {
+					type: URL
+					url: "%s/ngs/v1/accounts/jwt/"
+					use_last_known_good_on_fetch_error: %t
+				}
+			`, ojwt, ts.URL, use)))
+			s, _ := RunServerWithConfig(conf)
+			defer s.Shutdown()
+			require_True(t, s.getOpts().ResolverUseLastKnownGood == use)
+
+			_, claimJWT, err := s.fetchAccountClaims(apub)
+			require_NoError(t, err)
+			require_Equal(t, claimJWT, ajwt)
+
+			// Fetching again while the resolver is failing.
+			fail.Store(true)
+			_, claimJWT, err = s.fetchAccountClaims(apub)
+			if use {
+				require_NoError(t, err)
+				require_Equal(t, claimJWT, ajwt)
+			} else {
+				require_Error(t, err)
+			}
+		})
+	}
+
+	conf := createConfFile(t, []byte(`
+		resolver: {
+			type: URL
+			url: "http://127.0.0.1:1/"
+			use_last_known_good_on_fetch_error: "yes"
+		}
+	`))
+	_, err := ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "use_last_known_good_on_fetch_error should be a boolean")
+}
+
 func TestJWTAccountURLResolverNoFetchOnReload(t *testing.T) {
 	kp, _ := nkeys.FromSeed(oSeed)
 	akp, _ := nkeys.CreateAccount()
@@ -7853,3 +8054,193 @@ func TestJWTUserLimitsOverflowInt32SubPub(t *testing.T) {
 		}
 	})
 }
+
+func TestJWTAccountResolverLimitPercentage(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := `
+		resolver: {
+			type: %s
+			dir: '%s'
+			limit: %s
+		}
+	`
+	for _, typ := range []string{"full", "cache"} {
+		conf := createConfFile(t, []byte(fmt.Sprintf(tmpl, typ, dir, `"50%"`)))
+		opts, err := ProcessConfigFile(conf)
+		require_NoError(t, err)
+		var dr *DirAccResolver
+		switch res := opts.AccountResolver.(type) {
+		case *DirAccResolver:
+			dr = res
+		case *CacheDirAccResolver:
+			dr = &res.DirAccResolver
+		}
+		require_NotNil(t, dr)
+		require_Equal(t, dr.limitPct, 50)
+		// Only resolved when the resolver starts.
+		require_Equal(t, dr.DirJWTStore.expiration.limit, math.MaxInt64)
+		s := &Server{}
+		s.SetLogger(&DummyLogger{}, false, false)
+		dr.Lock()
+		dr.resolveLimit(s)
+		dr.Unlock()
+		require_Equal(t, dr.DirJWTStore.expiration.limit, dirResolverLimitFromDisk(dir, 50))
+		require_True(t, dr.DirJWTStore.expiration.limit < math.MaxInt64)
+		dr.Close()
+	}
+
+	// The absolute form keeps working.
+	conf := createConfFile(t, []byte(fmt.Sprintf(tmpl, "full", dir, "4")))
+	opts, err := ProcessConfigFile(conf)
+	require_NoError(t, err)
+	dr := opts.AccountResolver.(*DirAccResolver)
+	require_Equal(t, dr.DirJWTStore.expiration.limit, 4)
+	require_Equal(t, dr.limitPct, 0)
+	dr.Close()
+
+	for _, limit := range []string{`"0%"`, `"101%"`, `"50"`, `"x%"`} {
+		conf := createConfFile(t, []byte(fmt.Sprintf(tmpl, "full", dir, limit)))
+		_, err := ProcessConfigFile(conf)
+		require_Error(t, err)
+		require_Contains(t, err.Error(), "resolver limit percentage must be between 1% and 100%")
+	}
+}
+
+func TestJWTResolverMaxActiveAccounts(t *testing.T) {
+	okp, _ := nkeys.FromSeed(oSeed)
+	dir := t.TempDir()
+	newAccount := func() (string, nkeys.KeyPair) {
+		t.Helper()
+		akp, _ := nkeys.CreateAccount()
+		apub, _ := akp.PublicKey()
+		ajwt, err := jwt.NewAccountClaims(apub).Encode(okp)
+		require_NoError(t, err)
+		writeJWT(t, dir, apub, ajwt)
+		return apub, akp
+	}
+	syspub, _ := newAccount()
+	apub, _ := newAccount()
+	bpub, bkp := newAccount()
+	cpub, _ := newAccount()
+
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		operator: %s
+		system_account: %s
+		resolver: {
+			type: full
+			dir: '%s'
+			max_active_accounts: 2
+		}
+	`, ojwt, syspub, dir)))
+	s, opts := RunServerWithConfig(conf)
+	defer s.Shutdown()
+	require_Equal(t, opts.ResolverMaxActiveAccounts, 2)
+
+	active := func(pub string) bool {
+		_, ok := s.accounts.Load(pub)
+		return ok
+	}
+
+	for _, pub := range []string{apub, bpub, cpub} {
+		_, err := s.LookupAccount(pub)
+		require_NoError(t, err)
+	}
+	// The least recently used account was evicted.
+	require_False(t, active(apub))
+	require_True(t, active(bpub))
+	require_True(t, active(cpub))
+
+	// Accounts with connections are never evicted.
+	nc := natsConnect(t, s.ClientURL(), createUserCreds(t, nil, bkp))
+	defer nc.Close()
+	_, err := s.LookupAccount(apub)
+	require_NoError(t, err)
+	require_True(t, active(apub))
+	require_True(t, active(bpub))
+	require_False(t, active(cpub))
+}
+
+func TestJWTResolverMaxActiveAccountsRemoteInterest(t *testing.T) {
+	okp, _ := nkeys.FromSeed(oSeed)
+	dirA, dirB := t.TempDir(), t.TempDir()
+	newAccount := func(claim func(ac *jwt.AccountClaims)) (string, nkeys.KeyPair) {
+		t.Helper()
+		akp, _ := nkeys.CreateAccount()
+		apub, _ := akp.PublicKey()
+		ac := jwt.NewAccountClaims(apub)
+		if claim != nil {
+			claim(ac)
+		}
+		ajwt, err := ac.Encode(okp)
+		require_NoError(t, err)
+		writeJWT(t, dirA, apub, ajwt)
+		writeJWT(t, dirB, apub, ajwt)
+		return apub, akp
+	}
+	syspub, _ := newAccount(nil)
+	apub, akp := newAccount(nil)
+	bpub, _ := newAccount(nil)
+	cpub, _ := newAccount(func(ac *jwt.AccountClaims) {
+		ac.Exports.Add(&jwt.Export{Subject: "bar", Type: jwt.Stream})
+	})
+	dpub, _ := newAccount(func(ac *jwt.AccountClaims) {
+		ac.Imports.Add(&jwt.Import{Account: cpub, Subject: "bar", Type: jwt.Stream})
+	})
+	epub, _ := newAccount(nil)
+
+	tmpl := `
+		listen: 127.0.0.1:-1
+		server_name: %s
+		operator: %s
+		system_account: %s
+		resolver: {
+			type: full
+			dir: '%s'
+			max_active_accounts: 2
+		}
+		cluster {
+			name: clust
+			listen: 127.0.0.1:-1
+			%s
+		}
+	`
+	confA := createConfFile(t, []byte(fmt.Sprintf(tmpl, "A", ojwt, syspub, dirA, _EMPTY_)))
+	sA, _ := RunServerWithConfig(confA)
+	defer sA.Shutdown()
+	routes := fmt.Sprintf("routes: [nats-route://127.0.0.1:%d]", sA.ClusterAddr().Port)
+	confB := createConfFile(t, []byte(fmt.Sprintf(tmpl, "B", ojwt, syspub, dirB, routes)))
+	sB, _ := RunServerWithConfig(confB)
+	defer sB.Shutdown()
+	checkClusterFormed(t, sA, sB)
+
+	// A subscription on B is only known to A through the route.
+	nc := natsConnect(t, sB.ClientURL(), createUserCreds(t, nil, akp))
+	defer nc.Close()
+	sub := natsSubSync(t, nc, "foo")
+	natsFlush(t, nc)
+	checkSubInterest(t, sA, apub, "foo", time.Second)
+	acc, err := sA.LookupAccount(apub)
+	require_NoError(t, err)
+
+	for _, pub := range []string{bpub, dpub, epub} {
+		_, err := sA.LookupAccount(pub)
+		require_NoError(t, err)
+	}
+	// Neither the account with route interest nor the one imported from are evicted.
+	for _, pub := range []string{apub, cpub} {
+		v, ok := sA.accounts.Load(pub)
+		require_True(t, ok)
+		if pub == apub {
+			require_True(t, v.(*Account) == acc)
+		}
+	}
+	_, ok := sA.accounts.Load(bpub)
+	require_False(t, ok)
+
+	// Messages published on A still reach the subscription on B.
+	ncA := natsConnect(t, sA.ClientURL(), createUserCreds(t, nil, akp))
+	defer ncA.Close()
+	natsPub(t, ncA, "foo", []byte("hello"))
+	natsNexMsg(t, sub, time.Second)
+}
@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
@@ -29,6 +30,7 @@ import (
 	"path"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -65,6 +67,10 @@ const (
 	// When a soliciting leafnode is rejected because it does not meet the
 	// configured minimum version, delay the next reconnect attempt by this long.
 	leafNodeMinVersionReconnectDelay = 5 * time.Second
+
+	// Maximum delay before reconnecting to a remote that repeatedly
+	// accepts the TCP connection but does not send the INFO protocol.
+	leafNodeFirstInfoTimeoutMaxDelay = 30 * time.Second
 )
 
 type leaf struct {
@@ -117,6 +123,10 @@ type leafNodeCfg struct {
 	quitCh         chan struct{}
 	removed        bool
 	connInProgress bool
+	// Total and consecutive number of connections that failed because
+	// the remote did not send the INFO protocol in time.
+	firstInfoTimeouts    uint64
+	firstInfoTimeoutsSeq int
 }
 
 // Check to see if this is a solicited leafnode. We do special processing for solicited.
@@ -457,6 +467,14 @@ func (s *Server) reConnectToRemoteLeafNode(remote *leafNodeCfg) {
 	clearInProgress = !connectToRemoteLeafNode(s, remote, false)
 }
 
+// Returns the local publish and subscribe deny clauses for the leaf connection
+// of this remote, combining deny_exports/deny_imports with deny_publish/deny_subscribe.
+func (r *RemoteLeafOpts) localDenies() (denyPub, denySub []string) {
+	denyPub = append(slices.Clone(r.DenyExports), r.DenyPublish...)
+	denySub = append(slices.Clone(r.DenyImports), r.DenySubscribe...)
+	return denyPub, denySub
+}
+
 // Creates a leafNodeCfg object that wraps the RemoteLeafOpts.
 func newLeafNodeCfg(remote *RemoteLeafOpts) *leafNodeCfg {
 	cfg := &leafNodeCfg{
@@ -464,13 +482,13 @@ func newLeafNodeCfg(remote *RemoteLeafOpts) *leafNodeCfg {
 		urls:           make([]*url.URL, 0, len(remote.URLs)),
 		quitCh:         make(chan struct{}, 1),
 	}
-	if len(remote.DenyExports) > 0 || len(remote.DenyImports) > 0 {
+	if denyPub, denySub := remote.localDenies(); len(denyPub) > 0 || len(denySub) > 0 {
 		perms := &Permissions{}
-		if len(remote.DenyExports) > 0 {
-			perms.Publish = &SubjectPermission{Deny: remote.DenyExports}
+		if len(denyPub) > 0 {
+			perms.Publish = &SubjectPermission{Deny: denyPub}
 		}
-		if len(remote.DenyImports) > 0 {
-			perms.Subscribe = &SubjectPermission{Deny: remote.DenyImports}
+		if len(denySub) > 0 {
+			perms.Subscribe = &SubjectPermission{Deny: denySub}
 		}
 		cfg.perms = perms
 	}
@@ -583,6 +601,33 @@ func (cfg *leafNodeCfg) setConnectDelay(delay time.Duration) {
 	cfg.Unlock()
 }
 
+// Records a connection failure due to the remote not sending the INFO
+// protocol within the first info timeout and returns the number of
+// consecutive such failures and the delay to apply before reconnecting.
+// The delay doubles with each consecutive failure, starting at the
+// given reconnect interval, and is capped to leafNodeFirstInfoTimeoutMaxDelay.
+func (cfg *leafNodeCfg) firstInfoTimeoutFailure(reconnectInterval time.Duration) (int, time.Duration) {
+	cfg.Lock()
+	defer cfg.Unlock()
+	cfg.firstInfoTimeouts++
+	cfg.firstInfoTimeoutsSeq++
+	delay := reconnectInterval
+	for i := 1; i < cfg.firstInfoTimeoutsSeq && delay < leafNodeFirstInfoTimeoutMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, leafNodeFirstInfoTimeoutMaxDelay)
+	cfg.connDelay = delay
+	return cfg.firstInfoTimeoutsSeq, delay
+}
+
+// Returns the total number of connection failures due to the remote
+// not sending the INFO protocol in time.
+func (cfg *leafNodeCfg) getFirstInfoTimeouts() uint64 {
+	cfg.RLock()
+	defer cfg.RUnlock()
+	return cfg.firstInfoTimeouts
+}
+
 // Ensure that non-exported options (used in tests) have
 // been properly set.
 func (s *Server) setLeafNodeNonExportedOptions() {
@@ -1006,6 +1051,7 @@ func (s *Server) startLeafNodeAcceptLoop() {
 		Headers:       s.supportsHeaders(),
 		JetStream:     opts.JetStream,
 		Domain:        opts.JetStreamDomain,
+		Tags:          copyStrings(opts.Tags),
 		Proto:         s.getServerProto(),
 		InfoOnConnect: true,
 		JSApiLevel:    JSApiLevel,
@@ -1233,11 +1279,7 @@ func (s *Server) createLeafNode(conn net.Conn, rURL *url.URL, remote *leafNodeCf
 	opts := s.getOpts()
 
 	maxPay := int32(opts.MaxPayload)
-	maxSubs := int32(opts.MaxSubs)
-	// For system, maxSubs of 0 means unlimited, so re-adjust here.
-	if maxSubs == 0 {
-		maxSubs = -1
-	}
+	maxSubs := maxSubsLimit(opts.MaxSubs)
 	now := time.Now().UTC()
 
 	c := &client{srv: s, nc: conn, kind: LEAF, opts: defaultOpts, mpay: maxPay, msubs: maxSubs, start: now, last: now}
@@ -1624,6 +1666,12 @@ func (c *client) processLeafnodeInfo(info *Info) {
 	if firstINFO {
 		// Mark that the INFO protocol has been received.
 		c.flags.set(infoReceived)
+		// Reset the backoff applied to remotes not sending INFO in time.
+		if remote := c.leaf.remote; remote != nil {
+			remote.Lock()
+			remote.firstInfoTimeoutsSeq = 0
+			remote.Unlock()
+		}
 		// Prevent connecting to non leafnode port. Need to do this only for
 		// the first INFO, not for async INFO updates...
 		//
@@ -1694,17 +1742,18 @@ func (c *client) processLeafnodeInfo(info *Info) {
 		}
 		// Check if we have local deny clauses that we need to merge.
 		if remote := c.leaf.remote; remote != nil {
-			if len(remote.DenyExports) > 0 {
+			denyPub, denySub := remote.localDenies()
+			if len(denyPub) > 0 {
 				if perms.Publish == nil {
 					perms.Publish = &SubjectPermission{}
 				}
-				perms.Publish.Deny = append(perms.Publish.Deny, remote.DenyExports...)
+				perms.Publish.Deny = append(perms.Publish.Deny, denyPub...)
 			}
-			if len(remote.DenyImports) > 0 {
+			if len(denySub) > 0 {
 				if perms.Subscribe == nil {
 					perms.Subscribe = &SubjectPermission{}
 				}
-				perms.Subscribe.Deny = append(perms.Subscribe.Deny, remote.DenyImports...)
+				perms.Subscribe.Deny = append(perms.Subscribe.Deny, denySub...)
 			}
 		}
 		c.setPermissions(perms)
@@ -2087,7 +2136,7 @@ func (s *Server) addLeafNodeConnection(c *client, srvName, clusterName string, c
 	// If we have a specified JetStream domain we will want to add a mapping to
 	// allow access cross domain for each non-system account.
 	if opts.JetStreamDomain != _EMPTY_ && opts.JetStream && acc != nil && acc != sysAcc {
-		for src, dest := range generateJSMappingTable(opts.JetStreamDomain) {
+		for src, dest := range generateJSDomainMappingTable(opts.JetStreamDomain, opts.JetStreamDomainAliases) {
 			if err := acc.AddMapping(src, dest); err != nil {
 				c.Debugf("Error adding JetStream domain mapping: %s", err.Error())
 			} else {
@@ -2095,14 +2144,16 @@ func (s *Server) addLeafNodeConnection(c *client, srvName, clusterName string, c
 			}
 		}
 		if blockMappingOutgoing {
-			src := fmt.Sprintf(jsDomainAPI, opts.JetStreamDomain)
 			// make sure that messages intended for this domain, do not leave the cluster via this leaf node connection
 			// This is a guard against a miss-config with two identical domain names and will only cover some forms
 			// of this issue, not all of them.
 			// This guards against a hub and a spoke having the same domain name.
 			// But not two spokes having the same one and the request coming from the hub.
-			c.mergeDenyPermissionsLocked(pub, []string{src})
-			c.Debugf("Adding deny %q for outgoing messages to account %q", src, accName)
+			for _, domain := range append([]string{opts.JetStreamDomain}, opts.JetStreamDomainAliases...) {
+				src := fmt.Sprintf(jsDomainAPI, domain)
+				c.mergeDenyPermissionsLocked(pub, []string{src})
+				c.Debugf("Adding deny %q for outgoing messages to account %q", src, accName)
+			}
 		}
 	}
 	return true
@@ -2312,6 +2363,13 @@ func (c *client) processLeafNodeConnect(s *Server, arg []byte, lang string) erro
 		acc.registerLeafNodeCluster(proto.Cluster)
 	}
 
+	// Make sure the remote server does not bind more accounts than allowed.
+	if err := s.checkLeafNodeMaxAccounts(c, proto.Name, proto.Cluster, acc.Name); err != nil {
+		c.sendErrAndErr(err.Error())
+		c.closeConnection(MaxLeafNodeAccountsExceeded)
+		return err
+	}
+
 	// Add in the leafnode here since we passed through auth at this point.
 	s.addLeafNodeConnection(c, proto.Name, proto.Cluster, true)
 
@@ -2335,6 +2393,38 @@ func (c *client) processLeafNodeConnect(s *Server, arg []byte, lang string) erro
 	return nil
 }
 
+// Checks the number of distinct accounts bound by the accepted leafnode connections
+// of the given remote server against the max_accounts_per_connection limit.
+// Returns an error if binding `accName` would exceed it.
+func (s *Server) checkLeafNodeMaxAccounts(c *client, srvName, clusterName, accName string) error {
+	maxAccs := s.getOpts().LeafNode.MaxAccountsPerConnection
+	if maxAccs <= 0 || srvName == _EMPTY_ {
+		return nil
+	}
+	accs := make(map[string]struct{})
+	s.mu.RLock()
+	for _, l := range s.leafs {
+		if l == c {
+			continue
+		}
+		l.mu.Lock()
+		if !l.isSolicitedLeafNode() && l.acc != nil &&
+			l.leaf.remoteServer == srvName && l.leaf.remoteCluster == clusterName {
+			accs[l.acc.Name] = struct{}{}
+		}
+		l.mu.Unlock()
+	}
+	s.mu.RUnlock()
+	if _, ok := accs[accName]; ok || len(accs) < maxAccs {
+		return nil
+	}
+	c.mu.Lock()
+	addr := net.JoinHostPort(c.host, strconv.Itoa(int(c.port)))
+	c.mu.Unlock()
+	return fmt.Errorf("%w: leafnode %q from %s can not bind account %q, limit of %d accounts reached",
+		ErrLeafNodeMaxAccountsExceeded, srvName, addr, accName, maxAccs)
+}
+
 // checkInternalSyncConsumers
 func (s *Server) checkInternalSyncConsumers(acc *Account) {
 	// Grab our js
@@ -3512,6 +3602,35 @@ func (c *client) setLeafConnectDelayIfSoliciting(delay time.Duration) (string, t
 	return accName, delay
 }
 
+// Invoked when a read error occurs for a leafnode connection. If this is
+// a solicited connection that did not receive the INFO protocol yet and
+// the error is a timeout, this is a first info timeout failure: the
+// failure is logged and the reconnect is delayed according to a backoff.
+func (c *client) leafCheckFirstInfoTimeout(err error) {
+	var ne net.Error
+	if !errors.As(err, &ne) || !ne.Timeout() {
+		return
+	}
+	c.mu.Lock()
+	if !c.isSolicitedLeafNode() || c.flags.isSet(infoReceived) || c.srv == nil {
+		c.mu.Unlock()
+		return
+	}
+	remote := c.leaf.remote
+	c.mu.Unlock()
+
+	remote.RLock()
+	infoTimeout := remote.FirstInfoTimeout
+	remote.RUnlock()
+	var rURL string
+	if u := remote.getCurrentURL(); u != nil {
+		rURL = u.Host
+	}
+	seq, delay := remote.firstInfoTimeoutFailure(c.srv.getOpts().LeafNode.ReconnectInterval)
+	c.Warnf("Did not receive INFO from remote leafnode %q within %v (consecutive failures: %d), will delay next attempt by %v",
+		rURL, infoTimeout, seq, delay)
+}
+
 // For the given remote Leafnode configuration, this function returns
 // if TLS is required, and if so, will return a clone of the TLS Config
 // (since some fields will be changed during handshake), the TLS server
@@ -3533,6 +3652,9 @@ func (c *client) leafNodeGetTLSConfigForSolicit(remote *leafNodeCfg) (bool, *tls
 		} else {
 			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
 		}
+		if name := remote.TLSExpectedServerName; name != _EMPTY_ {
+			tlsConfig.VerifyConnection = verifyLeafServerIdentity(name, tlsConfig.VerifyConnection)
+		}
 		tlsName = remote.tlsName
 		tlsTimeout = remote.TLSTimeout
 		if tlsTimeout == 0 {
@@ -3543,6 +3665,26 @@ func (c *client) leafNodeGetTLSConfigForSolicit(remote *leafNodeCfg) (bool, *tls
 	return tlsRequired, tlsConfig, tlsName, tlsTimeout
 }
 
+// Returns a TLS VerifyConnection callback that requires the certificate presented
+// by the remote server to be valid for the expected server name. An existing
+// callback, such as the one used for OCSP peer verification, is invoked first.
+func verifyLeafServerIdentity(name string, verify func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if verify != nil {
+			if err := verify(cs); err != nil {
+				return err
+			}
+		}
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("remote server did not present a certificate, expected server name %q", name)
+		}
+		if err := cs.PeerCertificates[0].VerifyHostname(name); err != nil {
+			return fmt.Errorf("remote server certificate does not match expected server name %q: %v", name, err)
+		}
+		return nil
+	}
+}
+
 // Initiates the LeafNode Websocket connection by:
 // - doing the TLS handshake if needed
 // - sending the HTTP request
@@ -58,6 +58,12 @@ func (s *Server) ConfigureLogger() {
 		return
 	}
 
+	// Listeners can enable debug and trace logging for their connections only,
+	// so the logger has to let those through. The server wide flags passed to
+	// SetLoggerV2 below still gate everything else.
+	ld, lt := opts.listenerLogging()
+	debug, trace := opts.Debug || ld, opts.Trace || lt
+
 	syslog := opts.Syslog
 	if isWindowsService() && opts.LogFile == "" {
 		// Enable syslog if no log file is specified and we're running as a
@@ -66,7 +72,7 @@ func (s *Server) ConfigureLogger() {
 	}
 
 	if opts.LogFile != "" {
-		log = srvlog.NewFileLogger(opts.LogFile, opts.Logtime, opts.Debug, opts.Trace, true, srvlog.LogUTC(opts.LogtimeUTC))
+		log = srvlog.NewFileLogger(opts.LogFile, opts.Logtime, debug, trace, true, srvlog.LogUTC(opts.LogtimeUTC))
 		if opts.LogSizeLimit > 0 {
 			if l, ok := log.(*srvlog.Logger); ok {
 				l.SetSizeLimit(opts.LogSizeLimit)
@@ -83,9 +89,9 @@ func (s *Server) ConfigureLogger() {
 			}
 		}
 	} else if opts.RemoteSyslog != "" {
-		log = srvlog.NewRemoteSysLogger(opts.RemoteSyslog, opts.Debug, opts.Trace)
+		log = srvlog.NewRemoteSysLogger(opts.RemoteSyslog, debug, trace)
 	} else if syslog {
-		log = srvlog.NewSysLogger(opts.Debug, opts.Trace)
+		log = srvlog.NewSysLogger(debug, trace)
 	} else {
 		colors := true
 		// Check to see if stderr is being redirected and if so turn off color
@@ -94,7 +100,7 @@ func (s *Server) ConfigureLogger() {
 		if err != nil || (stat.Mode()&os.ModeCharDevice) == 0 {
 			colors = false
 		}
-		log = srvlog.NewStdLogger(opts.Logtime, opts.Debug, opts.Trace, colors, true, srvlog.LogUTC(opts.LogtimeUTC))
+		log = srvlog.NewStdLogger(opts.Logtime, debug, trace, colors, true, srvlog.LogUTC(opts.LogtimeUTC))
 	}
 
 	s.SetLoggerV2(log, opts.Debug, opts.Trace, opts.TraceVerbose)
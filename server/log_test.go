@@ -15,6 +15,7 @@ package server
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -534,3 +535,59 @@ func TestRemoveAdditionalAuthFieldsFromTrace(t *testing.T) {
 		})
 	}
 }
+
+func TestListenerLogging(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "nats.log")
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		log_file: %q
+		leafnodes {
+			listen: 127.0.0.1:-1
+			debug: true
+			trace: true
+		}
+	`, logFile)))
+	s, o := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	lconf := createConfFile(t, []byte(fmt.Sprintf(`
+		listen: 127.0.0.1:-1
+		leafnodes { remotes [{url: "nats://127.0.0.1:%d"}] }
+	`, o.LeafNode.Port)))
+	sl, _ := RunServerWithConfig(lconf)
+	defer sl.Shutdown()
+	checkLeafNodeConnected(t, s)
+
+	nc := natsConnect(t, s.ClientURL())
+	natsSubSync(t, nc, "foo")
+	natsFlush(t, nc)
+	nc.Close()
+
+	// Only the leafnode connection logs at debug and trace levels.
+	checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+		buf, err := os.ReadFile(logFile)
+		if err != nil {
+			return err
+		}
+		var leafDebug, leafTrace bool
+		for _, line := range strings.Split(string(buf), "\n") {
+			verbose := strings.Contains(line, "[DBG]") || strings.Contains(line, "[TRC]")
+			if verbose && !strings.Contains(line, " - lid:") {
+				t.Fatalf("Unexpected verbose log line: %q", line)
+			}
+			leafDebug = leafDebug || strings.Contains(line, "[DBG]")
+			leafTrace = leafTrace || strings.Contains(line, "[TRC]")
+		}
+		if !leafDebug || !leafTrace {
+			return fmt.Errorf("expected leafnode debug and trace lines, got debug=%v trace=%v", leafDebug, leafTrace)
+		}
+		return nil
+	})
+
+	conf = createConfFile(t, []byte(`
+		websocket { port: -1, no_tls: true, trace: "yes" }
+	`))
+	_, err := ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "Expected trace to be a boolean")
+}
@@ -31,6 +31,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"runtime/debug"
 	"runtime/pprof"
@@ -126,6 +127,7 @@ type ConnInfo struct {
 	Uptime         string         `json:"uptime"`
 	Idle           string         `json:"idle"`
 	Pending        int            `json:"pending_bytes"`
+	MaxPending     int64          `json:"max_pending,omitempty"`
 	InMsgs         int64          `json:"in_msgs"`
 	OutMsgs        int64          `json:"out_msgs"`
 	InBytes        int64          `json:"in_bytes"`
@@ -587,6 +589,7 @@ func (ci *ConnInfo) fill(client *client, nc net.Conn, now time.Time, auth bool)
 	ci.OutBytes = client.outBytes
 	ci.NumSubs = uint32(len(client.subs))
 	ci.Pending = int(client.out.pb)
+	ci.MaxPending = client.out.mp
 	ci.Name = client.opts.Name
 	ci.Lang = client.opts.Lang
 	ci.Version = client.opts.Version
@@ -840,6 +843,10 @@ type RouteInfo struct {
 	SubsDetail   []SubDetail        `json:"subscriptions_list_detail,omitempty"`
 	Account      string             `json:"account,omitempty"`
 	Compression  string             `json:"compression,omitempty"`
+	// CompressionRTT is the measured RTT that selected the current compression
+	// level, only set when the configured compression mode is s2_auto.
+	CompressionRTT string   `json:"compression_rtt,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
 }
 
 // Routez returns a Routez struct containing information about routes.
@@ -872,6 +879,7 @@ func (s *Server) Routez(routezOpts *RoutezOptions) (*Routez, error) {
 			Rid:          r.cid,
 			RemoteID:     r.route.remoteID,
 			RemoteName:   r.route.remoteName,
+			Tags:         copyStrings(r.route.tags),
 			DidSolicit:   r.route.didSolicit,
 			IsConfigured: r.route.routeType == Explicit,
 			InMsgs:       atomic.LoadInt64(&r.inMsgs),
@@ -890,6 +898,9 @@ func (s *Server) Routez(routezOpts *RoutezOptions) (*Routez, error) {
 			Account:      string(r.route.accName),
 			Compression:  r.route.compression,
 		}
+		if r.route.compressionRTT > 0 {
+			ri.CompressionRTT = r.route.compressionRTT.String()
+		}
 
 		if len(r.subs) > 0 {
 			if routezOpts.SubscriptionsDetail {
@@ -1820,7 +1831,7 @@ func (s *Server) updateVarzConfigReloadableFields(v *Varz) {
 	v.ConfigLoadTime = s.configTime.UTC()
 	v.ConfigDigest = opts.configDigest
 	v.Tags = opts.Tags
-	v.Metadata = opts.Metadata
+	v.Metadata = opts.getMergedMetadata()
 	v.FeatureFlags = opts.getMergedFeatureFlags()
 	// Update route URLs if applicable
 	if s.varzUpdateRouteURLs {
@@ -2412,6 +2423,19 @@ type Leafz struct {
 	Now      time.Time   `json:"now"`
 	NumLeafs int         `json:"leafnodes"`
 	Leafs    []*LeafInfo `json:"leafs"`
+	// Remotes lists the configured remotes, including those not connected.
+	Remotes []*LeafRemoteInfo `json:"remotes,omitempty"`
+}
+
+// LeafRemoteInfo has information on each configured leafnode remote,
+// whether it is currently connected or not.
+type LeafRemoteInfo struct {
+	Account   string   `json:"account"`
+	URLs      []string `json:"urls"`
+	Connected bool     `json:"connected"`
+	// FirstInfoTimeouts is the number of connection attempts to the remote
+	// that failed because INFO was not received in time.
+	FirstInfoTimeouts uint64 `json:"first_info_timeouts,omitempty"`
 }
 
 // LeafzOptions are options passed to Leafz
@@ -2439,13 +2463,31 @@ type LeafInfo struct {
 	Subs        []string   `json:"subscriptions_list,omitempty"`
 	Compression string     `json:"compression,omitempty"`
 	Proxy       *ProxyInfo `json:"proxy,omitempty"`
+	// FirstInfoTimeouts is, for solicited leafnodes, the number of connection
+	// attempts to the remote that failed because INFO was not received in time.
+	FirstInfoTimeouts uint64 `json:"first_info_timeouts,omitempty"`
 }
 
 // Leafz returns a Leafz structure containing information about leafnodes.
 func (s *Server) Leafz(opts *LeafzOptions) (*Leafz, error) {
-	// Grab leafnodes
+	// Grab leafnodes and configured remotes
 	var lconns []*client
+	var remotes []*leafNodeCfg
 	s.mu.Lock()
+	for lrc := range s.leafRemoteCfgs {
+		if opts != nil && opts.Account != _EMPTY_ {
+			lrc.RLock()
+			acc := lrc.LocalAccount
+			lrc.RUnlock()
+			if acc == _EMPTY_ {
+				acc = globalAccountName
+			}
+			if acc != opts.Account {
+				continue
+			}
+		}
+		remotes = append(remotes, lrc)
+	}
 	if len(s.leafs) > 0 {
 		lconns = make([]*client, 0, len(s.leafs))
 		for _, ln := range s.leafs {
@@ -2463,6 +2505,7 @@ func (s *Server) Leafz(opts *LeafzOptions) (*Leafz, error) {
 	s.mu.Unlock()
 
 	leafnodes := make([]*LeafInfo, 0, len(lconns))
+	connected := make(map[*leafNodeCfg]struct{}, len(lconns))
 
 	if len(lconns) > 0 {
 		for _, ln := range lconns {
@@ -2484,6 +2527,10 @@ func (s *Server) Leafz(opts *LeafzOptions) (*Leafz, error) {
 				Compression: ln.leaf.compression,
 				Proxy:       createProxyInfo(ln),
 			}
+			if remote := ln.leaf.remote; remote != nil {
+				lni.FirstInfoTimeouts = remote.getFirstInfoTimeouts()
+				connected[remote] = struct{}{}
+			}
 			if opts != nil && opts.Subscriptions {
 				lni.Subs = make([]string, 0, len(ln.subs))
 				for _, sub := range ln.subs {
@@ -2495,11 +2542,33 @@ func (s *Server) Leafz(opts *LeafzOptions) (*Leafz, error) {
 		}
 	}
 
+	var lris []*LeafRemoteInfo
+	for _, lrc := range remotes {
+		lrc.RLock()
+		lri := &LeafRemoteInfo{
+			Account:           lrc.LocalAccount,
+			FirstInfoTimeouts: lrc.firstInfoTimeouts,
+		}
+		for _, u := range redactURLList(lrc.URLs) {
+			lri.URLs = append(lri.URLs, u.String())
+		}
+		lrc.RUnlock()
+		if lri.Account == _EMPTY_ {
+			lri.Account = globalAccountName
+		}
+		_, lri.Connected = connected[lrc]
+		lris = append(lris, lri)
+	}
+	slices.SortFunc(lris, func(a, b *LeafRemoteInfo) int {
+		return strings.Compare(strings.Join(a.URLs, ","), strings.Join(b.URLs, ","))
+	})
+
 	return &Leafz{
 		ID:       s.ID(),
 		Now:      time.Now().UTC(),
 		NumLeafs: len(leafnodes),
 		Leafs:    leafnodes,
+		Remotes:  lris,
 	}, nil
 }
 
@@ -2689,6 +2758,12 @@ func (reason ClosedState) String() string {
 		return "Proxy Not Trusted"
 	case ProxyRequired:
 		return "Proxy Required"
+	case UserJWTTooOld:
+		return "User JWT Too Old"
+	case MaxLeafNodeAccountsExceeded:
+		return "Maximum Leafnode Accounts Exceeded"
+	case HeaderSupportRequired:
+		return "Header Support Required"
 	}
 
 	return "Unknown State"
@@ -3115,6 +3190,8 @@ type JSInfo struct {
 	Meta            *MetaClusterInfo `json:"meta_cluster,omitempty"`
 	AccountDetails  []*AccountDetail `json:"account_details,omitempty"`
 	Total           int              `json:"total"`
+	// PreloadStreams is the preload status of the configured streams, keyed by "account:stream".
+	PreloadStreams map[string]string `json:"preload_streams,omitempty"`
 }
 
 func (s *Server) accountDetail(jsa *jsAccount, optStreams, optConsumers, optDirectConsumers, optCfg, optRaft, optStreamLeader bool) *AccountDetail {
@@ -3306,6 +3383,9 @@ func (s *Server) Jsz(opts *JSzOptions) (*JSInfo, error) {
 	for _, info := range js.accounts {
 		accounts = append(accounts, info)
 	}
+	if len(js.preload) > 0 {
+		jsi.PreloadStreams = maps.Clone(js.preload)
+	}
 	js.mu.RUnlock()
 
 	jsi.Total = len(accounts)
@@ -4357,3 +4437,73 @@ func (s *Server) Raftz(opts *RaftzOptions) *RaftzStatus {
 
 	return &infos
 }
+
+// Configz represents the effective configuration of the server, as computed after
+// defaults and merges, with all secrets redacted.
+type Configz struct {
+	ID         string                     `json:"server_id"`
+	Now        time.Time                  `json:"now"`
+	ConfigFile string                     `json:"config_file,omitempty"`
+	Options    map[string]json.RawMessage `json:"options"`
+	Defaults   []string                   `json:"defaults,omitempty"`
+}
+
+// Configz returns the effective configuration of the server. Options are keyed by
+// their field name, and Defaults lists the options that have their default value.
+func (s *Server) Configz() (*Configz, error) {
+	opts := s.getOpts()
+	if !opts.EnableConfigz {
+		return nil, errors.New("configz is not enabled")
+	}
+	ro := opts.Redacted()
+	defaults := &Options{}
+	setBaselineOptions(defaults)
+
+	cz := &Configz{
+		ID:         s.ID(),
+		Now:        time.Now().UTC(),
+		ConfigFile: opts.ConfigFile,
+		Options:    make(map[string]json.RawMessage),
+	}
+	rv, ov, dv := reflect.ValueOf(ro).Elem(), reflect.ValueOf(opts).Elem(), reflect.ValueOf(defaults).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Func, reflect.Interface, reflect.Chan:
+			continue
+		}
+		// Skip anything that can not be represented, like the TLS configurations.
+		b, err := json.Marshal(rv.Field(i).Interface())
+		if err != nil {
+			continue
+		}
+		cz.Options[field.Name] = b
+		if !opts.inConfig[field.Name] && !opts.inCmdLine[field.Name] &&
+			reflect.DeepEqual(ov.Field(i).Interface(), dv.Field(i).Interface()) {
+			cz.Defaults = append(cz.Defaults, field.Name)
+		}
+	}
+	return cz, nil
+}
+
+// HandleConfigz process HTTP requests for the effective configuration.
+func (s *Server) HandleConfigz(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.httpReqStats[ConfigzPath]++
+	s.mu.Unlock()
+
+	cz, err := s.Configz()
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	b, err := json.MarshalIndent(cz, "", "  ")
+	if err != nil {
+		s.Errorf("Error marshaling response to /configz request: %v", err)
+	}
+	ResponseHandler(w, r, b)
+}
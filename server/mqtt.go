@@ -559,10 +559,7 @@ func (s *Server) createMQTTClient(conn net.Conn, ws *websocket) *client {
 	opts := s.getOpts()
 
 	maxPay := int32(opts.MaxPayload)
-	maxSubs := int32(opts.MaxSubs)
-	if maxSubs == 0 {
-		maxSubs = -1
-	}
+	maxSubs := maxSubsLimit(opts.MaxSubs)
 	now := time.Now()
 
 	mqtt := &mqtt{
@@ -607,6 +604,7 @@ func (s *Server) createMQTTClient(conn net.Conn, ws *websocket) *client {
 		return nil
 	}
 	s.clients[c.cid] = c
+	s.checkMaxConnSoftLimitLocked(opts)
 
 	// Websocket TLS handshake is already done when getting to this function.
 	tlsRequired := opts.MQTT.TLSConfig != nil && ws == nil
@@ -30,6 +30,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -48,6 +49,9 @@ var allowUnknownTopLevelField = int32(0)
 // of a configuration file. By default, an error is reported if unknown
 // fields are found. If `noError` is set to true, no error will be reported
 // if top-level unknown fields are found.
+// A configuration file can override this setting for its own top-level
+// fields with the `strict_unknown_fields` directive, in which case the
+// file-local setting wins.
 func NoErrOnUnknownFields(noError bool) {
 	var val int32
 	if noError {
@@ -56,6 +60,46 @@ func NoErrOnUnknownFields(noError bool) {
 	atomic.StoreInt32(&allowUnknownTopLevelField, val)
 }
 
+// unknownFieldsPolicy is a file-local override of the global
+// NoErrOnUnknownFields setting, set with the top-level
+// `strict_unknown_fields` directive. It only applies to top-level
+// fields defined in the file that contains the directive, so that
+// included files keep following the global setting.
+type unknownFieldsPolicy struct {
+	file   string
+	strict bool
+}
+
+// allowUnknown returns true if the unknown top-level field represented
+// by the given token should not be reported as an error.
+func (p *unknownFieldsPolicy) allowUnknown(tk token) bool {
+	if p != nil && tk != nil && tk.SourceFile() == p.file {
+		return !p.strict
+	}
+	return atomic.LoadInt32(&allowUnknownTopLevelField) != 0
+}
+
+// parseUnknownFieldsPolicy looks for the top-level `strict_unknown_fields`
+// directive and returns the file-local policy, or nil if not set.
+func parseUnknownFieldsPolicy(m map[string]any) (*unknownFieldsPolicy, error) {
+	for k, v := range m {
+		if !strings.EqualFold(k, "strict_unknown_fields") {
+			continue
+		}
+		tk, v := unwrapValue(v, nil)
+		strict, ok := v.(bool)
+		if !ok {
+			return nil, &configErr{tk, fmt.Sprintf("strict_unknown_fields should be a boolean, got %T", v)}
+		}
+		p := &unknownFieldsPolicy{strict: strict}
+		if tk != nil {
+			p.file = tk.SourceFile()
+		}
+		return p, nil
+	}
+	return nil, nil
+}
+
 // PinnedCertSet is a set of lower case hex-encoded sha256 of DER encoded SubjectPublicKeyInfo
 type PinnedCertSet map[string]struct{}
 
@@ -63,30 +107,33 @@ type PinnedCertSet map[string]struct{}
 // NOTE: This structure is no longer used for monitoring endpoints
 // and json tags are deprecated and may be removed in the future.
 type ClusterOpts struct {
-	Name              string             `json:"-"`
-	Host              string             `json:"addr,omitempty"`
-	Port              int                `json:"cluster_port,omitempty"`
-	Username          string             `json:"-"`
-	Password          string             `json:"-"`
-	AuthTimeout       float64            `json:"auth_timeout,omitempty"`
-	Permissions       *RoutePermissions  `json:"-"`
-	TLSTimeout        float64            `json:"-"`
-	TLSConfig         *tls.Config        `json:"-"`
-	TLSMap            bool               `json:"-"`
-	TLSCheckKnownURLs bool               `json:"-"`
-	TLSPinnedCerts    PinnedCertSet      `json:"-"`
-	ListenStr         string             `json:"-"`
-	Advertise         string             `json:"-"`
-	NoAdvertise       bool               `json:"-"`
-	ConnectRetries    int                `json:"-"`
-	ConnectBackoff    bool               `json:"-"`
-	PoolSize          int                `json:"-"`
-	PinnedAccounts    []string           `json:"-"`
-	Compression       CompressionOpts    `json:"-"`
-	PingInterval      time.Duration      `json:"-"`
-	MaxPingsOut       int                `json:"-"`
-	WriteDeadline     time.Duration      `json:"-"`
-	WriteTimeout      WriteTimeoutPolicy `json:"-"`
+	Name               string                       `json:"-"`
+	Host               string                       `json:"addr,omitempty"`
+	Port               int                          `json:"cluster_port,omitempty"`
+	Username           string                       `json:"-"`
+	Password           string                       `json:"-"`
+	AuthTimeout        float64                      `json:"auth_timeout,omitempty"`
+	Permissions        *RoutePermissions            `json:"-"`
+	AccountPermissions map[string]*RoutePermissions `json:"-"`
+	TLSTimeout         float64                      `json:"-"`
+	TLSConfig          *tls.Config                  `json:"-"`
+	TLSMap             bool                         `json:"-"`
+	TLSCheckKnownURLs  bool                         `json:"-"`
+	TLSPinnedCerts     PinnedCertSet                `json:"-"`
+	ListenStr          string                       `json:"-"`
+	Advertise          string                       `json:"-"`
+	NoAdvertise        bool                         `json:"-"`
+	ConnectRetries     int                          `json:"-"`
+	ConnectBackoff     bool                         `json:"-"`
+	PoolSize           int                          `json:"-"`
+	PinnedAccounts     []string                     `json:"-"`
+	Compression        CompressionOpts              `json:"-"`
+	PingInterval       time.Duration                `json:"-"`
+	MaxPingsOut        int                          `json:"-"`
+	WriteDeadline      time.Duration                `json:"-"`
+	WriteTimeout       WriteTimeoutPolicy           `json:"-"`
+	Debug              bool                         `json:"-"`
+	Trace              bool                         `json:"-"`
 
 	// Not exported (used in tests)
 	resolver netResolver
@@ -156,9 +203,12 @@ type GatewayOpts struct {
 	ConnectRetries    int                  `json:"connect_retries,omitempty"`
 	ConnectBackoff    bool                 `json:"connect_backoff,omitempty"`
 	Gateways          []*RemoteGatewayOpts `json:"gateways,omitempty"`
-	RejectUnknown     bool                 `json:"reject_unknown,omitempty"` // config got renamed to reject_unknown_cluster
+	RejectUnknown     bool                 `json:"reject_unknown,omitempty"`  // config got renamed to reject_unknown_cluster
+	AllowedUnknown    []string             `json:"allowed_unknown,omitempty"` // Unknown clusters still accepted when RejectUnknown is set
 	WriteDeadline     time.Duration        `json:"-"`
 	WriteTimeout      WriteTimeoutPolicy   `json:"-"`
+	Debug             bool                 `json:"-"`
+	Trace             bool                 `json:"-"`
 
 	// Not exported, for tests.
 	resolver         netResolver
@@ -229,6 +279,17 @@ type LeafNodeOpts struct {
 	// east-west propagation.
 	IsolateLeafnodeInterest bool `json:"-"`
 
+	// MaxAccountsPerConnection limits the number of distinct accounts that a
+	// remote server can bind with its leafnode connections. Zero means unlimited.
+	MaxAccountsPerConnection int `json:"-"`
+
+	// MaxURLsPerRemote limits the number of URLs of each remote. Zero means unlimited.
+	MaxURLsPerRemote int `json:"-"`
+
+	// Debug and Trace enable verbose logging for leafnode connections only.
+	Debug bool `json:"-"`
+	Trace bool `json:"-"`
+
 	// Not exported, for tests.
 	resolver    netResolver
 	dialTimeout time.Duration
@@ -264,6 +325,17 @@ type RemoteLeafOpts struct {
 	// connection.
 	FirstInfoTimeout time.Duration `json:"-"`
 
+	// TLSExpectedServerName, if set, requires the certificate presented by the
+	// remote server to be valid for this name, in addition to the standard verification.
+	TLSExpectedServerName string `json:"-"`
+
+	// DenyPublish and DenySubscribe are enforced as permissions on this leaf
+	// connection only. DenyImports and DenyExports also shape the interest
+	// propagated over the link, and deny imports are advertised to the remote,
+	// whereas these are never communicated to the remote server.
+	DenyPublish   []string `json:"-"`
+	DenySubscribe []string `json:"-"`
+
 	// Compression options for this remote. Each remote could have a different
 	// setting and also be different from the LeafNode options.
 	Compression CompressionOpts `json:"-"`
@@ -366,6 +438,12 @@ type JSLimitOpts struct {
 	MaxBatchInflightTotal     int           `json:"max_batch_inflight_total,omitempty"`      // MaxBatchInflightTotal is the maximum amount of total open batches per server
 	MaxBatchSize              int           `json:"max_batch_size,omitempty"`                // MaxBatchSize is the maximum amount of messages allowed in a batch publish to a Stream
 	MaxBatchTimeout           time.Duration `json:"max_batch_timeout,omitempty"`             // MaxBatchTimeout is the maximum time to receive the commit message after receiving the first message of a batch
+	MaxDuplicateEntries       int           `json:"max_duplicate_entries,omitempty"`         // MaxDuplicateEntries is the maximum amount of message ids tracked for duplicate detection per Stream, 0 relies on the duplicate window only
+	MaxConsumerNameLen        int           `json:"max_consumer_name_len,omitempty"`         // MaxConsumerNameLen is the maximum length of Consumer names, 0 means JSMaxNameLen
+	MaxConsumerDescriptionLen int           `json:"max_consumer_description_len,omitempty"`  // MaxConsumerDescriptionLen is the maximum length of Consumer descriptions, 0 means JSMaxDescriptionLen
+	MaxConsumerPendingBytes   int64         `json:"max_consumer_pending_bytes,omitempty"`    // MaxConsumerPendingBytes is the approximate memory a Consumer may use for pending acks before delivery pauses, 0 relies on MaxAckPending only
+	MaxStreamSubjects         int           `json:"max_stream_subjects,omitempty"`           // MaxStreamSubjects is the maximum amount of subjects a Stream may be configured with, 0 means unlimited
+	FlowControlHeartbeat      time.Duration `json:"flow_control_heartbeat,omitempty"`        // FlowControlHeartbeat is the default Heartbeat of flow controlled Consumers that do not set one, 0 requires it to be set
 }
 
 type JSTpmOpts struct {
@@ -417,6 +495,7 @@ type Options struct {
 	MaxConn                    int           `json:"max_connections"`
 	MaxSubs                    int           `json:"max_subscriptions,omitempty"`
 	MaxSubTokens               uint8         `json:"-"`
+	MaxPubSubTokens            uint8         `json:"-"`
 	Nkeys                      []*NkeyUser   `json:"-"`
 	Users                      []*User       `json:"-"`
 	Accounts                   []*Account    `json:"-"`
@@ -436,10 +515,12 @@ type Options struct {
 	HTTPPort                   int           `json:"http_port"`
 	HTTPBasePath               string        `json:"http_base_path"`
 	HTTPSPort                  int           `json:"https_port"`
+	HTTPTLSConfig              *tls.Config   `json:"-"`
 	AuthTimeout                float64       `json:"auth_timeout"`
 	MaxControlLine             int32         `json:"max_control_line"`
 	MaxPayload                 int32         `json:"max_payload"`
 	MaxPending                 int64         `json:"max_pending"`
+	SlowConsumerLogThreshold   int64         `json:"slow_consumer_log_threshold,omitempty"`
 	NoFastProducerStall        bool          `json:"-"`
 	Cluster                    ClusterOpts   `json:"cluster,omitempty"`
 	Gateway                    GatewayOpts   `json:"gateway,omitempty"`
@@ -449,6 +530,7 @@ type Options struct {
 	JetStreamMaxMemory         int64         `json:"-"`
 	JetStreamMaxStore          int64         `json:"-"`
 	JetStreamDomain            string        `json:"-"`
+	JetStreamDomainAliases     []string      `json:"-"` // Handled as if JetStreamDomain.
 	JetStreamExtHint           string        `json:"-"`
 	JetStreamKey               string        `json:"-"`
 	JetStreamOldKey            string        `json:"-"`
@@ -519,6 +601,16 @@ type Options struct {
 	TrustedOperators         []*jwt.OperatorClaims `json:"-"`
 	AccountResolver          AccountResolver       `json:"-"`
 	AccountResolverTLSConfig *tls.Config           `json:"-"`
+	// AccountExpirationGrace is how long already connected clients are
+	// allowed to stay connected after their account JWT has expired.
+	// New connections are rejected as soon as the account JWT expires.
+	AccountExpirationGrace time.Duration `json:"-"`
+	// ResolverMaxActiveAccounts caps how many accounts fetched from the resolver
+	// are kept in memory, evicting the least recently used idle ones. Zero means unlimited.
+	ResolverMaxActiveAccounts int `json:"-"`
+	// ResolverUseLastKnownGood serves the last successfully fetched account JWT,
+	// even if no longer cached by the resolver, when fetching it fails.
+	ResolverUseLastKnownGood bool `json:"-"`
 
 	// AlwaysEnableNonce will always present a nonce to new connections
 	// typically used by custom Authentication implementations who embeds
@@ -555,17 +647,141 @@ type Options struct {
 	FeatureFlags map[string]bool `json:"-"`
 
 	// OCSPConfig enables OCSP Stapling in the server.
-	OCSPConfig    *OCSPConfig
-	tlsConfigOpts *TLSConfigOpts
+	OCSPConfig        *OCSPConfig
+	tlsConfigOpts     *TLSConfigOpts
+	httpTLSConfigOpts *TLSConfigOpts
 
 	// Proxies configuration.
 	Proxies *ProxiesConfig
 
+	// JetStreamRequireExplicitDeliverPolicy makes the server reject consumer
+	// create requests that do not explicitly set a deliver policy.
+	JetStreamRequireExplicitDeliverPolicy bool `json:"-"`
+
+	// JetStreamMaxConcurrentSnapshots and JetStreamMaxConcurrentRestores limit
+	// the number of in-flight stream snapshots and restores. Zero means unlimited.
+	JetStreamMaxConcurrentSnapshots int `json:"-"`
+	JetStreamMaxConcurrentRestores  int `json:"-"`
+
+	// JetStreamDisallowNetworkStorage refuses to enable JetStream when the store
+	// directory is on a known network filesystem: nfs, smb/cifs, ceph, afs, coda,
+	// 9p, gfs2, ocfs2 and lustre on Linux, nfs, smbfs, afpfs and webdav on macOS
+	// and FreeBSD. Other platforms can not detect the filesystem type and only warn.
+	JetStreamDisallowNetworkStorage bool `json:"-"`
+
+	// AccountsRequired lists accounts that must be resolvable at startup,
+	// either from the configuration or through the account resolver.
+	AccountsRequired []string `json:"-"`
+
+	// TLSAccountMapping is the client certificate subject attribute (e.g. "OU")
+	// used to derive the account of clients authenticated with verify_and_map.
+	// The user is still mapped from the certificate, only its account is derived.
+	// A user explicitly defined in an account must map to that same account.
+	TLSAccountMapping string `json:"-"`
+
+	// JetStreamClusterName is the cluster name used by JetStream for placement and
+	// its meta and raft groups, defaults to Cluster.Name. Changing it on an existing
+	// cluster is disruptive: assets placed under the old name can no longer find peers,
+	// so all servers need the same value and placement directives must be updated.
+	JetStreamClusterName string `json:"-"`
+
+	// NoAuthUserPermissions, if set, replaces the permissions of the NoAuthUser
+	// for connections mapped to it because they did not authenticate. Connections
+	// authenticating as that user keep the user's own permissions.
+	NoAuthUserPermissions *Permissions `json:"-"`
+
+	// LogConsumerLeaderChanges logs every consumer leadership transition in
+	// clustered JetStream. Off by default to avoid noise in large clusters.
+	LogConsumerLeaderChanges bool `json:"-"`
+
+	// ConsumerLeaderChangeGrace is added to the inactive threshold of consumers
+	// for the window following a leadership change in clustered JetStream, so
+	// reconnecting clients are not raced into the consumer's deletion.
+	ConsumerLeaderChangeGrace time.Duration `json:"-"`
+
+	// AuthTimeouts sets the auth timeout, in seconds, per connection type: client,
+	// cluster, gateway, leafnode, websocket and mqtt. A timeout set in the
+	// authorization block of the corresponding configuration takes precedence.
+	AuthTimeouts map[string]float64 `json:"-"`
+
+	// NoConfigFileMetadata suppresses the reserved server metadata entries
+	// reporting the path and digest of the loaded configuration file.
+	NoConfigFileMetadata bool `json:"-"`
+
+	// TLSMinVersion is a floor for the minimum TLS version of every TLS
+	// configuration. A block's min_version can raise but not lower it.
+	TLSMinVersion uint16 `json:"-"`
+
+	// EnableConfigz exposes the effective, redacted options on the
+	// monitoring endpoint /configz.
+	EnableConfigz bool `json:"-"`
+
+	// JetStreamAuditSubject, if set, is the system account subject on which an
+	// audit event is published for every stream and consumer create, update
+	// and delete. Empty disables auditing.
+	JetStreamAuditSubject string `json:"-"`
+
+	// JetStreamPreloadStreams lists streams, as "account:stream", whose state is
+	// eagerly loaded when they are recovered at startup instead of on first access.
+	JetStreamPreloadStreams []string `json:"-"`
+
+	// TCPKeepAlive enables TCP keepalives with this period on accepted connections,
+	// for intermediaries that drop idle TCP connections. Zero leaves them disabled.
+	TCPKeepAlive time.Duration `json:"-"`
+
+	// JetStreamAllowedAccounts restricts JetStream to these accounts, regardless
+	// of the limits they are configured with. Empty allows all accounts.
+	JetStreamAllowedAccounts []string `json:"-"`
+
+	// MaxConnSoftLimit is a number of client connections, lower than MaxConn, at
+	// which the server logs a warning and sends an advisory, giving orchestrators
+	// a signal before connections get rejected. Zero disables it.
+	MaxConnSoftLimit int `json:"-"`
+
+	// LameDuckOnMaxConnSoftLimit makes the server enter lame duck mode when
+	// MaxConnSoftLimit is reached, so that load shifts to other servers.
+	LameDuckOnMaxConnSoftLimit bool `json:"-"`
+
+	// MaxUserJWTAge rejects user JWTs issued longer ago than this, based on their
+	// issued at claim and regardless of their expiration. Zero disables the check.
+	MaxUserJWTAge time.Duration `json:"-"`
+
+	// QoSProfiles are named sets of outbound settings that accounts can
+	// refer to with their qos_profile field.
+	QoSProfiles map[string]*QoSProfile `json:"-"`
+
+	// CloseAdvisoryDetail is how much is included in the client disconnect
+	// advisories. The extended level adds the last error and subscription count.
+	CloseAdvisoryDetail CloseAdvisoryDetail `json:"-"`
+
+	// JetStreamMinFreeMemory is the minimum amount of free system memory required
+	// for memory storage streams to accept new writes. Zero disables the check.
+	// The free system memory is only known on Linux, it is ignored elsewhere.
+	JetStreamMinFreeMemory int64 `json:"-"`
+
+	// JetStreamConsumerInfoOmitCluster omits the cluster details from consumer
+	// info responses unless the request asks for them.
+	JetStreamConsumerInfoOmitCluster bool `json:"-"`
+
+	// JetStreamEphemeralDeleteJitter is the maximum jitter added to the inactive threshold
+	// before ephemeral and pull consumers are deleted. Zero means no jitter.
+	JetStreamEphemeralDeleteJitter time.Duration `json:"-"`
+
+	// JetStreamOnCorruptRecovery controls what happens when recovery finds a stream
+	// or consumer with corrupted state on startup. One of "fail", "skip" or "quarantine".
+	// Empty means "skip", which is how recovery has always behaved. Streams or consumers
+	// that could not be decrypted are never quarantined, and streams whose metadata was
+	// valid but that failed to be recreated are always skipped.
+	JetStreamOnCorruptRecovery string `json:"-"`
+
 	// private fields, used to know if bool options are explicitly
 	// defined in config and/or command line params.
 	inConfig  map[string]bool
 	inCmdLine map[string]bool
 
+	// Where each option comes from, only set by MergeOptionsWithProvenance.
+	provenance map[string]OptionSource
+
 	// private fields for operator mode
 	operatorJWT            []string
 	resolverPreloads       map[string]string
@@ -580,6 +796,8 @@ type Options struct {
 	maxStoreSet bool
 	syncSet     bool
 
+	ephemeralDeleteJitterSet bool
+
 	// OCSP Cache config enables next-gen cache for OCSP features
 	OCSPCacheConfig *OCSPResponseCacheConfig
 
@@ -661,6 +879,11 @@ type WebsocketOpts struct {
 	// SameOrigin is false, any origin is accepted.
 	AllowedOrigins []string
 
+	// If true, the server refuses to start unless an origin policy is
+	// explicitly configured with SameOrigin or AllowedOrigins, instead of
+	// accepting any origin by default.
+	RequireOriginConfig bool
+
 	// If set to true, the server will negotiate with clients
 	// if compression can be used. If this is false, no compression
 	// will be used (both in server and clients) since it has to
@@ -681,6 +904,16 @@ type WebsocketOpts struct {
 	// Useful for adding custom headers like Strict-Transport-Security.
 	Headers map[string]string
 
+	// Subprotocols accepted during the upgrade handshake. If a client offers
+	// subprotocols, the first one that is in this list is echoed back in the
+	// upgrade response, and the upgrade is rejected if none is in the list.
+	// Clients that do not offer any subprotocol are not affected.
+	Subprotocols []string
+
+	// Debug and Trace enable verbose logging for websocket connections only.
+	Debug bool
+	Trace bool
+
 	// Snapshot of configured TLS options.
 	tlsConfigOpts *TLSConfigOpts
 }
@@ -770,6 +1003,10 @@ type MQTTOpts struct {
 	// Note that changes to this option is applied only to new subscriptions.
 	MaxAckPending uint16
 
+	// Debug and Trace enable verbose logging for MQTT connections only.
+	Debug bool
+	Trace bool
+
 	// Snapshot of configured TLS options.
 	tlsConfigOpts *TLSConfigOpts
 
@@ -829,6 +1066,68 @@ func (o *Options) Clone() *Options {
 	return clone
 }
 
+// Redacted returns a copy of the options with passwords, tokens, keys and
+// credentials in URLs replaced, so that they can be safely exposed.
+func (o *Options) Redacted() *Options {
+	if o == nil {
+		return nil
+	}
+	const redacted = "[REDACTED]"
+	redact := func(v *string) {
+		if *v != _EMPTY_ {
+			*v = redacted
+		}
+	}
+	redactUsers := func(users []*User) []*User {
+		if users == nil {
+			return nil
+		}
+		rusers := make([]*User, len(users))
+		for i, u := range users {
+			rusers[i] = u.clone()
+			if rusers[i] != nil {
+				redact(&rusers[i].Password)
+			}
+		}
+		return rusers
+	}
+
+	ro := o.Clone()
+	redact(&ro.Password)
+	redact(&ro.Authorization)
+	ro.Users = redactUsers(o.Users)
+	if ro.Routes != nil {
+		ro.Routes = redactURLList(ro.Routes)
+	}
+	redact(&ro.RoutesStr)
+	redact(&ro.Cluster.Password)
+	redact(&ro.Gateway.Password)
+	for _, g := range ro.Gateway.Gateways {
+		g.URLs = redactURLList(g.URLs)
+	}
+	redact(&ro.LeafNode.Password)
+	ro.LeafNode.Users = redactUsers(o.LeafNode.Users)
+	if o.LeafNode.Remotes != nil {
+		ro.LeafNode.Remotes = make([]*RemoteLeafOpts, len(o.LeafNode.Remotes))
+		for i, r := range o.LeafNode.Remotes {
+			rr := *r
+			rr.URLs = redactURLList(r.URLs)
+			rr.Proxy.URL = redactURLString(r.Proxy.URL)
+			redact(&rr.Proxy.Password)
+			ro.LeafNode.Remotes[i] = &rr
+		}
+	}
+	redact(&ro.Websocket.Password)
+	redact(&ro.Websocket.Token)
+	redact(&ro.MQTT.Password)
+	redact(&ro.MQTT.Token)
+	redact(&ro.JetStreamKey)
+	redact(&ro.JetStreamOldKey)
+	redact(&ro.JetStreamTpm.KeyPassword)
+	redact(&ro.JetStreamTpm.SrkPassword)
+	return ro
+}
+
 func deepCopyURLs(urls []*url.URL) []*url.URL {
 	if urls == nil {
 		return nil
@@ -887,6 +1186,17 @@ type TLSConfigOpts struct {
 	OCSPPeerConfig       *certidp.OCSPPeerConfig
 	Certificates         []*TLSCertPairOpt
 	MinVersion           uint16
+	ExpectedServerName   string // Only used by solicited leafnode connections.
+	AccountMapping       string // Only used by client connections, the certificate attribute to derive the account from.
+
+	// SessionTicketsDisabled disables TLS session tickets, otherwise Go's default behavior applies.
+	SessionTicketsDisabled bool
+	// SessionTicketKeysHook, if set, is invoked once with a function setting the session
+	// ticket keys of the generated tls.Config, allowing embedders to rotate them.
+	SessionTicketKeysHook func(setKeys func(keys [][32]byte))
+	// AllowedSNI restricts the server names clients can request during the handshake.
+	// Empty means any server name is accepted.
+	AllowedSNI []string
 }
 
 // TLSCertPairOpt are the paths to a certificate and private key.
@@ -1033,6 +1343,67 @@ func configureSystemAccount(o *Options, m map[string]any) (retErr error) {
 	return nil
 }
 
+// QoSProfile is a named set of outbound settings applied to the client
+// connections of the accounts referring to it.
+type QoSProfile struct {
+	// MaxPending overrides the server's max_pending, 0 means it applies.
+	MaxPending int64
+	// WriteDeadline overrides the server's write_deadline, 0 means it applies.
+	WriteDeadline time.Duration
+}
+
+// configureQoSProfiles parses the "qos_profiles" block, so that accounts
+// can refer to the profiles regardless of their order in the config.
+func configureQoSProfiles(o *Options, m map[string]any, errors *[]error, warnings *[]error) {
+	v, ok := m["qos_profiles"]
+	if !ok {
+		return
+	}
+	var lt token
+	defer convertPanicToErrorList(&lt, errors)
+
+	tk, v := unwrapValue(v, &lt)
+	pm, ok := v.(map[string]any)
+	if !ok {
+		*errors = append(*errors, &configErr{tk, fmt.Sprintf("Expected qos_profiles to be a map, got %T", v)})
+		return
+	}
+	o.QoSProfiles = make(map[string]*QoSProfile, len(pm))
+	for name, pv := range pm {
+		tk, pv := unwrapValue(pv, &lt)
+		fm, ok := pv.(map[string]any)
+		if !ok {
+			*errors = append(*errors, &configErr{tk, fmt.Sprintf("Expected qos profile %q to be a map, got %T", name, pv)})
+			continue
+		}
+		p := &QoSProfile{}
+		for k, fv := range fm {
+			tk, fv := unwrapValue(fv, &lt)
+			switch strings.ToLower(k) {
+			case "max_pending":
+				mp, ok := fv.(int64)
+				if !ok || mp < 0 {
+					*errors = append(*errors, &configErr{tk, fmt.Sprintf("QoS profile %q max_pending must be positive, got %v", name, fv)})
+					continue
+				}
+				p.MaxPending = mp
+			case "write_deadline":
+				wd := parseDuration(k, tk, fv, errors, warnings)
+				if wd < 0 {
+					*errors = append(*errors, &configErr{tk, fmt.Sprintf("QoS profile %q write_deadline must be positive, got %v", name, wd)})
+					continue
+				}
+				p.WriteDeadline = wd
+			default:
+				if !tk.IsUsedVariable() {
+					*errors = append(*errors, &configErr{tk, fmt.Sprintf("Unknown field %q parsing qos profile %q", k, name)})
+				}
+			}
+		}
+		o.QoSProfiles[name] = p
+	}
+}
+
 // ProcessConfigFile updates the Options structure with options
 // present in the given configuration file.
 // This version is convenient if one wants to set some default
@@ -1061,6 +1432,42 @@ func (o *Options) ProcessConfigFile(configFile string) error {
 	return o.processConfigFile(configFile, m)
 }
 
+// remoteLeafDenyWarnings returns warnings for deny_imports and deny_exports subjects
+// of remote leafnodes that do not overlap any import or export of the remote's local
+// account, which usually indicates a typo. This is best effort, so accounts that are
+// not configured or have no imports and exports are not checked.
+func remoteLeafDenyWarnings(o *Options) []error {
+	var warnings []error
+	for _, r := range o.LeafNode.Remotes {
+		if len(r.DenyImports) == 0 && len(r.DenyExports) == 0 {
+			continue
+		}
+		accName := r.LocalAccount
+		if accName == _EMPTY_ {
+			accName = globalAccountName
+		}
+		idx := slices.IndexFunc(o.Accounts, func(a *Account) bool { return a.Name == accName })
+		if idx < 0 {
+			continue
+		}
+		subjects := o.Accounts[idx].importExportSubjects()
+		if len(subjects) == 0 {
+			continue
+		}
+		check := func(field string, denies []string) {
+			for _, deny := range denies {
+				if !slices.ContainsFunc(subjects, func(subj string) bool { return SubjectsCollide(deny, subj) }) {
+					warnings = append(warnings, fmt.Errorf("leafnode remote %s: %s subject %q does not match any import or export of account %q",
+						r.safeName(), field, deny, accName))
+				}
+			}
+		}
+		check("deny_imports", r.DenyImports)
+		check("deny_exports", r.DenyExports)
+	}
+	return warnings
+}
+
 // ProcessConfigString is the same as ProcessConfigFile, but expects the
 // contents of the config file to be passed in rather than the file name.
 func (o *Options) ProcessConfigString(data string) error {
@@ -1077,6 +1484,33 @@ func (o *Options) ConfigDigest() string {
 	return o.configDigest
 }
 
+// Reserved server metadata keys describing the loaded configuration file.
+const (
+	serverMetadataConfigFile   = "_nats.config_file"
+	serverMetadataConfigDigest = "_nats.config_digest"
+)
+
+// getMergedMetadata returns the server metadata including the reserved entries
+// for the configuration file, unless suppressed. User provided keys take precedence.
+func (o *Options) getMergedMetadata() map[string]string {
+	if o.NoConfigFileMetadata || o.ConfigFile == _EMPTY_ {
+		return o.Metadata
+	}
+	merged := make(map[string]string, len(o.Metadata)+2)
+	if path, err := filepath.Abs(o.ConfigFile); err == nil {
+		merged[serverMetadataConfigFile] = path
+	} else {
+		merged[serverMetadataConfigFile] = o.ConfigFile
+	}
+	if o.configDigest != _EMPTY_ {
+		merged[serverMetadataConfigDigest] = o.configDigest
+	}
+	for k, v := range o.Metadata {
+		merged[k] = v
+	}
+	return merged
+}
+
 func (o *Options) processConfigFile(configFile string, m map[string]any) error {
 	// Collect all errors and warnings and report them all together.
 	errors := make([]error, 0)
@@ -1091,10 +1525,25 @@ func (o *Options) processConfigFile(configFile string, m map[string]any) error {
 		errors = append(errors, err)
 	}
 
+	ufp, err := parseUnknownFieldsPolicy(m)
+	if err != nil {
+		errors = append(errors, err)
+	}
+
+	// QoS profiles need to be known before parsing the accounts referring to them.
+	configureQoSProfiles(o, m, &errors, &warnings)
+
 	for k, v := range m {
-		o.processConfigFileLine(k, v, &errors, &warnings)
+		o.processConfigFileLine(k, v, ufp, &errors, &warnings)
 	}
 
+	// Post-process: warn about remote leafnode deny subjects that can never match.
+	warnings = append(warnings, remoteLeafDenyWarnings(o)...)
+
+	// Post-process: apply the global TLS minimum version to all TLS blocks,
+	// since they may have been parsed before it.
+	o.applyTLSMinVersion()
+
 	// Post-process: check auth callout allowed accounts against configured accounts.
 	if o.AuthCallout != nil {
 		accounts := make(map[string]struct{})
@@ -1120,7 +1569,7 @@ func (o *Options) processConfigFile(configFile string, m map[string]any) error {
 	return nil
 }
 
-func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnings *[]error) {
+func (o *Options) processConfigFileLine(k string, v any, ufp *unknownFieldsPolicy, errors *[]error, warnings *[]error) {
 	var lt token
 	defer convertPanicToErrorList(&lt, errors)
 
@@ -1254,6 +1703,13 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 			o.Nkeys = append(o.Nkeys, auth.nkeys...)
 		}
 	case "http":
+		// The block form configures the monitoring endpoint itself.
+		if _, ok := v.(map[string]any); ok {
+			if err := parseHTTP(tk, o, errors); err != nil {
+				*errors = append(*errors, err)
+			}
+			return
+		}
 		hp, err := parseListen(v)
 		if err != nil {
 			err := &configErr{tk, err.Error()}
@@ -1342,12 +1798,23 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 		o.MaxPayload = int32(v.(int64))
 	case "max_pending":
 		o.MaxPending = v.(int64)
+	case "slow_consumer_log_threshold":
+		o.SlowConsumerLogThreshold = v.(int64)
 	case "proxy_protocol":
 		o.ProxyProtocol = v.(bool)
 	case "max_connections", "max_conn":
 		if o.MaxConn = int(v.(int64)); o.MaxConn == 0 {
 			o.MaxConn = -1
 		}
+	case "max_connections_soft", "max_conn_soft":
+		if n := int(v.(int64)); n < 0 {
+			err := &configErr{tk, fmt.Sprintf("%s can not be negative", k)}
+			*errors = append(*errors, err)
+		} else {
+			o.MaxConnSoftLimit = n
+		}
+	case "max_connections_soft_lame_duck", "max_conn_soft_lame_duck":
+		o.LameDuckOnMaxConnSoftLimit = v.(bool)
 	case "max_traced_msg_len":
 		o.MaxTracedMsgLen = int(v.(int64))
 	case "max_subscriptions", "max_subs":
@@ -1364,6 +1831,18 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 		} else {
 			o.MaxSubTokens = uint8(n)
 		}
+	case "max_pub_tokens", "max_publish_tokens":
+		if n := v.(int64); n > math.MaxUint8 {
+			err := &configErr{tk, fmt.Sprintf("%s value is too big", k)}
+			*errors = append(*errors, err)
+			return
+		} else if n < 0 {
+			err := &configErr{tk, fmt.Sprintf("%s value can not be negative", k)}
+			*errors = append(*errors, err)
+			return
+		} else {
+			o.MaxPubSubTokens = uint8(n)
+		}
 	case "ping_interval":
 		o.PingInterval = parseDuration("ping_interval", tk, v, errors, warnings)
 	case "ping_max":
@@ -1374,6 +1853,10 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 			*errors = append(*errors, err)
 			return
 		}
+		if err := checkTLSExpectedServerName(tk, tc); err != nil {
+			*errors = append(*errors, err)
+			return
+		}
 		if o.TLSConfig, err = GenTLSConfig(tc); err != nil {
 			err := &configErr{tk, err.Error()}
 			*errors = append(*errors, err)
@@ -1381,6 +1864,12 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 		}
 		o.TLSTimeout = tc.Timeout
 		o.TLSMap = tc.Map
+		if tc.AccountMapping != _EMPTY_ && !tc.Map {
+			err := &configErr{tk, "tls 'account_mapping' requires 'verify_and_map' to be enabled"}
+			*errors = append(*errors, err)
+			return
+		}
+		o.TLSAccountMapping = tc.AccountMapping
 		o.TLSPinnedCerts = tc.PinnedCerts
 		o.TLSRateLimit = tc.RateLimit
 		o.TLSHandshakeFirst = tc.HandshakeFirst
@@ -1442,6 +1931,30 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 		o.WriteDeadline = parseDuration("write_deadline", tk, v, errors, warnings)
 	case "write_timeout":
 		o.WriteTimeout = parseWriteDeadlinePolicy(tk, v.(string), errors)
+	case "max_user_jwt_age":
+		if age := parseDuration(k, tk, v, errors, warnings); age <= 0 {
+			err := &configErr{tk, fmt.Sprintf("%s needs to be positive", k)}
+			*errors = append(*errors, err)
+		} else {
+			o.MaxUserJWTAge = age
+		}
+	case "close_advisory_detail":
+		switch strings.ToLower(v.(string)) {
+		case "basic":
+			o.CloseAdvisoryDetail = CloseAdvisoryDetailBasic
+		case "extended":
+			o.CloseAdvisoryDetail = CloseAdvisoryDetailExtended
+		default:
+			err := &configErr{tk, fmt.Sprintf("%s must be 'basic' or 'extended', got %q", k, v)}
+			*errors = append(*errors, err)
+		}
+	case "tcp_keepalive", "tcp_keep_alive":
+		if ka := parseDuration(k, tk, v, errors, warnings); ka < 0 {
+			err := &configErr{tk, fmt.Sprintf("%s can not be negative", k)}
+			*errors = append(*errors, err)
+		} else {
+			o.TCPKeepAlive = ka
+		}
 	case "lame_duck_duration":
 		dur, err := time.ParseDuration(v.(string))
 		if err != nil {
@@ -1552,6 +2065,7 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 			dir := _EMPTY_
 			dirType := _EMPTY_
 			limit := int64(0)
+			limitPct := int64(0)
 			ttl := time.Duration(0)
 			sync := time.Duration(0)
 			opts := []DirResOption{}
@@ -1574,8 +2088,21 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 				hdel = v.(bool)
 			}
 			if v, ok := v["limit"]; ok {
-				_, v := unwrapValue(v, &lt)
-				limit = v.(int64)
+				ltk, v := unwrapValue(v, &lt)
+				switch lv := v.(type) {
+				case int64:
+					limit = lv
+				case string:
+					pct, perr := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(lv), "%"), 10, 64)
+					if perr != nil || !strings.HasSuffix(lv, "%") || pct < 1 || pct > 100 {
+						*errors = append(*errors, &configErr{ltk, fmt.Sprintf("resolver limit percentage must be between 1%% and 100%%, got %q", lv)})
+						return
+					}
+					limitPct = pct
+				default:
+					*errors = append(*errors, &configErr{ltk, fmt.Sprintf("resolver limit should be an integer or a percentage, got %T", v)})
+					return
+				}
 			}
 			if v, ok := v["ttl"]; ok {
 				_, v := unwrapValue(v, &lt)
@@ -1596,6 +2123,33 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 				*errors = append(*errors, &configErr{tk, err.Error()})
 				return
 			}
+			if v, ok := v["expiration_grace"]; ok {
+				gtk, v := unwrapValue(v, &lt)
+				grace := parseDuration("expiration_grace", gtk, v, errors, warnings)
+				if grace < 0 {
+					*errors = append(*errors, &configErr{gtk, "expiration_grace cannot be negative"})
+					return
+				}
+				o.AccountExpirationGrace = grace
+			}
+			if v, ok := v["max_active_accounts"]; ok {
+				mtk, v := unwrapValue(v, &lt)
+				n, ok := v.(int64)
+				if !ok || n < 0 {
+					*errors = append(*errors, &configErr{mtk, fmt.Sprintf("resolver max_active_accounts should be a positive integer, got %v", v)})
+					return
+				}
+				o.ResolverMaxActiveAccounts = int(n)
+			}
+			if v, ok := v["use_last_known_good_on_fetch_error"]; ok {
+				utk, v := unwrapValue(v, &lt)
+				use, ok := v.(bool)
+				if !ok {
+					*errors = append(*errors, &configErr{utk, fmt.Sprintf("resolver use_last_known_good_on_fetch_error should be a boolean, got %T", v)})
+					return
+				}
+				o.ResolverUseLastKnownGood = use
+			}
 
 			checkDir := func() {
 				if dir == _EMPTY_ {
@@ -1608,8 +2162,26 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 				}
 			}
 
+			// A percentage limit is resolved against the filesystem hosting dir
+			// when the resolver starts, until then do not limit.
+			if limitPct > 0 {
+				limit = math.MaxInt64
+				opts = append(opts, limitFromDiskPercent(limitPct))
+			}
+
 			var res AccountResolver
 			switch strings.ToUpper(dirType) {
+			case "URL":
+				var url string
+				if uv, ok := v["url"]; ok {
+					_, uv := unwrapValue(uv, &lt)
+					url, _ = uv.(string)
+				}
+				if _, err := parseURL(url, "account resolver"); err != nil || url == _EMPTY_ {
+					*errors = append(*errors, &configErr{tk, "URL requires a valid url"})
+					return
+				}
+				res, err = NewURLAccResolver(url)
 			case "CACHE":
 				checkDir()
 				if sync != 0 {
@@ -1654,7 +2226,7 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 		}
 		if o.AccountResolver == nil {
 			err := &configErr{tk, "error parsing account resolver, should be MEM or " +
-				" URL(\"url\") or a map containing dir and type state=[FULL|CACHE])"}
+				" URL(\"url\") or a map containing dir and type state=[FULL|CACHE] or url and type URL)"}
 			*errors = append(*errors, err)
 		}
 	case "resolver_tls":
@@ -1663,6 +2235,10 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 			*errors = append(*errors, err)
 			return
 		}
+		if err := checkTLSExpectedServerName(tk, tc); err != nil {
+			*errors = append(*errors, err)
+			return
+		}
 		tlsConfig, err := GenTLSConfig(tc)
 		if err != nil {
 			err := &configErr{tk, err.Error()}
@@ -1725,8 +2301,139 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 			return
 		}
 	case "no_auth_user":
-		o.NoAuthUser = v.(string)
-	case "system_account", "system":
+		switch vv := v.(type) {
+		case string:
+			o.NoAuthUser = vv
+		case map[string]any:
+			// The block form allows narrower permissions for anonymous connections.
+			for mk, mv := range vv {
+				tk, mv := unwrapValue(mv, &lt)
+				switch strings.ToLower(mk) {
+				case "user":
+					user, ok := mv.(string)
+					if !ok {
+						err := &configErr{tk, fmt.Sprintf("Expected no_auth_user user to be a string, got %T", mv)}
+						*errors = append(*errors, err)
+						continue
+					}
+					o.NoAuthUser = user
+				case "permissions":
+					perms, err := parseUserPermissions(tk, errors)
+					if err != nil {
+						*errors = append(*errors, err)
+						continue
+					}
+					o.NoAuthUserPermissions = perms
+				default:
+					if !tk.IsUsedVariable() {
+						err := &unknownConfigFieldErr{
+							field: mk,
+							configErr: configErr{
+								token: tk,
+							},
+						}
+						*errors = append(*errors, err)
+					}
+				}
+			}
+			if o.NoAuthUser == _EMPTY_ {
+				err := &configErr{tk, "no_auth_user block requires a user"}
+				*errors = append(*errors, err)
+			}
+		default:
+			err := &configErr{tk, fmt.Sprintf("Expected no_auth_user to be a string or a map, got %T", v)}
+			*errors = append(*errors, err)
+		}
+	case "consumer_leader_change_grace":
+		dur := parseDuration("consumer_leader_change_grace", tk, v, errors, warnings)
+		if dur < 0 {
+			err := &configErr{tk, fmt.Sprintf("invalid consumer_leader_change_grace of %v, must not be negative", dur)}
+			*errors = append(*errors, err)
+			return
+		}
+		o.ConsumerLeaderChangeGrace = dur
+	case "log_consumer_leader_changes":
+		b, ok := v.(bool)
+		if !ok {
+			err := &configErr{tk, fmt.Sprintf("Expected log_consumer_leader_changes to be a boolean, got %T", v)}
+			*errors = append(*errors, err)
+			return
+		}
+		o.LogConsumerLeaderChanges = b
+	case "auth_timeouts":
+		am, ok := v.(map[string]any)
+		if !ok {
+			err := &configErr{tk, fmt.Sprintf("Expected auth_timeouts to be a map, got %T", v)}
+			*errors = append(*errors, err)
+			return
+		}
+		for mk, mv := range am {
+			tk, mv := unwrapValue(mv, &lt)
+			kind := strings.ToLower(mk)
+			switch kind {
+			case "client", "cluster", "gateway", "leafnode", "websocket", "mqtt":
+			default:
+				err := &configErr{tk, fmt.Sprintf("error parsing auth_timeouts, unknown connection type %q", mk)}
+				*errors = append(*errors, err)
+				continue
+			}
+			at, err := parseAuthTimeout(tk, "auth_timeouts", mk, mv, warnings)
+			if err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
+			if at < 0 {
+				err := &configErr{tk, fmt.Sprintf("error parsing auth_timeouts, '%s' can not be negative", mk)}
+				*errors = append(*errors, err)
+				continue
+			}
+			if o.AuthTimeouts == nil {
+				o.AuthTimeouts = make(map[string]float64)
+			}
+			o.AuthTimeouts[kind] = at
+		}
+	case "enable_configz":
+		b, ok := v.(bool)
+		if !ok {
+			err := &configErr{tk, fmt.Sprintf("Expected enable_configz to be a boolean, got %T", v)}
+			*errors = append(*errors, err)
+			return
+		}
+		o.EnableConfigz = b
+	case "tls_min_version":
+		version, err := parseTLSVersion(v)
+		if err != nil {
+			*errors = append(*errors, &configErr{tk, fmt.Sprintf("error parsing tls_min_version: %v", err)})
+			return
+		}
+		o.TLSMinVersion = version
+	case "no_config_file_metadata":
+		b, ok := v.(bool)
+		if !ok {
+			err := &configErr{tk, fmt.Sprintf("Expected no_config_file_metadata to be a boolean, got %T", v)}
+			*errors = append(*errors, err)
+			return
+		}
+		o.NoConfigFileMetadata = b
+	case "accounts_required":
+		arr, ok := v.([]any)
+		if !ok {
+			err := &configErr{tk, fmt.Sprintf("error parsing accounts_required: expected an array, got %T", v)}
+			*errors = append(*errors, err)
+			return
+		}
+		o.AccountsRequired = make([]string, 0, len(arr))
+		for _, mv := range arr {
+			tk, mv = unwrapValue(mv, &lt)
+			name, ok := mv.(string)
+			if !ok || name == _EMPTY_ {
+				err := &configErr{tk, "error parsing accounts_required: each entry must be a non-empty account name"}
+				*errors = append(*errors, err)
+				continue
+			}
+			o.AccountsRequired = append(o.AccountsRequired, name)
+		}
+	case "system_account", "system", "qos_profiles":
 		// Already processed at the beginning so we just skip them
 		// to not treat them as unknown values.
 		return
@@ -1898,8 +2605,10 @@ func (o *Options) processConfigFileLine(k string, v any, errors *[]error, warnin
 			return
 		}
 		o.Proxies = proxies
+	case "strict_unknown_fields":
+		// Handled by parseUnknownFieldsPolicy.
 	default:
-		if au := atomic.LoadInt32(&allowUnknownTopLevelField); au == 0 && !tk.IsUsedVariable() {
+		if !ufp.allowUnknown(tk) && !tk.IsUsedVariable() {
 			err := &unknownConfigFieldErr{
 				field: k,
 				configErr: configErr{
@@ -2022,6 +2731,11 @@ func parseCluster(v any, opts *Options, errors *[]error, warnings *[]error) erro
 				continue
 			}
 			opts.Cluster.Name = cn
+		case "debug", "trace":
+			if err := parseListenerLogging(tk, mk, mv, &opts.Cluster.Debug, &opts.Cluster.Trace); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 		case "listen":
 			hp, err := parseListen(mv)
 			if err != nil {
@@ -2106,19 +2820,16 @@ func parseCluster(v any, opts *Options, errors *[]error, warnings *[]error) erro
 		case "connect_backoff":
 			opts.Cluster.ConnectBackoff = mv.(bool)
 		case "permissions":
-			perms, err := parseUserPermissions(mv, errors)
+			perms, accPerms, err := parseClusterPermissions(tk, mv, errors)
 			if err != nil {
 				*errors = append(*errors, err)
 				continue
 			}
-			// Dynamic response permissions do not make sense here.
-			if perms.Response != nil {
-				err := &configErr{tk, "Cluster permissions do not support dynamic responses"}
-				*errors = append(*errors, err)
-				continue
+			if perms != nil {
+				// This will possibly override permissions that were define in auth block
+				setClusterPermissions(&opts.Cluster, perms)
 			}
-			// This will possibly override permissions that were define in auth block
-			setClusterPermissions(&opts.Cluster, perms)
+			opts.Cluster.AccountPermissions = accPerms
 		case "pool_size":
 			opts.Cluster.PoolSize = int(mv.(int64))
 		case "accounts":
@@ -2155,6 +2866,92 @@ func parseCluster(v any, opts *Options, errors *[]error, warnings *[]error) erro
 	return nil
 }
 
+// listenerLogging returns whether any listener enables debug or trace
+// logging for its connections.
+func (o *Options) listenerLogging() (debug, trace bool) {
+	debug = o.Cluster.Debug || o.Gateway.Debug || o.LeafNode.Debug || o.Websocket.Debug || o.MQTT.Debug
+	trace = o.Cluster.Trace || o.Gateway.Trace || o.LeafNode.Trace || o.Websocket.Trace || o.MQTT.Trace
+	return debug, trace
+}
+
+// parseHTTP parses the monitoring endpoint block, which allows a TLS
+// configuration separate from the one used for client connections.
+func parseHTTP(v any, o *Options, errors *[]error) error {
+	var lt token
+	defer convertPanicToErrorList(&lt, errors)
+
+	tk, v := unwrapValue(v, &lt)
+	hm, ok := v.(map[string]any)
+	if !ok {
+		return &configErr{tk, fmt.Sprintf("Expected http to be a map, got %T", v)}
+	}
+	for mk, mv := range hm {
+		tk, mv = unwrapValue(mv, &lt)
+		switch strings.ToLower(mk) {
+		case "listen":
+			hp, err := parseListen(mv)
+			if err != nil {
+				*errors = append(*errors, &configErr{tk, err.Error()})
+				continue
+			}
+			o.HTTPHost = hp.host
+			o.HTTPPort = hp.port
+		case "https_listen":
+			hp, err := parseListen(mv)
+			if err != nil {
+				*errors = append(*errors, &configErr{tk, err.Error()})
+				continue
+			}
+			o.HTTPHost = hp.host
+			o.HTTPSPort = hp.port
+		case "base_path":
+			o.HTTPBasePath = mv.(string)
+		case "tls":
+			tc, err := parseTLS(tk, true)
+			if err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
+			if err := checkTLSExpectedServerName(tk, tc); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
+			if o.HTTPTLSConfig, err = GenTLSConfig(tc); err != nil {
+				*errors = append(*errors, &configErr{tk, err.Error()})
+				continue
+			}
+			o.httpTLSConfigOpts = tc
+		default:
+			if !tk.IsUsedVariable() {
+				err := &unknownConfigFieldErr{
+					field: mk,
+					configErr: configErr{
+						token: tk,
+					},
+				}
+				*errors = append(*errors, err)
+				continue
+			}
+		}
+	}
+	return nil
+}
+
+// parseListenerLogging parses the "debug" and "trace" fields that enable
+// verbose logging for the connections of a single listener.
+func parseListenerLogging(tk token, mk string, mv any, debug, trace *bool) error {
+	v, ok := mv.(bool)
+	if !ok {
+		return &configErr{tk, fmt.Sprintf("Expected %s to be a boolean, got %T", mk, mv)}
+	}
+	if strings.EqualFold(mk, "debug") {
+		*debug = v
+	} else {
+		*trace = v
+	}
+	return nil
+}
+
 // The parameter `chosenModeForOn` indicates which compression mode to use
 // when the user selects "on" (or enabled, true, etc..). This is because
 // we may have different defaults depending on where the compression is used.
@@ -2264,6 +3061,11 @@ func parseGateway(v any, o *Options, errors *[]error, warnings *[]error) error {
 				continue
 			}
 			o.Gateway.Name = gn
+		case "debug", "trace":
+			if err := parseListenerLogging(tk, mk, mv, &o.Gateway.Debug, &o.Gateway.Trace); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 		case "listen":
 			hp, err := parseListen(mv)
 			if err != nil {
@@ -2327,6 +3129,25 @@ func parseGateway(v any, o *Options, errors *[]error, warnings *[]error) error {
 			o.Gateway.Gateways = gateways
 		case "reject_unknown", "reject_unknown_cluster":
 			o.Gateway.RejectUnknown = mv.(bool)
+		case "allowed_unknown_clusters":
+			// Only consulted when reject_unknown is set, to let those clusters through.
+			arr, ok := mv.([]any)
+			if !ok {
+				err := &configErr{tk, fmt.Sprintf("error parsing allowed_unknown_clusters: expected an array of strings, got %T", mv)}
+				*errors = append(*errors, err)
+				continue
+			}
+			o.Gateway.AllowedUnknown = make([]string, 0, len(arr))
+			for _, v := range arr {
+				tk, v := unwrapValue(v, &lt)
+				name, ok := v.(string)
+				if !ok || name == _EMPTY_ {
+					err := &configErr{tk, "error parsing allowed_unknown_clusters: each entry must be a non-empty cluster name"}
+					*errors = append(*errors, err)
+					continue
+				}
+				o.Gateway.AllowedUnknown = append(o.Gateway.AllowedUnknown, name)
+			}
 		case "write_deadline":
 			o.Gateway.WriteDeadline = parseDuration("write_deadline", tk, mv, errors, warnings)
 		case "write_timeout":
@@ -2347,7 +3168,7 @@ func parseGateway(v any, o *Options, errors *[]error, warnings *[]error) error {
 	return nil
 }
 
-var dynamicJSAccountLimits = JetStreamAccountLimits{-1, -1, -1, -1, -1, -1, -1, false}
+var dynamicJSAccountLimits = JetStreamAccountLimits{-1, -1, -1, -1, -1, -1, -1, false, 0}
 var defaultJSAccountTiers = map[string]JetStreamAccountLimits{_EMPTY_: dynamicJSAccountLimits}
 
 // Parses jetstream account limits for an account. Simple setup with boolen is allowed, and we will
@@ -2373,7 +3194,7 @@ func parseJetStreamForAccount(v any, acc *Account, errors *[]error) error {
 			return &configErr{tk, fmt.Sprintf("Expected 'enabled' or 'disabled' for string value, got '%s'", vv)}
 		}
 	case map[string]any:
-		jsLimits := JetStreamAccountLimits{-1, -1, -1, -1, -1, -1, -1, false}
+		jsLimits := JetStreamAccountLimits{-1, -1, -1, -1, -1, -1, -1, false, 0}
 		for mk, mv := range vv {
 			tk, mv = unwrapValue(mv, &lt)
 			switch strings.ToLower(mk) {
@@ -2425,6 +3246,27 @@ func parseJetStreamForAccount(v any, acc *Account, errors *[]error) error {
 					return &configErr{tk, fmt.Sprintf("Expected a parseable size for %q, got %v", mk, mv)}
 				}
 				jsLimits.MaxAckPending = int(vv)
+			case "max_ha_assets":
+				vv, ok := mv.(int64)
+				if !ok || vv < 0 {
+					return &configErr{tk, fmt.Sprintf("Expected an absolute size for %q, got %v", mk, mv)}
+				}
+				jsLimits.MaxHAAssets = int(vv)
+			case "max_buffered_msgs":
+				vv, ok := mv.(int64)
+				if !ok || vv < 0 {
+					return &configErr{tk, fmt.Sprintf("Expected an absolute size for %q, got %v", mk, mv)}
+				}
+				acc.jsMaxBufferedMsgs = int(vv)
+			case "max_buffered_size":
+				vv, err := getStorageSize(mv)
+				if err != nil {
+					return &configErr{tk, fmt.Sprintf("%s %s", strings.ToLower(mk), err)}
+				}
+				if vv < 0 {
+					return &configErr{tk, fmt.Sprintf("Expected an absolute size for %q, got %v", mk, mv)}
+				}
+				acc.jsMaxBufferedSize = vv
 			case "cluster_traffic":
 				vv, ok := mv.(string)
 				if !ok {
@@ -2522,6 +3364,63 @@ func parseJetStreamLimits(v any, opts *Options, errors *[]error) error {
 			if err := parseJetStreamLimitsBatch(tk, opts, errors); err != nil {
 				return err
 			}
+		case "max_duplicate_entries":
+			// Zero means to rely on the duplicate window only.
+			n := mv.(int64)
+			if n < 0 {
+				err := &configErr{tk, fmt.Sprintf("max_duplicate_entries must be positive, got %d", n)}
+				*errors = append(*errors, err)
+				continue
+			}
+			opts.JetStreamLimits.MaxDuplicateEntries = int(n)
+		case "max_consumer_name_len", "max_consumer_description_len":
+			// Zero means to inherit the hard limit.
+			n, ok := mv.(int64)
+			if !ok {
+				err := &configErr{tk, fmt.Sprintf("%s must be an integer, got %T", mk, mv)}
+				*errors = append(*errors, err)
+				continue
+			}
+			hard := JSMaxNameLen
+			if strings.ToLower(mk) == "max_consumer_description_len" {
+				hard = JSMaxDescriptionLen
+			}
+			if n < 0 || n > int64(hard) {
+				err := &configErr{tk, fmt.Sprintf("%s must be between 0 and %d, got %d", mk, hard, n)}
+				*errors = append(*errors, err)
+				continue
+			}
+			if hard == JSMaxNameLen {
+				opts.JetStreamLimits.MaxConsumerNameLen = int(n)
+			} else {
+				opts.JetStreamLimits.MaxConsumerDescriptionLen = int(n)
+			}
+		case "max_consumer_pending_bytes":
+			n, err := getStorageSize(mv)
+			if err != nil {
+				*errors = append(*errors, &configErr{tk, err.Error()})
+				continue
+			}
+			if n < 0 {
+				err := &configErr{tk, fmt.Sprintf("max_consumer_pending_bytes must be positive, got %d", n)}
+				*errors = append(*errors, err)
+				continue
+			}
+			opts.JetStreamLimits.MaxConsumerPendingBytes = n
+		case "max_stream_subjects":
+			// Zero means unlimited.
+			n, ok := mv.(int64)
+			if !ok {
+				err := &configErr{tk, fmt.Sprintf("%s must be an integer, got %T", mk, mv)}
+				*errors = append(*errors, err)
+				continue
+			}
+			if n < 0 {
+				err := &configErr{tk, fmt.Sprintf("max_stream_subjects must be positive, got %d", n)}
+				*errors = append(*errors, err)
+				continue
+			}
+			opts.JetStreamLimits.MaxStreamSubjects = int(n)
 		default:
 			if !tk.IsUsedVariable() {
 				err := &unknownConfigFieldErr{
@@ -2619,6 +3518,35 @@ func parseJetStreamTPM(v interface{}, opts *Options, errors *[]error) error {
 	return nil
 }
 
+// jetStreamKeyFromEnv resolves a key given as "${VAR}" from the environment,
+// so the key does not need to be kept inline in the configuration file.
+// Any other value is returned as is.
+func jetStreamKeyFromEnv(key string) (string, error) {
+	if !strings.HasPrefix(key, "${") || !strings.HasSuffix(key, "}") {
+		return key, nil
+	}
+	name := key[2 : len(key)-1]
+	v, ok := os.LookupEnv(name)
+	if !ok || v == _EMPTY_ {
+		return _EMPTY_, fmt.Errorf("jetstream encryption key environment variable %q is not set", name)
+	}
+	return v, nil
+}
+
+// jetStreamKeyFromFile reads the JetStream encryption key from a file,
+// surrounding whitespace and newlines are ignored.
+func jetStreamKeyFromFile(file string) (string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return _EMPTY_, fmt.Errorf("error reading jetstream encryption key_file: %v", err)
+	}
+	key := strings.TrimSpace(string(b))
+	if key == _EMPTY_ {
+		return _EMPTY_, fmt.Errorf("jetstream encryption key_file %q is empty", file)
+	}
+	return key, nil
+}
+
 func setJetStreamEkCipher(opts *Options, mv interface{}, tk token) error {
 	switch strings.ToLower(mv.(string)) {
 	case "chacha", "chachapoly":
@@ -2655,9 +3583,35 @@ func parseJetStream(v any, opts *Options, errors *[]error, warnings *[]error) er
 		}
 	case map[string]any:
 		doEnable := true
+		// Kept aside since the limits block resets all the limits.
+		var fcHeartbeat time.Duration
 		for mk, mv := range vv {
 			tk, mv = unwrapValue(mv, &lt)
 			switch strings.ToLower(mk) {
+			case "default_flow_control_heartbeat":
+				fcHeartbeat = parseDuration(mk, tk, mv, errors, warnings)
+				if fcHeartbeat < JsMinFlowControlHeartbeat {
+					return &configErr{tk, fmt.Sprintf("%s must be at least %v, got %v", mk, JsMinFlowControlHeartbeat, fcHeartbeat)}
+				}
+			case "ephemeral_delete_jitter":
+				jitter := parseDuration(mk, tk, mv, errors, warnings)
+				if jitter < 0 {
+					return &configErr{tk, fmt.Sprintf("%s can not be negative, got %v", mk, jitter)}
+				}
+				opts.JetStreamEphemeralDeleteJitter = jitter
+				opts.ephemeralDeleteJitterSet = true
+			case "on_corrupt_recovery":
+				action, ok := mv.(string)
+				if !ok {
+					return &configErr{tk, fmt.Sprintf("Expected a string for %s, got %T", mk, mv)}
+				}
+				switch action = strings.ToLower(action); action {
+				case JsCorruptRecoveryFail, JsCorruptRecoverySkip, JsCorruptRecoveryQuarantine:
+					opts.JetStreamOnCorruptRecovery = action
+				default:
+					return &configErr{tk, fmt.Sprintf("%s must be one of %q, %q or %q, got %q",
+						mk, JsCorruptRecoveryFail, JsCorruptRecoverySkip, JsCorruptRecoveryQuarantine, action)}
+				}
 			case "strict":
 				if v, ok := mv.(bool); ok {
 					opts.NoJetStreamStrict = !v
@@ -2692,12 +3646,57 @@ func parseJetStream(v any, opts *Options, errors *[]error, warnings *[]error) er
 				}
 				opts.JetStreamMaxStore = s
 				opts.maxStoreSet = true
+			case "consumer_info_omit_cluster":
+				v, ok := mv.(bool)
+				if !ok {
+					return &configErr{tk, fmt.Sprintf("Expected a boolean for %q, got %T", mk, mv)}
+				}
+				opts.JetStreamConsumerInfoOmitCluster = v
+			case "min_free_memory", "min_free_mem":
+				s, err := getStorageSize(mv)
+				if err != nil {
+					return &configErr{tk, fmt.Sprintf("min_free_memory %s", err)}
+				}
+				if s < 0 {
+					return &configErr{tk, fmt.Sprintf("min_free_memory can not be negative, got %d", s)}
+				}
+				opts.JetStreamMinFreeMemory = s
 			case "domain":
 				opts.JetStreamDomain = mv.(string)
+			case "domain_aliases":
+				arr, ok := mv.([]any)
+				if !ok {
+					return &configErr{tk, fmt.Sprintf("Expected an array of domain names for %q, got %T", mk, mv)}
+				}
+				opts.JetStreamDomainAliases = make([]string, 0, len(arr))
+				for _, v := range arr {
+					tk, v = unwrapValue(v, &lt)
+					alias, ok := v.(string)
+					if !ok {
+						return &configErr{tk, fmt.Sprintf("Expected a domain name for %q, got %T", mk, v)}
+					}
+					opts.JetStreamDomainAliases = append(opts.JetStreamDomainAliases, alias)
+				}
+			case "cluster_name":
+				cn := mv.(string)
+				if strings.Contains(cn, " ") {
+					return &configErr{tk, ErrClusterNameHasSpaces.Error()}
+				}
+				opts.JetStreamClusterName = cn
 			case "enable", "enabled":
 				doEnable = mv.(bool)
 			case "key", "ek", "encryption_key":
-				opts.JetStreamKey = mv.(string)
+				key, err := jetStreamKeyFromEnv(mv.(string))
+				if err != nil {
+					return &configErr{tk, err.Error()}
+				}
+				opts.JetStreamKey = key
+			case "key_file":
+				key, err := jetStreamKeyFromFile(mv.(string))
+				if err != nil {
+					return &configErr{tk, err.Error()}
+				}
+				opts.JetStreamKey = key
 			case "prev_key", "prev_ek", "prev_encryption_key":
 				opts.JetStreamOldKey = mv.(string)
 			case "cipher":
@@ -2757,18 +3756,75 @@ func parseJetStream(v any, opts *Options, errors *[]error, warnings *[]error) er
 				if err != nil {
 					return &configErr{tk, fmt.Sprintf("%s %s", strings.ToLower(mk), err)}
 				}
-				if s < 0 {
-					return &configErr{tk, fmt.Sprintf("Expected an absolute size for %q, got %v", mk, mv)}
+				if s < 0 {
+					return &configErr{tk, fmt.Sprintf("Expected an absolute size for %q, got %v", mk, mv)}
+				}
+				opts.JetStreamMetaCompactSize = uint64(s)
+			case "meta_compact_sync":
+				opts.JetStreamMetaCompactSync = mv.(bool)
+			case "max_concurrent_io":
+				dios, ok := mv.(int64)
+				if !ok || dios < minConcurrentIOs || dios > maxConcurrentIOs {
+					return &configErr{tk, fmt.Sprintf("Expected an absolute size for %q between 4 and 8192, got %v", mk, mv)}
+				}
+				opts.JetStreamConcurrentIOs = int(dios)
+			case "max_concurrent_snapshots", "max_concurrent_restores":
+				n, ok := mv.(int64)
+				if !ok || n < 0 {
+					return &configErr{tk, fmt.Sprintf("Expected a non-negative integer for %q, got %v", mk, mv)}
+				}
+				if strings.ToLower(mk) == "max_concurrent_snapshots" {
+					opts.JetStreamMaxConcurrentSnapshots = int(n)
+				} else {
+					opts.JetStreamMaxConcurrentRestores = int(n)
+				}
+			case "disallow_network_storage":
+				if v, ok := mv.(bool); ok {
+					opts.JetStreamDisallowNetworkStorage = v
+				} else {
+					return &configErr{tk, fmt.Sprintf("Expected 'true' or 'false' for bool value, got '%s'", mv)}
+				}
+			case "require_explicit_deliver_policy":
+				if v, ok := mv.(bool); ok {
+					opts.JetStreamRequireExplicitDeliverPolicy = v
+				} else {
+					return &configErr{tk, fmt.Sprintf("Expected 'true' or 'false' for bool value, got '%s'", mv)}
+				}
+			case "audit_subject":
+				subj, ok := mv.(string)
+				if !ok || (subj != _EMPTY_ && !IsValidPublishSubject(subj)) {
+					return &configErr{tk, fmt.Sprintf("Expected a valid publish subject for %q, got %v", mk, mv)}
+				}
+				opts.JetStreamAuditSubject = subj
+			case "allowed_accounts":
+				arr, ok := mv.([]any)
+				if !ok {
+					return &configErr{tk, fmt.Sprintf("Expected an array of account names for %q, got %T", mk, mv)}
+				}
+				opts.JetStreamAllowedAccounts = make([]string, 0, len(arr))
+				for _, v := range arr {
+					tk, v = unwrapValue(v, &lt)
+					name, _ := v.(string)
+					if name == _EMPTY_ || strings.ContainsAny(name, " \t\r\n*>") {
+						return &configErr{tk, fmt.Sprintf("Expected a valid account name for %q, got %v", mk, v)}
+					}
+					opts.JetStreamAllowedAccounts = append(opts.JetStreamAllowedAccounts, name)
 				}
-				opts.JetStreamMetaCompactSize = uint64(s)
-			case "meta_compact_sync":
-				opts.JetStreamMetaCompactSync = mv.(bool)
-			case "max_concurrent_io":
-				dios, ok := mv.(int64)
-				if !ok || dios < minConcurrentIOs || dios > maxConcurrentIOs {
-					return &configErr{tk, fmt.Sprintf("Expected an absolute size for %q between 4 and 8192, got %v", mk, mv)}
+			case "preload_streams":
+				arr, ok := mv.([]any)
+				if !ok {
+					return &configErr{tk, fmt.Sprintf("Expected an array of \"account:stream\" for %q, got %T", mk, mv)}
+				}
+				opts.JetStreamPreloadStreams = make([]string, 0, len(arr))
+				for _, v := range arr {
+					tk, v = unwrapValue(v, &lt)
+					entry, _ := v.(string)
+					acc, stream, ok := strings.Cut(entry, ":")
+					if !ok || acc == _EMPTY_ || !isValidName(stream) {
+						return &configErr{tk, fmt.Sprintf("Expected \"account:stream\" for %q, got %v", mk, v)}
+					}
+					opts.JetStreamPreloadStreams = append(opts.JetStreamPreloadStreams, entry)
 				}
-				opts.JetStreamConcurrentIOs = int(dios)
 			default:
 				if !tk.IsUsedVariable() {
 					err := &unknownConfigFieldErr{
@@ -2783,6 +3839,7 @@ func parseJetStream(v any, opts *Options, errors *[]error, warnings *[]error) er
 			}
 		}
 		opts.JetStream = doEnable
+		opts.JetStreamLimits.FlowControlHeartbeat = fcHeartbeat
 	default:
 		return &configErr{tk, fmt.Sprintf("Expected map, bool or string to define JetStream, got %T", v)}
 	}
@@ -2801,10 +3858,17 @@ func parseLeafNodes(v any, opts *Options, errors *[]error, warnings *[]error) er
 		return &configErr{tk, fmt.Sprintf("Expected map to define a leafnode, got %T", v)}
 	}
 
+	// Remotes are parsed last, since they are checked against other fields.
+	var remotesToken token
 	for mk, mv := range cm {
 		// Again, unwrap token value if line check is required.
 		tk, mv = unwrapValue(mv, &lt)
 		switch strings.ToLower(mk) {
+		case "debug", "trace":
+			if err := parseListenerLogging(tk, mk, mv, &opts.LeafNode.Debug, &opts.LeafNode.Trace); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 		case "listen":
 			hp, err := parseListen(mv)
 			if err != nil {
@@ -2837,13 +3901,7 @@ func parseLeafNodes(v any, opts *Options, errors *[]error, warnings *[]error) er
 				continue
 			}
 		case "remotes":
-			// Parse the remote options here.
-			remotes, err := parseRemoteLeafNodes(tk, errors, warnings)
-			if err != nil {
-				*errors = append(*errors, err)
-				continue
-			}
-			opts.LeafNode.Remotes = remotes
+			remotesToken = tk
 		case "reconnect", "reconnect_delay", "reconnect_interval":
 			opts.LeafNode.ReconnectInterval = parseDuration("reconnect", tk, mv, errors, warnings)
 		case "tls":
@@ -2852,6 +3910,10 @@ func parseLeafNodes(v any, opts *Options, errors *[]error, warnings *[]error) er
 				*errors = append(*errors, err)
 				continue
 			}
+			if err := checkTLSExpectedServerName(tk, tc); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 			if opts.LeafNode.TLSConfig, err = GenTLSConfig(tc); err != nil {
 				err := &configErr{tk, err.Error()}
 				*errors = append(*errors, err)
@@ -2885,6 +3947,22 @@ func parseLeafNodes(v any, opts *Options, errors *[]error, warnings *[]error) er
 			opts.LeafNode.IsolateLeafnodeInterest = mv.(bool)
 		case "write_deadline":
 			opts.LeafNode.WriteDeadline = parseDuration("write_deadline", tk, mv, errors, warnings)
+		case "max_accounts_per_connection":
+			maxAccs := int(mv.(int64))
+			if maxAccs < 0 {
+				err := &configErr{tk, fmt.Sprintf("%s can not be negative, got %d", mk, maxAccs)}
+				*errors = append(*errors, err)
+				continue
+			}
+			opts.LeafNode.MaxAccountsPerConnection = maxAccs
+		case "max_urls_per_remote":
+			maxURLs := int(mv.(int64))
+			if maxURLs < 0 {
+				err := &configErr{tk, fmt.Sprintf("%s can not be negative, got %d", mk, maxURLs)}
+				*errors = append(*errors, err)
+				continue
+			}
+			opts.LeafNode.MaxURLsPerRemote = maxURLs
 		case "write_timeout":
 			opts.LeafNode.WriteTimeout = parseWriteDeadlinePolicy(tk, mv.(string), errors)
 		default:
@@ -2900,6 +3978,15 @@ func parseLeafNodes(v any, opts *Options, errors *[]error, warnings *[]error) er
 			}
 		}
 	}
+	if remotesToken != nil {
+		// Parse the remote options here.
+		remotes, err := parseRemoteLeafNodes(remotesToken, opts.LeafNode.MaxURLsPerRemote, errors, warnings)
+		if err != nil {
+			*errors = append(*errors, err)
+		} else {
+			opts.LeafNode.Remotes = remotes
+		}
+	}
 	return nil
 }
 
@@ -3039,7 +4126,7 @@ func parseLeafUsers(mv any, errors *[]error) ([]*User, error) {
 	return users, nil
 }
 
-func parseRemoteLeafNodes(v any, errors *[]error, warnings *[]error) ([]*RemoteLeafOpts, error) {
+func parseRemoteLeafNodes(v any, maxURLs int, errors *[]error, warnings *[]error) ([]*RemoteLeafOpts, error) {
 	var lt token
 	defer convertPanicToErrorList(&lt, errors)
 	tk, v := unwrapValue(v, &lt)
@@ -3057,6 +4144,7 @@ func parseRemoteLeafNodes(v any, errors *[]error, warnings *[]error) ([]*RemoteL
 			*errors = append(*errors, &configErr{tk, fmt.Sprintf("Expected remote leafnode entry to be a map/struct, got %v", r)})
 			continue
 		}
+		remoteToken := tk
 		remote := &RemoteLeafOpts{}
 		var proxyToken token
 		for k, v := range rm {
@@ -3130,6 +4218,7 @@ func parseRemoteLeafNodes(v any, errors *[]error, warnings *[]error) ([]*RemoteL
 					remote.TLSTimeout = float64(DEFAULT_LEAF_TLS_TIMEOUT) / float64(time.Second)
 				}
 				remote.TLSHandshakeFirst = tc.HandshakeFirst
+				remote.TLSExpectedServerName = tc.ExpectedServerName
 				remote.tlsConfigOpts = tc
 			case "hub":
 				remote.Hub = v.(bool)
@@ -3147,6 +4236,20 @@ func parseRemoteLeafNodes(v any, errors *[]error, warnings *[]error) ([]*RemoteL
 					continue
 				}
 				remote.DenyExports = subjects
+			case "deny_publish", "deny_pub":
+				subjects, err := parsePermSubjects(tk, errors)
+				if err != nil {
+					*errors = append(*errors, err)
+					continue
+				}
+				remote.DenyPublish = subjects
+			case "deny_subscribe", "deny_sub":
+				subjects, err := parsePermSubjects(tk, errors)
+				if err != nil {
+					*errors = append(*errors, err)
+					continue
+				}
+				remote.DenySubscribe = subjects
 			case "ws_compress", "ws_compression", "websocket_compress", "websocket_compression":
 				remote.Websocket.Compression = v.(bool)
 			case "ws_no_masking", "websocket_no_masking":
@@ -3242,6 +4345,10 @@ func parseRemoteLeafNodes(v any, errors *[]error, warnings *[]error) ([]*RemoteL
 				*warnings = append(*warnings, &configErr{proxyToken, warn})
 			}
 		}
+		if maxURLs > 0 && len(remote.URLs) > maxURLs {
+			*errors = append(*errors, &configErr{remoteToken, fmt.Sprintf("remote leafnode has %d urls, exceeding max_urls_per_remote of %d", len(remote.URLs), maxURLs)})
+			continue
+		}
 		rn := remote.name()
 		if _, dup := names[rn]; dup {
 			*errors = append(*errors, &configErr{tk, fmt.Sprintf("duplicate remote %s", remote.safeName())})
@@ -3253,6 +4360,15 @@ func parseRemoteLeafNodes(v any, errors *[]error, warnings *[]error) ([]*RemoteL
 	return remotes, nil
 }
 
+// Returns an error if the TLS block sets an expected server name, which
+// is only supported for leafnode remotes.
+func checkTLSExpectedServerName(tk token, tc *TLSConfigOpts) error {
+	if tc.ExpectedServerName == _EMPTY_ {
+		return nil
+	}
+	return &configErr{tk, "tls 'expected_server_name' is only supported for leafnode remotes"}
+}
+
 // Parse TLS and returns a TLSConfig and TLSTimeout.
 // Used by cluster and gateway parsing.
 func getTLSConfig(tk token) (*tls.Config, *TLSConfigOpts, error) {
@@ -3260,6 +4376,9 @@ func getTLSConfig(tk token) (*tls.Config, *TLSConfigOpts, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := checkTLSExpectedServerName(tk, tc); err != nil {
+		return nil, nil, err
+	}
 	config, err := GenTLSConfig(tc)
 	if err != nil {
 		err := &configErr{tk, err.Error()}
@@ -3342,17 +4461,89 @@ func parseGateways(v any, errors *[]error, warnings *[]error) ([]*RemoteGatewayO
 // Sets cluster's permissions based on given pub/sub permissions,
 // doing the appropriate translation.
 func setClusterPermissions(opts *ClusterOpts, perms *Permissions) {
+	opts.Permissions = routePermissionsFromPermissions(perms)
+}
+
+// Converts pub/sub permissions as parsed from the configuration into
+// route import/export permissions.
+func routePermissionsFromPermissions(perms *Permissions) *RoutePermissions {
 	// Import is whether or not we will send a SUB for interest to the other side.
 	// Export is whether or not we will accept a SUB from the remote for a given subject.
 	// Both only effect interest registration.
 	// The parsing sets Import into Publish and Export into Subscribe, convert
 	// accordingly.
-	opts.Permissions = &RoutePermissions{
+	return &RoutePermissions{
 		Import: perms.Publish,
 		Export: perms.Subscribe,
 	}
 }
 
+// Parses the cluster's permissions block. In addition to the global
+// import/export permissions, an "accounts" map can be used to specify
+// import/export permissions for routes dedicated to specific accounts.
+// The global permissions are returned as nil if only per-account
+// permissions were specified.
+func parseClusterPermissions(tk token, mv any, errors *[]error) (*Permissions, map[string]*RoutePermissions, error) {
+	pm, ok := mv.(map[string]any)
+	if !ok {
+		return nil, nil, &configErr{tk, fmt.Sprintf("Expected permissions to be a map/struct, got %+v", mv)}
+	}
+	var (
+		lt       token
+		accPerms map[string]*RoutePermissions
+		global   = make(map[string]any, len(pm))
+	)
+	for k, v := range pm {
+		if strings.ToLower(k) != "accounts" {
+			global[k] = v
+			continue
+		}
+		atk, av := unwrapValue(v, &lt)
+		am, ok := av.(map[string]any)
+		if !ok {
+			return nil, nil, &configErr{atk, fmt.Sprintf("Expected cluster accounts permissions to be a map/struct, got %+v", av)}
+		}
+		accPerms = make(map[string]*RoutePermissions, len(am))
+		for accName, ap := range am {
+			ptk, _ := unwrapValue(ap, &lt)
+			perms, err := parseUserPermissions(ap, errors)
+			if err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
+			if perms.Response != nil {
+				*errors = append(*errors, &configErr{ptk, fmt.Sprintf("Cluster permissions for account %q do not support dynamic responses", accName)})
+				continue
+			}
+			accPerms[accName] = routePermissionsFromPermissions(perms)
+		}
+	}
+	if len(global) == 0 && accPerms != nil {
+		return nil, accPerms, nil
+	}
+	perms, err := parseUserPermissions(global, errors)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Dynamic response permissions do not make sense here.
+	if perms.Response != nil {
+		return nil, nil, &configErr{tk, "Cluster permissions do not support dynamic responses"}
+	}
+	return perms, accPerms, nil
+}
+
+// Returns the route permissions that apply to a route for the given account.
+// If the route is not dedicated to an account, or if there are no specific
+// permissions for this account, the global cluster permissions are returned.
+func (o *ClusterOpts) routePermissions(accName string) *RoutePermissions {
+	if accName != _EMPTY_ {
+		if perms, ok := o.AccountPermissions[accName]; ok {
+			return perms
+		}
+	}
+	return o.Permissions
+}
+
 // Temp structures to hold account import and export defintions since they need
 // to be processed after being parsed.
 type export struct {
@@ -3534,11 +4725,22 @@ func parseAccountLimits(mv any, acc *Account, errors *[]error) error {
 		case "max_connections", "max_conn":
 			acc.mconns = int32(mv.(int64))
 		case "max_subscriptions", "max_subs":
-			acc.msubs = int32(mv.(int64))
+			// 0 means that the default applies (no account limit),
+			// and a negative value means explicitly unlimited.
+			acc.msubs = maxSubsLimit(int(mv.(int64)))
 		case "max_payload", "max_pay":
 			acc.mpay = int32(mv.(int64))
 		case "max_leafnodes", "max_leafs":
 			acc.mleafs = int32(mv.(int64))
+		case "max_pending":
+			// 0 means that the server's max_pending applies.
+			mp := mv.(int64)
+			if mp < 0 {
+				err := &configErr{tk, fmt.Sprintf("Account limit max_pending must be positive, got %d", mp)}
+				*errors = append(*errors, err)
+				continue
+			}
+			acc.mpend = mp
 		default:
 			if !tk.IsUsedVariable() {
 				err := &configErr{tk, fmt.Sprintf("Unknown field %q parsing account limits", k)}
@@ -3613,6 +4815,31 @@ func parseAccountMsgTrace(mv any, topKey string, acc *Account) error {
 	return nil
 }
 
+// parseAccountLatencySubjectAllow parses the subjects that service export
+// latency results are allowed to be sent to.
+func parseAccountLatencySubjectAllow(mv any, acc *Account) error {
+	var lt token
+	tk, v := unwrapValue(mv, &lt)
+	var subjects []any
+	switch vv := v.(type) {
+	case string:
+		subjects = []any{vv}
+	case []any:
+		subjects = vv
+	default:
+		return &configErr{tk, fmt.Sprintf("Expected latency_subject_allow to be a string or an array, got %T", v)}
+	}
+	for _, sv := range subjects {
+		tk, sv := unwrapValue(sv, &lt)
+		subj, ok := sv.(string)
+		if !ok || !IsValidSubject(subj) {
+			return &configErr{tk, fmt.Sprintf("Latency subject allow entry %v is not a valid subject", sv)}
+		}
+		acc.latencyAllow = append(acc.latencyAllow, subj)
+	}
+	return nil
+}
+
 // parseAccounts will parse the different accounts syntax.
 func parseAccounts(v any, opts *Options, errors *[]error, warnings *[]error) error {
 	var (
@@ -3678,6 +4905,7 @@ func parseAccounts(v any, opts *Options, errors *[]error, warnings *[]error) err
 				users   []*User
 				nkeyUsr []*NkeyUser
 				usersTk token
+				qos     *QoSProfile
 			)
 			acc := NewAccount(aname)
 			opts.Accounts = append(opts.Accounts, acc)
@@ -3742,6 +4970,11 @@ func parseAccounts(v any, opts *Options, errors *[]error, warnings *[]error) err
 						*errors = append(*errors, err)
 						continue
 					}
+				case "latency_subject_allow":
+					if err := parseAccountLatencySubjectAllow(tk, acc); err != nil {
+						*errors = append(*errors, err)
+						continue
+					}
 				case "msg_trace", "trace_dest":
 					if err := parseAccountMsgTrace(tk, k, acc); err != nil {
 						*errors = append(*errors, err)
@@ -3759,6 +4992,42 @@ func parseAccounts(v any, opts *Options, errors *[]error, warnings *[]error) err
 							&configErr{tk, "Trace destination sampling ignored since no destination was set"})
 						acc.traceDestSampling = 0
 					}
+				case "disable_short_first_ping":
+					dsfp, ok := mv.(bool)
+					if !ok {
+						err := &configErr{tk, fmt.Sprintf("Expected disable_short_first_ping to be a boolean, got %T", mv)}
+						*errors = append(*errors, err)
+						continue
+					}
+					acc.disableShortFirstPing = &dsfp
+				case "require_header_support":
+					rhs, ok := mv.(bool)
+					if !ok {
+						err := &configErr{tk, fmt.Sprintf("Expected require_header_support to be a boolean, got %T", mv)}
+						*errors = append(*errors, err)
+						continue
+					}
+					acc.requireHeaderSupport = rhs
+				case "allow_push_consumers":
+					apc, ok := mv.(bool)
+					if !ok {
+						err := &configErr{tk, fmt.Sprintf("Expected allow_push_consumers to be a boolean, got %T", mv)}
+						*errors = append(*errors, err)
+						continue
+					}
+					acc.noPushConsumers = !apc
+				case "qos_profile":
+					name, ok := mv.(string)
+					if !ok {
+						err := &configErr{tk, fmt.Sprintf("Expected qos_profile to be a string, got %T", mv)}
+						*errors = append(*errors, err)
+						continue
+					}
+					if qos, ok = opts.QoSProfiles[name]; !ok {
+						err := &configErr{tk, fmt.Sprintf("Unknown qos_profile %q for account %q", name, aname)}
+						*errors = append(*errors, err)
+						continue
+					}
 				default:
 					if !tk.IsUsedVariable() {
 						err := &unknownConfigFieldErr{
@@ -3771,6 +5040,13 @@ func parseAccounts(v any, opts *Options, errors *[]error, warnings *[]error) err
 					}
 				}
 			}
+			// A max_pending set in the account limits takes precedence over the profile.
+			if qos != nil {
+				if acc.mpend == 0 {
+					acc.mpend = qos.MaxPending
+				}
+				acc.wdl = qos.WriteDeadline
+			}
 			// Report error if there is an authorization{} block
 			// with u/p or token and any user defined in accounts{}
 			if len(nkeyUsr) > 0 || len(users) > 0 {
@@ -4502,6 +5778,31 @@ func applyDefaultPermissions(users []*User, nkeys []*NkeyUser, defaultP *Permiss
 	}
 }
 
+// parseAuthTimeout parses an auth timeout, given in seconds or as a duration string,
+// warning when it is unusually high. The context is used in error messages.
+func parseAuthTimeout(tk token, context, field string, v any, warnings *[]error) (float64, error) {
+	at := float64(0)
+	switch mv := v.(type) {
+	case int64:
+		at = float64(mv)
+	case float64:
+		at = mv
+	case string:
+		d, err := time.ParseDuration(mv)
+		if err != nil {
+			return 0, &configErr{tk, fmt.Sprintf("error parsing %s, '%s' %s", context, field, err)}
+		}
+		at = d.Seconds()
+	default:
+		return 0, &configErr{tk, fmt.Sprintf("error parsing %s, '%s' wrong type", context, field)}
+	}
+	if at > (60 * time.Second).Seconds() {
+		reason := fmt.Sprintf("timeout of %v (%f seconds) is high, consider keeping it under 60 seconds. possibly caused by unquoted duration; use '1m' instead of 1m, for example", v, at)
+		*warnings = append(*warnings, &configWarningErr{field: field, configErr: configErr{token: tk, reason: reason}})
+	}
+	return at, nil
+}
+
 // Helper function to parse Authorization configs.
 func parseAuthorization(v any, errors, warnings *[]error) (*authorization, error) {
 	var (
@@ -4524,24 +5825,9 @@ func parseAuthorization(v any, errors, warnings *[]error) (*authorization, error
 		case "token":
 			auth.token = mv.(string)
 		case "timeout":
-			at := float64(0)
-			switch mv := mv.(type) {
-			case int64:
-				at = float64(mv)
-			case float64:
-				at = mv
-			case string:
-				d, err := time.ParseDuration(mv)
-				if err != nil {
-					return nil, &configErr{tk, fmt.Sprintf("error parsing authorization config, 'timeout' %s", err)}
-				}
-				at = d.Seconds()
-			default:
-				return nil, &configErr{tk, "error parsing authorization config, 'timeout' wrong type"}
-			}
-			if at > (60 * time.Second).Seconds() {
-				reason := fmt.Sprintf("timeout of %v (%f seconds) is high, consider keeping it under 60 seconds. possibly caused by unquoted duration; use '1m' instead of 1m, for example", mv, at)
-				*warnings = append(*warnings, &configWarningErr{field: mk, configErr: configErr{token: tk, reason: reason}})
+			at, err := parseAuthTimeout(tk, "authorization config", mk, mv, warnings)
+			if err != nil {
+				return nil, err
 			}
 			auth.timeout = at
 		case "users":
@@ -4647,6 +5933,15 @@ func parseUsers(mv any, errors *[]error) ([]*NkeyUser, []*User, error) {
 			case "proxy_required":
 				nkey.ProxyRequired = v.(bool)
 				user.ProxyRequired = v.(bool)
+			case "monitoring_only":
+				mo, ok := v.(bool)
+				if !ok {
+					err := &configErr{tk, fmt.Sprintf("Expected monitoring_only to be a boolean, got %T", v)}
+					*errors = append(*errors, err)
+					continue
+				}
+				nkey.MonitoringOnly = mo
+				user.MonitoringOnly = mo
 			default:
 				if !tk.IsUsedVariable() {
 					err := &unknownConfigFieldErr{
@@ -5056,6 +6351,36 @@ func parseTLSVersion(v any) (uint16, error) {
 	return tlsVersionNumber, nil
 }
 
+// parseTLSAccountMapping parses the `account_mapping` block of a TLS config
+// and returns the certificate attribute to derive the account from.
+func parseTLSAccountMapping(tk token, v any) (string, error) {
+	am, ok := v.(map[string]any)
+	if !ok {
+		return _EMPTY_, &configErr{tk, "error parsing tls config, expected 'account_mapping' to be a map"}
+	}
+	var from string
+	for k, v := range am {
+		tk, v := unwrapValue(v, nil)
+		switch strings.ToLower(k) {
+		case "from":
+			f, ok := v.(string)
+			if !ok {
+				return _EMPTY_, &configErr{tk, "error parsing tls config, expected 'account_mapping' 'from' to be a string"}
+			}
+			from = strings.ToUpper(f)
+			if _, ok := tlsAccountMappingFields[from]; !ok {
+				return _EMPTY_, &configErr{tk, fmt.Sprintf("error parsing tls config, unsupported 'account_mapping' certificate attribute %q", f)}
+			}
+		default:
+			return _EMPTY_, &configErr{tk, fmt.Sprintf("error parsing tls config, unknown 'account_mapping' field %q", k)}
+		}
+	}
+	if from == _EMPTY_ {
+		return _EMPTY_, &configErr{tk, "error parsing tls config, 'account_mapping' requires a 'from' field"}
+	}
+	return from, nil
+}
+
 // Helper function to parse TLS configs.
 func parseTLS(v any, isClientCtx bool) (t *TLSConfigOpts, retErr error) {
 	var (
@@ -5128,6 +6453,26 @@ func parseTLS(v any, isClientCtx bool) (t *TLSConfigOpts, retErr error) {
 				return nil, &configErr{tk, "error parsing tls config, expected 'allow_insecure_cipher_suites' to be a boolean"}
 			}
 			tc.AllowInsecureCiphers = allow
+		case "session_tickets":
+			tickets, ok := mv.(bool)
+			if !ok {
+				return nil, &configErr{tk, "error parsing tls config, expected 'session_tickets' to be a boolean"}
+			}
+			tc.SessionTicketsDisabled = !tickets
+		case "allowed_sni":
+			ra, ok := mv.([]any)
+			if !ok {
+				return nil, &configErr{tk, "error parsing tls config, expected 'allowed_sni' to be an array of hostnames"}
+			}
+			tc.AllowedSNI = make([]string, 0, len(ra))
+			for _, r := range ra {
+				tk, r := unwrapValue(r, &lt)
+				host, ok := r.(string)
+				if !ok || host == _EMPTY_ {
+					return nil, &configErr{tk, "error parsing tls config, 'allowed_sni' hostnames cannot be empty"}
+				}
+				tc.AllowedSNI = append(tc.AllowedSNI, host)
+			}
 		case "cipher_suites":
 			ra := mv.([]any)
 			if len(ra) == 0 {
@@ -5203,6 +6548,18 @@ func parseTLS(v any, isClientCtx bool) (t *TLSConfigOpts, retErr error) {
 				}
 				tc.PinnedCerts = wl
 			}
+		case "expected_server_name", "verify_server_identity":
+			name, ok := mv.(string)
+			if !ok || name == _EMPTY_ {
+				return nil, &configErr{tk, fmt.Sprintf("error parsing tls config, expected '%s' to be a non-empty string", mk)}
+			}
+			tc.ExpectedServerName = name
+		case "account_mapping":
+			from, err := parseTLSAccountMapping(tk, mv)
+			if err != nil {
+				return nil, err
+			}
+			tc.AccountMapping = from
 		case "cert_store":
 			certStore, ok := mv.(string)
 			if !ok || certStore == _EMPTY_ {
@@ -5456,6 +6813,11 @@ func parseWebsocket(v any, o *Options, errors *[]error, warnings *[]error) error
 		// Again, unwrap token value if line check is required.
 		tk, mv = unwrapValue(mv, &lt)
 		switch strings.ToLower(mk) {
+		case "debug", "trace":
+			if err := parseListenerLogging(tk, mk, mv, &o.Websocket.Debug, &o.Websocket.Trace); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 		case "listen":
 			hp, err := parseListen(mv)
 			if err != nil {
@@ -5479,6 +6841,10 @@ func parseWebsocket(v any, o *Options, errors *[]error, warnings *[]error) error
 				*errors = append(*errors, err)
 				continue
 			}
+			if err := checkTLSExpectedServerName(tk, tc); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 			if o.Websocket.TLSConfig, err = GenTLSConfig(tc); err != nil {
 				err := &configErr{tk, err.Error()}
 				*errors = append(*errors, err)
@@ -5491,6 +6857,8 @@ func parseWebsocket(v any, o *Options, errors *[]error, warnings *[]error) error
 			o.Websocket.SameOrigin = mv.(bool)
 		case "allowed_origins", "allowed_origin", "allow_origins", "allow_origin", "origins", "origin":
 			o.Websocket.AllowedOrigins, _ = parseStringArray("allowed origins", tk, &lt, mv, errors)
+		case "require_origin_config":
+			o.Websocket.RequireOriginConfig = mv.(bool)
 		case "handshake_timeout":
 			ht := time.Duration(0)
 			switch mv := mv.(type) {
@@ -5546,6 +6914,18 @@ func parseWebsocket(v any, o *Options, errors *[]error, warnings *[]error) error
 			}
 		case "ping_interval":
 			o.Websocket.PingInterval = parseDuration("ping_interval", tk, mv, errors, warnings)
+		case "subprotocols", "subprotocol":
+			protos, err := parseStringArray("subprotocols", tk, &lt, mv, errors)
+			if err != nil {
+				continue
+			}
+			for _, p := range protos {
+				if strings.TrimSpace(p) == _EMPTY_ {
+					*errors = append(*errors, &configErr{tk, "websocket subprotocols cannot contain empty values"})
+					break
+				}
+			}
+			o.Websocket.Subprotocols = protos
 		default:
 			if !tk.IsUsedVariable() {
 				err := &unknownConfigFieldErr{
@@ -5575,6 +6955,11 @@ func parseMQTT(v any, o *Options, errors *[]error, warnings *[]error) error {
 		// Again, unwrap token value if line check is required.
 		tk, mv = unwrapValue(mv, &lt)
 		switch strings.ToLower(mk) {
+		case "debug", "trace":
+			if err := parseListenerLogging(tk, mk, mv, &o.MQTT.Debug, &o.MQTT.Trace); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 		case "listen":
 			hp, err := parseListen(mv)
 			if err != nil {
@@ -5594,6 +6979,10 @@ func parseMQTT(v any, o *Options, errors *[]error, warnings *[]error) error {
 				*errors = append(*errors, err)
 				continue
 			}
+			if err := checkTLSExpectedServerName(tk, tc); err != nil {
+				*errors = append(*errors, err)
+				continue
+			}
 			if o.MQTT.TLSConfig, err = GenTLSConfig(tc); err != nil {
 				err := &configErr{tk, err.Error()}
 				*errors = append(*errors, err)
@@ -5751,6 +7140,32 @@ func parseProxiesTrusted(mv any, errors *[]error) ([]*ProxyConfig, error) {
 	return trusted, nil
 }
 
+// applyTLSMinVersion raises the minimum version of all TLS configurations
+// to TLSMinVersion, if set.
+func (o *Options) applyTLSMinVersion() {
+	if o.TLSMinVersion == 0 {
+		return
+	}
+	raise := func(tc *tls.Config) {
+		if tc != nil && tc.MinVersion < o.TLSMinVersion {
+			tc.MinVersion = o.TLSMinVersion
+		}
+	}
+	raise(o.TLSConfig)
+	raise(o.Cluster.TLSConfig)
+	raise(o.Gateway.TLSConfig)
+	for _, r := range o.Gateway.Gateways {
+		raise(r.TLSConfig)
+	}
+	raise(o.LeafNode.TLSConfig)
+	for _, r := range o.LeafNode.Remotes {
+		raise(r.TLSConfig)
+	}
+	raise(o.Websocket.TLSConfig)
+	raise(o.MQTT.TLSConfig)
+	raise(o.AccountResolverTLSConfig)
+}
+
 // GenTLSConfig loads TLS related configuration parameters.
 func GenTLSConfig(tc *TLSConfigOpts) (*tls.Config, error) {
 	// Create the tls.Config from our options before including the certs.
@@ -5827,6 +7242,25 @@ func GenTLSConfig(tc *TLSConfigOpts) (*tls.Config, error) {
 		}
 		config.MinVersion = tc.MinVersion
 	}
+	if tc.SessionTicketsDisabled {
+		config.SessionTicketsDisabled = true
+	}
+	if tc.SessionTicketKeysHook != nil {
+		tc.SessionTicketKeysHook(config.SetSessionTicketKeys)
+	}
+	// Reject handshakes for server names that are not allowed.
+	if len(tc.AllowedSNI) > 0 {
+		allowed := make(map[string]struct{}, len(tc.AllowedSNI))
+		for _, host := range tc.AllowedSNI {
+			allowed[strings.ToLower(host)] = struct{}{}
+		}
+		config.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			if _, ok := allowed[strings.ToLower(hello.ServerName)]; !ok {
+				return nil, fmt.Errorf("server name %q not allowed", hello.ServerName)
+			}
+			return nil, nil
+		}
+	}
 
 	return &config, nil
 }
@@ -5930,6 +7364,103 @@ func RoutesFromStr(routesStr string) []*url.URL {
 	return routeUrls
 }
 
+// OptionSource is where the value of an option comes from.
+type OptionSource string
+
+const (
+	// OptionSourceDefault is for options neither set in the configuration file nor on the command line.
+	OptionSourceDefault OptionSource = "default"
+	// OptionSourceFile is for options set in the configuration file.
+	OptionSourceFile OptionSource = "file"
+	// OptionSourceFlag is for options set on the command line.
+	OptionSourceFlag OptionSource = "flag"
+)
+
+// Options that MergeOptions takes from the command line, when set there.
+// Keep in sync with MergeOptions.
+var mergedFlagOptions = []string{
+	"Port", "Host", "DontListen", "ClientAdvertise", "Username", "Password", "Authorization",
+	"HTTPPort", "HTTPBasePath", "Debug", "Trace", "Logtime", "LogFile", "PidFile", "PortsFileDir",
+	"ProfPort", "Cluster.ListenStr", "Cluster.NoAdvertise", "Cluster.ConnectRetries",
+	"Cluster.Advertise", "RoutesStr", "Routes", "JetStream", "StoreDir",
+}
+
+// MergeOptionsWithProvenance is like MergeOptions, but also records for each option of the
+// merged result whether it comes from the command line, the configuration file or is a default.
+// The provenance is then available with Options.Provenance. Options are named after the
+// fields of Options, nested ones with their path, e.g. "Cluster.Port".
+//
+// Provenance is best-effort: options not explicitly tracked as set in the configuration
+// file are reported as coming from it when they have a non-zero value there, and options
+// set from the command line outside of MergeOptions, e.g. the cluster listen address
+// being expanded into host and port, are not reported as such.
+func MergeOptionsWithProvenance(fileOpts, flagOpts *Options) *Options {
+	opts := MergeOptions(fileOpts, flagOpts)
+	if opts == nil {
+		return nil
+	}
+	fromFlags := make(map[string]bool)
+	if flagOpts != nil {
+		fv := reflect.ValueOf(flagOpts).Elem()
+		for _, name := range mergedFlagOptions {
+			if f := optionField(fv, name); f.IsValid() && !f.IsZero() {
+				fromFlags[name] = true
+			}
+		}
+		for name, set := range flagOpts.inCmdLine {
+			if set {
+				fromFlags[name] = true
+			}
+		}
+	}
+	prov := make(map[string]OptionSource)
+	var fv reflect.Value
+	if fileOpts != nil {
+		fv = reflect.ValueOf(fileOpts).Elem()
+	}
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := prefix + field.Name
+			if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+				walk(field.Type, name+".")
+				continue
+			}
+			switch {
+			case fromFlags[name]:
+				prov[name] = OptionSourceFlag
+			case fileOpts != nil && (fileOpts.inConfig[name] || !optionField(fv, name).IsZero()):
+				prov[name] = OptionSourceFile
+			default:
+				prov[name] = OptionSourceDefault
+			}
+		}
+	}
+	walk(reflect.TypeOf(Options{}), _EMPTY_)
+	opts.provenance = prov
+	return opts
+}
+
+// Returns the field of the options value with the given, possibly nested, name.
+func optionField(v reflect.Value, name string) reflect.Value {
+	for _, n := range strings.Split(name, ".") {
+		if v = v.FieldByName(n); !v.IsValid() {
+			break
+		}
+	}
+	return v
+}
+
+// Provenance returns where each option comes from, as recorded by MergeOptionsWithProvenance.
+// Returns nil if the options were not produced by MergeOptionsWithProvenance.
+func (o *Options) Provenance() map[string]OptionSource {
+	return o.provenance
+}
+
 // This will merge the flag routes and override anything that was present.
 func mergeRoutes(opts, flagOpts *Options) {
 	routeUrls := RoutesFromStr(flagOpts.RoutesStr)
@@ -5967,6 +7498,7 @@ func setBaselineOptions(opts *Options) {
 	if opts.TLSTimeout == 0 {
 		opts.TLSTimeout = float64(TLS_TIMEOUT) / float64(time.Second)
 	}
+	setAuthTimeoutsFromMap(opts)
 	if opts.AuthTimeout == 0 {
 		opts.AuthTimeout = getDefaultAuthTimeout(opts.TLSConfig, opts.TLSTimeout)
 	}
@@ -6123,6 +7655,9 @@ func setBaselineOptions(opts *Options) {
 	if opts.SyncInterval == 0 && !opts.syncSet {
 		opts.SyncInterval = defaultSyncInterval
 	}
+	if opts.JetStreamEphemeralDeleteJitter == 0 && !opts.ephemeralDeleteJitterSet {
+		opts.JetStreamEphemeralDeleteJitter = JsDeleteJitterDefault
+	}
 	if opts.JetStreamRequestQueueLimit <= 0 {
 		opts.JetStreamRequestQueueLimit = JSDefaultRequestQueueLimit
 	}
@@ -6134,6 +7669,33 @@ func setBaselineOptions(opts *Options) {
 	}
 }
 
+// setAuthTimeoutsFromMap applies the auth_timeouts map to the connection
+// types that do not have their own auth timeout set.
+func setAuthTimeoutsFromMap(opts *Options) {
+	for kind, at := range opts.AuthTimeouts {
+		var dst *float64
+		switch kind {
+		case "client":
+			dst = &opts.AuthTimeout
+		case "cluster":
+			dst = &opts.Cluster.AuthTimeout
+		case "gateway":
+			dst = &opts.Gateway.AuthTimeout
+		case "leafnode":
+			dst = &opts.LeafNode.AuthTimeout
+		case "websocket":
+			dst = &opts.Websocket.AuthTimeout
+		case "mqtt":
+			dst = &opts.MQTT.AuthTimeout
+		default:
+			continue
+		}
+		if *dst == 0 {
+			*dst = at
+		}
+	}
+}
+
 func getDefaultAuthTimeout(tls *tls.Config, tlsTimeout float64) float64 {
 	var authTimeout float64
 	if tls != nil {
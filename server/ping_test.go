@@ -66,3 +66,48 @@ func TestPing(t *testing.T) {
 		start = time.Now()
 	}
 }
+
+func TestPingAccountDisableShortFirstPing(t *testing.T) {
+	conf := createConfFile(t, []byte(`
+		listen: "127.0.0.1:-1"
+		ping_interval: "10s"
+		accounts {
+			A { users: [{user: a, password: pwd}], disable_short_first_ping: false }
+			B { users: [{user: b, password: pwd}] }
+		}
+	`))
+	o := LoadConfig(conf)
+	o.DisableShortFirstPing = true
+	s := RunServer(o)
+	defer s.Shutdown()
+
+	connect := func(user string) (net.Conn, *bufio.Reader) {
+		t.Helper()
+		c, err := net.Dial("tcp", s.ClientURL()[len("nats://"):])
+		require_NoError(t, err)
+		br := bufio.NewReader(c)
+		// Wait for INFO
+		br.ReadLine()
+		fmt.Fprintf(c, "CONNECT {\"verbose\":false,\"user\":%q,\"pass\":\"pwd\"}\r\nPING\r\n", user)
+		l, _, err := br.ReadLine()
+		require_NoError(t, err)
+		require_Equal(t, string(l), "PONG")
+		return c, br
+	}
+	ca, bra := connect("a")
+	defer ca.Close()
+	cb, brb := connect("b")
+	defer cb.Close()
+
+	// Account A re-enables the short first ping.
+	ca.SetReadDeadline(time.Now().Add(4 * time.Second))
+	l, _, err := bra.ReadLine()
+	require_NoError(t, err)
+	require_Equal(t, string(l), "PING")
+
+	// Account B inherits the server setting, so no ping before the interval.
+	cb.SetReadDeadline(time.Now().Add(time.Second))
+	if l, _, err := brb.ReadLine(); err == nil {
+		t.Fatalf("Expected no PING for account B, got %q", l)
+	}
+}
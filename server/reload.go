@@ -14,8 +14,10 @@
 package server
 
 import (
+	"bytes"
 	"cmp"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/url"
@@ -36,7 +38,8 @@ import (
 var FlagSnapshot *Options
 
 type reloadContext struct {
-	oldClusterPerms *RoutePermissions
+	oldClusterPerms    *RoutePermissions
+	oldClusterAccPerms map[string]*RoutePermissions
 }
 
 // option is a hot-swappable configuration setting.
@@ -230,6 +233,30 @@ func (r *remoteSyslogOption) Apply(server *Server) {
 	server.Noticef("Reloaded: remote_syslog = %v", r.newValue)
 }
 
+// listenerLoggingOption implements the option interface for the `debug` and
+// `trace` settings of a listener block such as `cluster` or `leafnodes`.
+type listenerLoggingOption struct {
+	traceLevelOption
+	listener string
+	debug    bool
+	trace    bool
+}
+
+// Apply is a no-op because logging and the trace level of connections
+// will be reloaded after options are applied.
+func (l *listenerLoggingOption) Apply(server *Server) {
+	server.Noticef("Reloaded: %s debug = %v, trace = %v", l.listener, l.debug, l.trace)
+}
+
+// Returns an option for the debug and trace settings of a listener block if
+// they changed, nil otherwise.
+func newListenerLoggingOption(listener string, oldDebug, oldTrace, newDebug, newTrace bool) *listenerLoggingOption {
+	if oldDebug == newDebug && oldTrace == newTrace {
+		return nil
+	}
+	return &listenerLoggingOption{listener: listener, debug: newDebug, trace: newTrace}
+}
+
 // tlsOption implements the option interface for the `tls` setting.
 type tlsOption struct {
 	noopOption
@@ -359,9 +386,13 @@ func (a *authTimeoutOption) Apply(server *Server) {
 // tagsOption implements the option interface for the `tags` setting.
 type tagsOption struct {
 	noopOption // Not authOption because this is a no-op; will be reloaded with options.
+	newValue   jwt.TagList
 }
 
+// Apply the tags change by sending an updated INFO to routes, gateways
+// and leafnodes so that they learn about the new tags.
 func (u *tagsOption) Apply(server *Server) {
+	server.sendAsyncTagsInfo(u.newValue)
 	server.Noticef("Reloaded: tags")
 }
 
@@ -452,10 +483,12 @@ func (c *clusterOption) Apply(s *Server) {
 				// need to change, and at any rate, we want to save the actual
 				// compression level here, not s2_auto.
 				r.updateS2AutoCompressionLevel(co, &r.route.compression)
+				r.route.compressionRTT = r.rtt
 			} else {
 				// Simply change the compression writer
 				r.out.cw = s2.NewWriter(nil, s2WriterOptions(newMode)...)
 				r.route.compression = newMode
+				r.route.compressionRTT = 0
 			}
 			r.mu.Unlock()
 		})
@@ -740,6 +773,7 @@ func (jso jetStreamOption) IsStatszChange() bool {
 	return true
 }
 
+// For changes to the JetStream server limits, a negative value means unchanged.
 type jetStreamLimitsOption struct {
 	noopOption
 	newMaxMemory int64
@@ -752,12 +786,12 @@ func (jso *jetStreamLimitsOption) Apply(s *Server) {
 		return
 	}
 	js.mu.Lock()
-	if jso.newMaxMemory > 0 {
+	if jso.newMaxMemory >= 0 {
 		js.config.MaxMemory = jso.newMaxMemory
 		atomic.StoreInt64(&js.memMax, js.config.MaxMemory)
 		s.Noticef("Reloaded: JetStream max_mem_store = %s", friendlyBytes(jso.newMaxMemory))
 	}
-	if jso.newMaxStore > 0 {
+	if jso.newMaxStore >= 0 {
 		js.config.MaxStore = jso.newMaxStore
 		atomic.StoreInt64(&js.storeMax, js.config.MaxStore)
 		s.Noticef("Reloaded: JetStream max_file_store = %s", friendlyBytes(jso.newMaxStore))
@@ -930,10 +964,13 @@ func getLeafNodeOptionsChanges(s *Server, old, new *LeafNodeOpts) (*leafNodeOpti
 	// allow them to be modified or will check later).
 	if err := checkConfigsEqual(old, new, []string{
 		"Compression",
+		"Debug",
+		"MaxAccountsPerConnection",
 		"Remotes",
 		"TLSHandshakeFirst",
 		"TLSHandshakeFirstFallback",
 		"TLSConfig",
+		"Trace",
 		"Users",
 	}); err != nil {
 		return nil, err
@@ -992,6 +1029,7 @@ forLoop:
 				"TLS",
 				"TLSHandshakeFirst",
 				"TLSConfig",
+				"TLSExpectedServerName",
 			})
 			if err != nil {
 				lrc.RUnlock()
@@ -1139,6 +1177,7 @@ func (l *leafNodeOption) Apply(s *Server) {
 		lrc.Lock()
 		// TLSConfig is always applied.
 		lrc.TLSConfig = rlo.opts.TLSConfig.Clone()
+		lrc.TLSExpectedServerName = rlo.opts.TLSExpectedServerName
 		// Now update what has been detected has changed.
 		if rlo.tlsFirstChanged {
 			lrc.TLSHandshakeFirst = rlo.opts.TLSHandshakeFirst
@@ -1535,7 +1574,10 @@ func (s *Server) reloadOptions(curOpts, newOpts *Options) error {
 
 	// Create a context that is used to pass special info that we may need
 	// while applying the new options.
-	ctx := reloadContext{oldClusterPerms: curOpts.Cluster.Permissions}
+	ctx := reloadContext{
+		oldClusterPerms:    curOpts.Cluster.Permissions,
+		oldClusterAccPerms: curOpts.Cluster.AccountPermissions,
+	}
 	s.setOpts(newOpts)
 	s.applyOptions(&ctx, changed)
 	return nil
@@ -1565,7 +1607,8 @@ func imposeOrder(value any) error {
 		slices.Sort(value.AllowedOrigins)
 	case string, bool, uint8, uint16, uint64, int, int32, int64, time.Duration, float64, nil, LeafNodeOpts, ClusterOpts, *tls.Config, PinnedCertSet,
 		*URLAccResolver, *MemAccResolver, *DirAccResolver, *CacheDirAccResolver, Authentication, MQTTOpts, jwt.TagList,
-		*OCSPConfig, map[string]string, map[string]bool, JSLimitOpts, StoreCipher, *OCSPResponseCacheConfig, *ProxiesConfig, WriteTimeoutPolicy:
+		*OCSPConfig, map[string]string, map[string]bool, JSLimitOpts, StoreCipher, *OCSPResponseCacheConfig, *ProxiesConfig, WriteTimeoutPolicy, *Permissions, map[string]float64,
+		map[string]*QoSProfile, CloseAdvisoryDetail:
 		// explicitly skipped types
 	case *AuthCallout:
 	case JSTpmOpts:
@@ -1577,6 +1620,123 @@ func imposeOrder(value any) error {
 	return nil
 }
 
+// checkReloadedTLSCertificates verifies the certificates of every TLS
+// configuration that changed with the reload. Each chain must be currently
+// valid, properly signed and, when the configuration has a CA pool, verify
+// against it, otherwise the reload is rejected. The expiration of the new
+// leaf certificates is logged once all of them have been checked.
+func (s *Server) checkReloadedTLSCertificates(oldOpts, newOpts *Options) error {
+	type tlsConfigs struct {
+		name     string
+		old, new *tls.Config
+	}
+	var (
+		now     = time.Now()
+		notices []string
+		configs = []tlsConfigs{
+			{"client", oldOpts.TLSConfig, newOpts.TLSConfig},
+			{"cluster", oldOpts.Cluster.TLSConfig, newOpts.Cluster.TLSConfig},
+			{"gateway", oldOpts.Gateway.TLSConfig, newOpts.Gateway.TLSConfig},
+			{"leafnode", oldOpts.LeafNode.TLSConfig, newOpts.LeafNode.TLSConfig},
+			{"websocket", oldOpts.Websocket.TLSConfig, newOpts.Websocket.TLSConfig},
+			{"mqtt", oldOpts.MQTT.TLSConfig, newOpts.MQTT.TLSConfig},
+			{"monitoring", oldOpts.HTTPTLSConfig, newOpts.HTTPTLSConfig},
+		}
+	)
+	for i, r := range newOpts.LeafNode.Remotes {
+		name := fmt.Sprintf("leafnode remote %d", i)
+		if len(r.URLs) > 0 && r.URLs[0] != nil {
+			name = fmt.Sprintf("leafnode remote %q", r.URLs[0].Redacted())
+		}
+		var old *tls.Config
+		if i < len(oldOpts.LeafNode.Remotes) {
+			old = oldOpts.LeafNode.Remotes[i].TLSConfig
+		}
+		configs = append(configs, tlsConfigs{name, old, r.TLSConfig})
+	}
+	for _, c := range configs {
+		if c.new == nil {
+			continue
+		}
+		pool := c.new.RootCAs
+		if pool == nil {
+			pool = c.new.ClientCAs
+		}
+		if c.old != nil && tlsCertificatesEqual(c.old.Certificates, c.new.Certificates) {
+			oldPool := c.old.RootCAs
+			if oldPool == nil {
+				oldPool = c.old.ClientCAs
+			}
+			if pool.Equal(oldPool) {
+				continue
+			}
+		}
+		for _, cert := range c.new.Certificates {
+			leaf, err := checkTLSCertificateChain(cert, pool, now)
+			if err != nil {
+				return fmt.Errorf("config reload rejected, invalid %s tls certificate: %v", c.name, err)
+			}
+			notices = append(notices, fmt.Sprintf("Reloaded: %s tls certificate %q valid until %s",
+				c.name, leaf.Subject.CommonName, leaf.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	for _, n := range notices {
+		s.Noticef("%s", n)
+	}
+	return nil
+}
+
+// tlsCertificatesEqual returns true if both lists hold the same raw certificates.
+func tlsCertificatesEqual(a, b []tls.Certificate) bool {
+	return slices.EqualFunc(a, b, func(x, y tls.Certificate) bool {
+		return slices.EqualFunc(x.Certificate, y.Certificate, bytes.Equal)
+	})
+}
+
+// checkTLSCertificateChain parses the chain of the given certificate, makes sure
+// that every certificate is valid at the given time and signed by the next one
+// in the chain, and returns the leaf certificate. If a CA pool is given, the
+// leaf must also verify against it.
+func checkTLSCertificateChain(cert tls.Certificate, roots *x509.CertPool, now time.Time) (*x509.Certificate, error) {
+	if len(cert.Certificate) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	chain := make([]*x509.Certificate, 0, len(cert.Certificate))
+	for _, der := range cert.Certificate {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate: %v", err)
+		}
+		if now.Before(c.NotBefore) {
+			return nil, fmt.Errorf("certificate %q not valid before %s", c.Subject, c.NotBefore.UTC().Format(time.RFC3339))
+		}
+		if now.After(c.NotAfter) {
+			return nil, fmt.Errorf("certificate %q expired on %s", c.Subject, c.NotAfter.UTC().Format(time.RFC3339))
+		}
+		chain = append(chain, c)
+	}
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return nil, fmt.Errorf("certificate %q not signed by %q: %v", chain[i].Subject, chain[i+1].Subject, err)
+		}
+	}
+	if roots != nil {
+		intermediates := x509.NewCertPool()
+		for _, c := range chain[1:] {
+			intermediates.AddCert(c)
+		}
+		if _, err := chain[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			return nil, fmt.Errorf("certificate %q does not verify against the configured CA: %v", chain[0].Subject, err)
+		}
+	}
+	return chain[0], nil
+}
+
 // diffOptions returns a slice containing options which have been changed. If
 // an option that doesn't support hot-swapping is changed, this returns an
 // error.
@@ -1597,6 +1757,11 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 		jsStoreDirChanged   bool
 		jsLimitsUpdate      *jetStreamLimitsOption
 	)
+	// Make sure that any new TLS certificate material is usable before
+	// anything gets swapped, otherwise keep the current configuration.
+	if err := s.checkReloadedTLSCertificates(oldOpts, newOpts); err != nil {
+		return nil, err
+	}
 	for i := 0; i < oldConfig.NumField(); i++ {
 		field := oldConfig.Type().Field(i)
 		// field.PkgPath is empty for exported fields, and is not for unexported ones.
@@ -1661,6 +1826,8 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 			diffOpts = append(diffOpts, &remoteSyslogOption{newValue: newValue.(string)})
 		case "tlsconfig":
 			diffOpts = append(diffOpts, &tlsOption{newValue: newValue.(*tls.Config)})
+		case "httptlsconfig":
+			// Used by the monitoring endpoint for new connections.
 		case "tlstimeout":
 			diffOpts = append(diffOpts, &tlsTimeoutOption{newValue: newValue.(float64)})
 		case "tlspinnedcerts":
@@ -1674,14 +1841,38 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 		case "password":
 			diffOpts = append(diffOpts, &passwordOption{})
 		case "tags":
-			diffOpts = append(diffOpts, &tagsOption{})
-		case "metadata":
+			diffOpts = append(diffOpts, &tagsOption{newValue: newValue.(jwt.TagList)})
+		case "metadata", "noconfigfilemetadata":
 			diffOpts = append(diffOpts, &metadataOption{})
 		case "authorization":
 			diffOpts = append(diffOpts, &authorizationOption{})
 		case "authtimeout":
 			diffOpts = append(diffOpts, &authTimeoutOption{newValue: newValue.(float64)})
-		case "users":
+		case "authtimeouts":
+			// Applied to the per connection type auth timeouts, which are checked on their own.
+		case "tlsminversion":
+			// Applied to the TLS configurations, which are checked on their own.
+		case "enableconfigz":
+			// Checked on each request to the monitoring endpoint.
+		case "jetstreamauditsubject":
+			// Checked on each JetStream API response.
+		case "jetstreampreloadstreams":
+			// Only used when streams are recovered at startup.
+		case "tcpkeepalive":
+			// Applied to connections accepted after the reload.
+		case "maxconnsoftlimit", "lameduckonmaxconnsoftlimit":
+			// Checked against the current options as clients connect.
+		case "maxuserjwtage":
+			// Checked against the current options as clients authenticate.
+		case "closeadvisorydetail":
+			// Checked against the current options when sending the advisories.
+		case "jetstreamconsumerinfoomitcluster":
+			// Checked against the current options on each consumer info request.
+		case "jetstreamephemeraldeletejitter":
+			// Checked against the current options when consumers update their inactive threshold.
+		case "jetstreamoncorruptrecovery":
+			// Checked against the current options when an account recovers its JetStream state.
+		case "users", "noauthuserpermissions":
 			diffOpts = append(diffOpts, &usersOption{})
 		case "nkeys":
 			diffOpts = append(diffOpts, &nkeysOption{})
@@ -1691,9 +1882,11 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 			if err := validateClusterOpts(oldClusterOpts, newClusterOpts); err != nil {
 				return nil, err
 			}
+			permsChanged := !reflect.DeepEqual(newClusterOpts.Permissions, oldClusterOpts.Permissions) ||
+				!reflect.DeepEqual(newClusterOpts.AccountPermissions, oldClusterOpts.AccountPermissions)
 			co := &clusterOption{
 				newValue:        newClusterOpts,
-				permsChanged:    !reflect.DeepEqual(newClusterOpts.Permissions, oldClusterOpts.Permissions),
+				permsChanged:    permsChanged,
 				compressChanged: !oldClusterOpts.Compression.equals(&newClusterOpts.Compression),
 			}
 			co.diffPoolAndAccounts(&oldClusterOpts)
@@ -1713,6 +1906,9 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 				}
 			}
 			diffOpts = append(diffOpts, co)
+			if lo := newListenerLoggingOption("cluster", oldClusterOpts.Debug, oldClusterOpts.Trace, newClusterOpts.Debug, newClusterOpts.Trace); lo != nil {
+				diffOpts = append(diffOpts, lo)
+			}
 		case "routes":
 			add, remove := diffRoutes(oldValue.([]*url.URL), newValue.([]*url.URL))
 			diffOpts = append(diffOpts, &routesOption{add: add, remove: remove})
@@ -1750,8 +1946,10 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 				return nil, fmt.Errorf("config reload does not support moving to or from an account resolver")
 			}
 			diffOpts = append(diffOpts, &accountsOption{})
-		case "accountresolvertlsconfig":
+		case "accountresolvertlsconfig", "qosprofiles":
 			diffOpts = append(diffOpts, &accountsOption{})
+		case "resolvermaxactiveaccounts", "resolveruselastknowngood":
+			// Checked whenever an account is fetched from the resolver.
 		case "gateway":
 			// Not supported for now, but report warning if configuration of gateway
 			// is actually changed so that user knows that it won't take effect.
@@ -1776,6 +1974,13 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 			tmpOld.Gateways = copyRemoteGWConfigsWithoutTLSConfig(tmpOld.Gateways)
 			tmpNew.Gateways = copyRemoteGWConfigsWithoutTLSConfig(tmpNew.Gateways)
 
+			// Debug and trace logging can be changed.
+			if lo := newListenerLoggingOption("gateway", tmpOld.Debug, tmpOld.Trace, tmpNew.Debug, tmpNew.Trace); lo != nil {
+				diffOpts = append(diffOpts, lo)
+			}
+			tmpOld.Debug, tmpOld.Trace = false, false
+			tmpNew.Debug, tmpNew.Trace = false, false
+
 			// If there is really a change prevents reload.
 			if !reflect.DeepEqual(tmpOld, tmpNew) {
 				// See TODO(ik) note below about printing old/new values.
@@ -1796,6 +2001,9 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 			if lno != nil {
 				diffOpts = append(diffOpts, lno)
 			}
+			if lo := newListenerLoggingOption("leafnodes", tmpOld.Debug, tmpOld.Trace, tmpNew.Debug, tmpNew.Trace); lo != nil {
+				diffOpts = append(diffOpts, lo)
+			}
 		case "jetstream":
 			new := newValue.(bool)
 			old := oldValue.(bool)
@@ -1831,18 +2039,28 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 				fromSet   = !fromUnset
 				toUnset   = new == -1
 				toSet     = !toUnset
-				increased = fromSet && toSet && new > old
+				decreased = fromSet && toSet && new < old
 			)
 			if jsEnabled && modified {
 				// Cannot change limits from dynamic storage at runtime.
 				switch {
-				case increased:
-					// Allowed to increase, but not decrease.
+				case fromSet && toSet:
+					// Allowed to increase, and to decrease as long as current usage still fits.
+					storeType, kind := FileStorage, "store"
+					if optName == "jetstreammaxmemory" {
+						storeType, kind = MemoryStorage, "memory"
+					}
+					if js := s.getJetStream(); decreased && js != nil {
+						if inUse := js.usage(storeType); new < inUse {
+							return nil, fmt.Errorf("config reload not supported for decreasing jetstream max %s to %s, below current usage of %s",
+								kind, friendlyBytes(new), friendlyBytes(inUse))
+						}
+					}
 					if jsLimitsUpdate == nil {
-						jsLimitsUpdate = &jetStreamLimitsOption{}
+						jsLimitsUpdate = &jetStreamLimitsOption{newMaxMemory: -1, newMaxStore: -1}
 						diffOpts = append(diffOpts, jsLimitsUpdate)
 					}
-					if optName == "jetstreammaxmemory" {
+					if storeType == MemoryStorage {
 						jsLimitsUpdate.newMaxMemory = new
 					} else {
 						jsLimitsUpdate.newMaxStore = new
@@ -1858,10 +2076,12 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 				case fromUnset && toSet:
 					// Prevent changing from dynamic max memory / file at runtime.
 					return nil, fmt.Errorf("config reload not supported for jetstream dynamic max memory and store")
-				default:
-					return nil, fmt.Errorf("config reload not supported for decreasing jetstream max memory and store")
 				}
 			}
+		case "jetstreamdisallownetworkstorage":
+			// Only checked when JetStream gets enabled.
+		case "logconsumerleaderchanges", "consumerleaderchangegrace":
+			// Consumers look at s.opts directly when changing leadership.
 		case "jetstreammetacompact", "jetstreammetacompactsize", "jetstreammetacompactsync":
 			// Allowed at runtime but monitorCluster looks at s.opts directly, so no further work needed here.
 		case "jetstreamconcurrentios":
@@ -1879,6 +2099,12 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 			tmpNew := newValue.(WebsocketOpts)
 			tmpOld.TLSConfig, tmpOld.tlsConfigOpts = nil, nil
 			tmpNew.TLSConfig, tmpNew.tlsConfigOpts = nil, nil
+			// Debug and trace logging can be changed.
+			if lo := newListenerLoggingOption("websocket", tmpOld.Debug, tmpOld.Trace, tmpNew.Debug, tmpNew.Trace); lo != nil {
+				diffOpts = append(diffOpts, lo)
+			}
+			tmpOld.Debug, tmpOld.Trace = false, false
+			tmpNew.Debug, tmpNew.Trace = false, false
 			// If there is really a change prevents reload.
 			if !reflect.DeepEqual(tmpOld, tmpNew) {
 				// See TODO(ik) note below about printing old/new values.
@@ -1899,8 +2125,10 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 			tmpNew := newValue.(MQTTOpts)
 			tmpOld.TLSConfig, tmpOld.tlsConfigOpts, tmpOld.AckWait, tmpOld.MaxAckPending, tmpOld.StreamReplicas, tmpOld.ConsumerReplicas, tmpOld.ConsumerMemoryStorage = nil, nil, 0, 0, 0, 0, false
 			tmpOld.ConsumerInactiveThreshold = 0
+			tmpOld.Debug, tmpOld.Trace = false, false
 			tmpNew.TLSConfig, tmpNew.tlsConfigOpts, tmpNew.AckWait, tmpNew.MaxAckPending, tmpNew.StreamReplicas, tmpNew.ConsumerReplicas, tmpNew.ConsumerMemoryStorage = nil, nil, 0, 0, 0, 0, false
 			tmpNew.ConsumerInactiveThreshold = 0
+			tmpNew.Debug, tmpNew.Trace = false, false
 
 			if !reflect.DeepEqual(tmpOld, tmpNew) {
 				// See TODO(ik) note below about printing old/new values.
@@ -1913,6 +2141,10 @@ func (s *Server) diffOptions(newOpts *Options) ([]option, error) {
 			tmpNew.ConsumerReplicas = newValue.(MQTTOpts).ConsumerReplicas
 			tmpNew.ConsumerMemoryStorage = newValue.(MQTTOpts).ConsumerMemoryStorage
 			tmpNew.ConsumerInactiveThreshold = newValue.(MQTTOpts).ConsumerInactiveThreshold
+			oldMQTT, newMQTT := oldValue.(MQTTOpts), newValue.(MQTTOpts)
+			if lo := newListenerLoggingOption("mqtt", oldMQTT.Debug, oldMQTT.Trace, newMQTT.Debug, newMQTT.Trace); lo != nil {
+				diffOpts = append(diffOpts, lo)
+			}
 		case "connecterrorreports":
 			diffOpts = append(diffOpts, &connectErrorReports{newValue: newValue.(int)})
 		case "reconnecterrorreports":
@@ -2061,7 +2293,7 @@ func (s *Server) applyOptions(ctx *reloadContext, opts []option) {
 		s.reloadAuthorization()
 	}
 	if reloadClusterPerms {
-		s.reloadClusterPermissions(ctx.oldClusterPerms)
+		s.reloadClusterPermissions(ctx.oldClusterPerms, ctx.oldClusterAccPerms)
 	}
 	newOpts := s.getOpts()
 	// If we need to reload cluster pool/per-account, then co will be not nil
@@ -2166,6 +2398,7 @@ func (s *Server) reloadClientTraceLevel() {
 	for _, c := range clients {
 		// client.trace is commonly read while holding the lock
 		c.mu.Lock()
+		c.setListenerLogging(opts)
 		c.setTraceLevel()
 		c.mu.Unlock()
 	}
@@ -2401,9 +2634,10 @@ func (s *Server) clientHasMovedToDifferentAccount(c *client) bool {
 // update INFO protocol so that remote can resend their local
 // subs if needed, and sending local subs matching cluster's
 // import subjects.
-func (s *Server) reloadClusterPermissions(oldPerms *RoutePermissions) {
+func (s *Server) reloadClusterPermissions(oldPerms *RoutePermissions, oldAccPerms map[string]*RoutePermissions) {
 	s.mu.Lock()
-	newPerms := s.getOpts().Cluster.Permissions
+	newClusterOpts := &s.getOpts().Cluster
+	newPerms := newClusterOpts.Permissions
 	routes := make(map[uint64]*client, s.numRoutes())
 	// Get all connected routes
 	s.forEachRoute(func(route *client) {
@@ -2445,6 +2679,24 @@ func (s *Server) reloadClusterPermissions(oldPerms *RoutePermissions) {
 	newPermsTester := &client{}
 	newPermsTester.setRoutePermissions(newPerms)
 
+	// Returns the fake clients to test cluster permissions for a given
+	// route. Routes dedicated to an account may have their own permissions.
+	oldClusterOpts := &ClusterOpts{Permissions: oldPerms, AccountPermissions: oldAccPerms}
+	getPermsTesters := func(route *client) (*client, *client) {
+		route.mu.Lock()
+		accName := string(route.route.accName)
+		route.mu.Unlock()
+		_, oldOk := oldAccPerms[accName]
+		_, newOk := newClusterOpts.AccountPermissions[accName]
+		if accName == _EMPTY_ || (!oldOk && !newOk) {
+			return oldPermsTester, newPermsTester
+		}
+		oldTester, newTester := &client{}, &client{}
+		oldTester.setRoutePermissions(oldClusterOpts.routePermissions(accName))
+		newTester.setRoutePermissions(newClusterOpts.routePermissions(accName))
+		return oldTester, newTester
+	}
+
 	var (
 		_localSubs       [4096]*subscription
 		subsNeedSUB      = map[*client][]*subscription{}
@@ -2467,7 +2719,7 @@ func (s *Server) reloadClusterPermissions(oldPerms *RoutePermissions) {
 	// First set the new permissions on all routes.
 	for _, route := range routes {
 		route.mu.Lock()
-		route.setRoutePermissions(newPerms)
+		route.setRoutePermissions(newClusterOpts.routePermissions(string(route.route.accName)))
 		route.mu.Unlock()
 	}
 
@@ -2486,13 +2738,14 @@ func (s *Server) reloadClusterPermissions(oldPerms *RoutePermissions) {
 		}
 		localSubs := _localSubs[:0]
 		sl.localSubs(&localSubs, false)
+		oldTester, newTester := getPermsTesters(route)
 
 		// Go through all local subscriptions
 		for _, sub := range localSubs {
 			// Get all subs that can now be imported
 			subj := string(sub.subject)
-			couldImportThen := oldPermsTester.canImport(subj)
-			canImportNow := newPermsTester.canImport(subj)
+			couldImportThen := oldTester.canImport(subj)
+			canImportNow := newTester.canImport(subj)
 			if canImportNow {
 				// If we could not before, then will need to send a SUB protocol.
 				if !couldImportThen {
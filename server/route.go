@@ -69,6 +69,7 @@ type route struct {
 	wsConnURLs   []string
 	gatewayURL   string
 	leafnodeURL  string
+	tags         []string
 	hash         string
 	idHash       string
 	// Location of the route in the slice: s.routes[remoteID][]*client.
@@ -84,6 +85,9 @@ type route struct {
 	// Selected compression mode, which may be different from the
 	// server configured mode.
 	compression string
+	// The RTT that drove the selection of the compression level
+	// when the configured mode is s2_auto, zero otherwise.
+	compressionRTT time.Duration
 	// Transient value used to set the Info.GossipMode when initiating
 	// an implicit route and sending to the remote.
 	gossipMode byte
@@ -706,6 +710,9 @@ func (c *client) processRouteInfo(info *Info) {
 		var wsConnectURLs []string
 		var updateRoutePerms bool
 
+		// The remote may have had its tags updated through a configuration reload.
+		c.route.tags = info.Tags
+
 		// If we are notified that the remote is going into LDM mode, capture route's connectURLs.
 		if info.LameDuckMode {
 			connectURLs = c.route.connectURLs
@@ -811,6 +818,7 @@ func (c *client) processRouteInfo(info *Info) {
 	c.route.tlsRequired = info.TLSRequired
 	c.route.gatewayURL = info.GatewayURL
 	c.route.remoteName = info.Name
+	c.route.tags = info.Tags
 	c.route.lnoc = info.LNOC
 	c.route.lnocu = info.LNOCU
 	c.route.jetstream = info.JetStream
@@ -868,6 +876,11 @@ func (c *client) processRouteInfo(info *Info) {
 		info.RouteAccount = string(c.route.accName)
 	} else if !didSolicit && info.RouteAccount != _EMPTY_ {
 		c.route.accName = []byte(info.RouteAccount)
+		// Now that we know the account this route is dedicated to, apply
+		// the account specific permissions, if any.
+		if perms, ok := opts.Cluster.AccountPermissions[info.RouteAccount]; ok {
+			c.setRoutePermissions(perms)
+		}
 	}
 	accName := string(c.route.accName)
 
@@ -904,6 +917,7 @@ func (s *Server) negotiateRouteCompression(c *client, didSolicit bool, accName,
 			c.rtt = computeRTT(c.start)
 		}
 		cm = selectS2AutoModeBasedOnRTT(c.rtt, opts.Cluster.Compression.RTTThresholds)
+		c.route.compressionRTT = c.rtt
 	}
 	// Keep track of the negotiated compression mode.
 	c.route.compression = cm
@@ -2000,7 +2014,7 @@ func (s *Server) createRoute(conn net.Conn, rURL *url.URL, rtype RouteType, goss
 	if didSolicit {
 		// Set permissions associated with the route user (if applicable).
 		// No lock needed since we are already under client lock.
-		c.setRoutePermissions(opts.Cluster.Permissions)
+		c.setRoutePermissions(opts.Cluster.routePermissions(accName))
 	}
 
 	// We can't safely send the pings until we have negotiated compression
@@ -2346,7 +2360,7 @@ func (s *Server) addRoute(c *client, didSolicit, sendDelayedInfo bool, gossipMod
 				s.nodeToInfo.Store(rHash, nodeInfo{
 					name:            rn,
 					version:         s.info.Version,
-					cluster:         s.info.Cluster,
+					cluster:         s.jsClusterName(),
 					domain:          info.Domain,
 					id:              id,
 					tags:            nil,
@@ -2740,6 +2754,7 @@ func (s *Server) startRouteAcceptLoop() {
 		Headers:      s.supportsHeaders(),
 		Cluster:      s.info.Cluster,
 		Domain:       s.info.Domain,
+		Tags:         copyStrings(opts.Tags),
 		Dynamic:      s.isClusterNameDynamic(),
 		LNOC:         true,
 		LNOCU:        true,
@@ -2864,6 +2864,83 @@ func TestRoutePerAccountConnectRace(t *testing.T) {
 	}
 }
 
+func TestRoutePerAccountPermissions(t *testing.T) {
+	tmpl := `
+		port: -1
+		accounts {
+			A { users: [{user: "a", password: "a"}] }
+			B { users: [{user: "b", password: "b"}] }
+		}
+		cluster {
+			port: -1
+			name: "local"
+			%s
+			accounts: ["A"]
+			permissions {
+				accounts {
+					A { import { deny: "foo" } }
+				}
+			}
+		}
+	`
+	conf1 := createConfFile(t, []byte(fmt.Sprintf(tmpl, _EMPTY_)))
+	s1, o1 := RunServerWithConfig(conf1)
+	defer s1.Shutdown()
+
+	conf2 := createConfFile(t, []byte(fmt.Sprintf(tmpl, fmt.Sprintf("routes: [\"nats://127.0.0.1:%d\"]", o1.Cluster.Port))))
+	s2, _ := RunServerWithConfig(conf2)
+	defer s2.Shutdown()
+
+	checkClusterFormed(t, s1, s2)
+
+	for _, acc := range []string{"A", "B"} {
+		nc := natsConnect(t, s2.ClientURL(), nats.UserInfo(strings.ToLower(acc), strings.ToLower(acc)))
+		defer nc.Close()
+		natsSubSync(t, nc, "foo")
+		natsSubSync(t, nc, "bar")
+		natsFlush(t, nc)
+	}
+
+	// The account that has specific permissions should not have
+	// interest in "foo" propagated, but "bar" should be.
+	checkSubInterest(t, s1, "A", "bar", time.Second)
+	checkSubNoInterest(t, s1, "A", "foo", 250*time.Millisecond)
+	// The other account is not affected.
+	checkSubInterest(t, s1, "B", "foo", time.Second)
+	checkSubInterest(t, s1, "B", "bar", time.Second)
+
+	// Referencing an account that is not pinned is an error.
+	conf := createConfFile(t, []byte(`
+		port: -1
+		accounts { A {}, B {} }
+		cluster {
+			port: -1
+			accounts: ["A"]
+			permissions {
+				accounts {
+					B { import { deny: "foo" } }
+				}
+			}
+		}
+	`))
+	o, err := ProcessConfigFile(conf)
+	require_NoError(t, err)
+	if _, err := NewServer(o); err == nil || !strings.Contains(err.Error(), "cluster's accounts list") {
+		t.Fatalf("Expected error about account not in pinned accounts list, got %v", err)
+	}
+
+	// Validating the permissions must not modify them.
+	allow := make([]string, 1, 2)
+	allow[0] = "foo"
+	o = DefaultOptions()
+	o.Cluster.PinnedAccounts = []string{"A"}
+	o.Cluster.AccountPermissions = map[string]*RoutePermissions{
+		"A": {Import: &SubjectPermission{Allow: allow, Deny: []string{"bar"}}},
+	}
+	require_NoError(t, validateOptions(o))
+	require_Equal(t, allow[:cap(allow)][1], _EMPTY_)
+}
+
 func TestRoutePerAccountGossipWorks(t *testing.T) {
 	tmplA := `
 		port: -1
@@ -4447,6 +4524,63 @@ func TestRouteCustomPing(t *testing.T) {
 	}
 }
 
+func TestRouteCompressionAutoRoutez(t *testing.T) {
+	tmpl := `
+		port: -1
+		server_name: "%s"
+		ping_interval: "%s"
+		cluster {
+			port: -1
+			name: "local"
+			compression: %s
+			%s
+		}
+	`
+	conf1 := createConfFile(t, []byte(fmt.Sprintf(tmpl, "A", "10s", "s2_fast", _EMPTY_)))
+	s1, o1 := RunServerWithConfig(conf1)
+	defer s1.Shutdown()
+
+	np := createNetProxy(0, 1024*1024*1024, 1024*1024*1024, fmt.Sprintf("nats://127.0.0.1:%d", o1.Cluster.Port), true)
+	routes := fmt.Sprintf("routes: [\"%s\"]", np.routeURL())
+
+	rtts := "{mode: s2_auto, rtt_thresholds: [100ms, 200ms, 300ms]}"
+	conf2 := createConfFile(t, []byte(fmt.Sprintf(tmpl, "B", "500ms", rtts, routes)))
+	s2, _ := RunServerWithConfig(conf2)
+	defer s2.Shutdown()
+	defer np.stop()
+
+	checkClusterFormed(t, s1, s2)
+
+	np.updateRTT(150 * time.Millisecond)
+	checkFor(t, 4*time.Second, 50*time.Millisecond, func() error {
+		rz, err := s2.Routez(nil)
+		if err != nil {
+			return err
+		}
+		for _, ri := range rz.Routes {
+			if ri.Compression != CompressionS2Fast {
+				return fmt.Errorf("Route %v compression mode expected to be %q, got %q", ri.Rid, CompressionS2Fast, ri.Compression)
+			}
+			rtt, err := time.ParseDuration(ri.CompressionRTT)
+			if err != nil {
+				return err
+			}
+			if rtt <= 100*time.Millisecond || rtt > 200*time.Millisecond {
+				return fmt.Errorf("Route %v unexpected compression RTT %v", ri.Rid, rtt)
+			}
+		}
+		return nil
+	})
+
+	// Not reported when the compression mode is not s2_auto.
+	rz, err := s1.Routez(nil)
+	require_NoError(t, err)
+	for _, ri := range rz.Routes {
+		require_Equal(t, ri.Compression, CompressionS2Fast)
+		require_Equal(t, ri.CompressionRTT, _EMPTY_)
+	}
+}
+
 func TestRouteCompressionStaleConnectionUsesClusterPingMax(t *testing.T) {
 	mockListener, err := net.Listen("tcp", "127.0.0.1:0")
 	require_NoError(t, err)
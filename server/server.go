@@ -15,6 +15,7 @@ package server
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"crypto/fips140"
 	"crypto/tls"
@@ -34,6 +35,7 @@ import (
 	"regexp"
 	"runtime"
 	"runtime/pprof"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -128,6 +130,7 @@ type Info struct {
 	Cluster           string   `json:"cluster,omitempty"`
 	Dynamic           bool     `json:"cluster_dynamic,omitempty"`
 	Domain            string   `json:"domain,omitempty"`
+	Tags              []string `json:"tags,omitempty"`            // Server tags, sent to routes, gateways and leafnodes.
 	ClientConnectURLs []string `json:"connect_urls,omitempty"`    // Contains URLs a client can connect to.
 	WSConnectURLs     []string `json:"ws_connect_urls,omitempty"` // Contains URLs a ws client can connect to.
 	LameDuckMode      bool     `json:"ldm,omitempty"`
@@ -197,6 +200,7 @@ type Server struct {
 	tmpAccounts         sync.Map // Temporarily stores accounts that are being built
 	activeAccounts      int32
 	accResolver         AccountResolver
+	accLastKnownGood    sync.Map // Key is account name, value is the last fetched JWT, see ResolverUseLastKnownGood
 	clients             map[uint64]*client
 	routes              map[string][]*client
 	remoteRoutePoolSize map[string]int                // Map for remote's configure route pool size
@@ -281,6 +285,9 @@ type Server struct {
 	ldm   bool
 	ldmCh chan bool
 
+	// Set once client connections reached the soft limit, until back below it.
+	maxConnSoftHit bool
+
 	// Trusted public operator keys.
 	trustedKeys []string
 	// map of trusted keys to operator setting StrictSigningKeyUsage
@@ -825,10 +832,14 @@ func NewServer(opts *Options) (*Server, error) {
 	// Place ourselves in the JetStream nodeInfo if needed.
 	if opts.JetStream {
 		ourNode := getHash(serverName)
+		jsClusterName := opts.Cluster.Name
+		if opts.JetStreamClusterName != _EMPTY_ {
+			jsClusterName = opts.JetStreamClusterName
+		}
 		s.nodeToInfo.Store(ourNode, nodeInfo{
 			name:            serverName,
 			version:         VERSION,
-			cluster:         opts.Cluster.Name,
+			cluster:         jsClusterName,
 			domain:          opts.JetStreamDomain,
 			id:              info.ID,
 			tags:            opts.Tags,
@@ -1012,6 +1023,15 @@ func (s *Server) cachedClusterName() string {
 	return cn
 }
 
+// Returns the cluster name used by JetStream for its meta and raft groups,
+// which can be configured independently of the routing cluster name.
+func (s *Server) jsClusterName() string {
+	if cn := s.getOpts().JetStreamClusterName; cn != _EMPTY_ {
+		return cn
+	}
+	return s.cachedClusterName()
+}
+
 // setClusterName will update the cluster name for this server.
 func (s *Server) setClusterName(name string) {
 	s.mu.Lock()
@@ -1127,6 +1147,26 @@ func validateCluster(o *Options) error {
 			m[a] = struct{}{}
 		}
 	}
+	// Per-account route permissions only apply to routes dedicated to
+	// an account, so the accounts need to be in the pinned accounts list.
+	for accName, perms := range o.Cluster.AccountPermissions {
+		if !slices.Contains(o.Cluster.PinnedAccounts, accName) {
+			return fmt.Errorf("cluster permissions for account %q require the account to be in the cluster's accounts list", accName)
+		}
+		if perms == nil {
+			continue
+		}
+		for _, sp := range []*SubjectPermission{perms.Import, perms.Export} {
+			if sp == nil {
+				continue
+			}
+			for _, subj := range append(append([]string(nil), sp.Allow...), sp.Deny...) {
+				if !IsValidSubject(subj) {
+					return fmt.Errorf("cluster permissions for account %q have an invalid subject %q", accName, subj)
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -1142,14 +1182,28 @@ func validatePinnedCerts(pinned PinnedCertSet) error {
 }
 
 func validateOptions(o *Options) error {
+	if o.AccountExpirationGrace < 0 {
+		return fmt.Errorf("account expiration grace (%v) cannot be negative", o.AccountExpirationGrace)
+	}
 	if o.LameDuckDuration > 0 && o.LameDuckGracePeriod >= o.LameDuckDuration {
 		return fmt.Errorf("lame duck grace period (%v) should be strictly lower than lame duck duration (%v)",
 			o.LameDuckGracePeriod, o.LameDuckDuration)
 	}
+	if o.MaxConnSoftLimit > 0 && o.MaxConn != 0 && (o.MaxConn < 0 || o.MaxConnSoftLimit >= o.MaxConn) {
+		return fmt.Errorf("max_connections_soft (%v) should be strictly lower than max_connections (%v)",
+			o.MaxConnSoftLimit, o.MaxConn)
+	}
 	if int64(o.MaxPayload) > o.MaxPending {
 		return fmt.Errorf("max_payload (%v) cannot be higher than max_pending (%v)",
 			o.MaxPayload, o.MaxPending)
 	}
+	if o.SlowConsumerLogThreshold < 0 {
+		return fmt.Errorf("slow_consumer_log_threshold (%v) cannot be negative", o.SlowConsumerLogThreshold)
+	}
+	if o.SlowConsumerLogThreshold > 0 && o.SlowConsumerLogThreshold >= o.MaxPending {
+		return fmt.Errorf("slow_consumer_log_threshold (%v) should be strictly lower than max_pending (%v)",
+			o.SlowConsumerLogThreshold, o.MaxPending)
+	}
 	if o.ServerName != _EMPTY_ && strings.Contains(o.ServerName, " ") {
 		return errors.New("server name cannot contain spaces")
 	}
@@ -1503,6 +1557,21 @@ func (s *Server) configureResolver() error {
 	return nil
 }
 
+// checkRequiredAccounts makes sure all accounts listed in accounts_required
+// can be resolved, and reports all missing ones at once.
+func (s *Server) checkRequiredAccounts() error {
+	var missing []string
+	for _, name := range s.getOpts().AccountsRequired {
+		if _, err := s.LookupAccount(name); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("accounts could not be resolved: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // This will check preloads for validation issues.
 func (s *Server) checkResolvePreloads() {
 	opts := s.getOpts()
@@ -2060,6 +2129,7 @@ func (s *Server) lookupOrFetchAccount(name string, fetch bool) (*Account, error)
 		acc = v.(*Account)
 	}
 	if acc != nil {
+		acc.lastUsed.Store(time.Now().UnixNano())
 		// If we are expired and we have a resolver, then
 		// return the latest information from the resolver.
 		if acc.IsExpired() {
@@ -2157,10 +2227,20 @@ func (s *Server) fetchRawAccountClaims(name string) (string, error) {
 	} else {
 		s.Debugf("Account [%s] fetch took %v", name, fetchTime)
 	}
+	useLastKnownGood := s.getOpts().ResolverUseLastKnownGood
 	if err != nil {
+		if useLastKnownGood {
+			if lkg, ok := s.accLastKnownGood.Load(name); ok {
+				s.Warnf("Account fetch failed, using last known good JWT for [%s]: %v", name, err)
+				return lkg.(string), nil
+			}
+		}
 		s.Warnf("Account fetch failed: %v", err)
 		return "", err
 	}
+	if useLastKnownGood {
+		s.accLastKnownGood.Store(name, claimJWT)
+	}
 	return claimJWT, nil
 }
 
@@ -2234,9 +2314,76 @@ func (s *Server) fetchAccount(name string) (*Account, error) {
 		acc.addAllServiceImportSubs()
 	}
 
+	acc.lastUsed.Store(time.Now().UnixNano())
+	if max := s.getOpts().ResolverMaxActiveAccounts; max > 0 {
+		s.evictIdleAccounts(max, acc)
+	}
+
 	return acc, nil
 }
 
+// evictIdleAccounts removes the least recently used idle accounts fetched from
+// the resolver until no more than max are active. Accounts with connections,
+// interest from routes, gateways or leafnodes, JetStream, exports or that other
+// accounts import from are never evicted, nor is the one just fetched.
+// They will be fetched again from the resolver when needed.
+// Lock is NOT held upon entry.
+func (s *Server) evictIdleAccounts(max int, keep *Account) {
+	sysAcc := s.SystemAccount()
+	var active int
+	var idle []*Account
+	imported := make(map[*Account]struct{})
+	s.accounts.Range(func(_, v any) bool {
+		acc := v.(*Account)
+		acc.mu.RLock()
+		for _, si := range acc.imports.streams {
+			if si != nil && si.acc != acc {
+				imported[si.acc] = struct{}{}
+			}
+		}
+		for _, sis := range acc.imports.services {
+			for _, si := range sis {
+				if si != nil && si.acc != acc {
+					imported[si.acc] = struct{}{}
+				}
+			}
+		}
+		if acc != sysAcc && acc != s.gacc && acc.claimJWT != _EMPTY_ {
+			active++
+			if acc != keep && len(acc.clients) == 0 && !acc.hasExternalInterest() && acc.js == nil &&
+				len(acc.exports.services) == 0 && len(acc.exports.streams) == 0 {
+				idle = append(idle, acc)
+			}
+		}
+		acc.mu.RUnlock()
+		return true
+	})
+	idle = slices.DeleteFunc(idle, func(acc *Account) bool {
+		_, ok := imported[acc]
+		return ok
+	})
+	if active <= max || len(idle) == 0 {
+		return
+	}
+	slices.SortFunc(idle, func(a, b *Account) int {
+		return cmp.Compare(a.lastUsed.Load(), b.lastUsed.Load())
+	})
+	for _, acc := range idle[:min(active-max, len(idle))] {
+		acc.mu.Lock()
+		// Could have gotten a connection or interest in the meantime.
+		if len(acc.clients) > 0 || acc.hasExternalInterest() {
+			acc.mu.Unlock()
+			continue
+		}
+		s.accounts.CompareAndDelete(acc.Name, acc)
+		clearTimer(&acc.etmr)
+		clearTimer(&acc.ctmr)
+		acc.mu.Unlock()
+		acc.removeAllServiceImportSubs()
+		s.Debugf("Evicted idle account %q, max active accounts of %d reached", acc.Name, max)
+	}
+}
+
 // Start up the server, this will not block.
 //
 // WaitForShutdown can be used to block and wait for the server to shutdown properly if needed
@@ -2410,6 +2557,12 @@ func (s *Server) Start() {
 		}
 	}
 
+	// Make sure all accounts we were told to expect can be resolved.
+	if err := s.checkRequiredAccounts(); err != nil {
+		s.Fatalf("Required accounts check failed: %v", err)
+		return
+	}
+
 	// Start expiration of mapped GW replies, regardless if
 	// this server is configured with gateway or not.
 	s.startGWReplyMapExpiration()
@@ -2423,15 +2576,16 @@ func (s *Server) Start() {
 			s.Fatalf("Not allowed to enable JetStream on the system account")
 		}
 		cfg := &JetStreamConfig{
-			StoreDir:     opts.StoreDir,
-			SyncInterval: opts.SyncInterval,
-			SyncAlways:   opts.SyncAlways,
-			Strict:       !opts.NoJetStreamStrict,
-			MaxMemory:    opts.JetStreamMaxMemory,
-			MaxStore:     opts.JetStreamMaxStore,
-			Domain:       opts.JetStreamDomain,
-			CompressOK:   true,
-			UniqueTag:    opts.JetStreamUniqueTag,
+			StoreDir:      opts.StoreDir,
+			SyncInterval:  opts.SyncInterval,
+			SyncAlways:    opts.SyncAlways,
+			Strict:        !opts.NoJetStreamStrict,
+			MaxMemory:     opts.JetStreamMaxMemory,
+			MaxStore:      opts.JetStreamMaxStore,
+			Domain:        opts.JetStreamDomain,
+			DomainAliases: opts.JetStreamDomainAliases,
+			CompressOK:    true,
+			UniqueTag:     opts.JetStreamUniqueTag,
 		}
 		if err := s.EnableJetStream(cfg); err != nil {
 			s.Fatalf("Can't start JetStream: %v", err)
@@ -2881,6 +3035,7 @@ func (s *Server) acceptConnections(l net.Listener, acceptName string, createFunc
 			continue
 		}
 		tmpDelay = ACCEPT_MIN_SLEEP
+		s.setTCPKeepAlive(conn)
 		if !s.startGoRoutine(func() {
 			s.reloadMu.RLock()
 			createFunc(conn)
@@ -2894,6 +3049,29 @@ func (s *Server) acceptConnections(l net.Listener, acceptName string, createFunc
 	s.done <- true
 }
 
+// setTCPKeepAlive enables TCP keepalives on an accepted connection
+// if the TCPKeepAlive option is set.
+func (s *Server) setTCPKeepAlive(conn net.Conn) {
+	period := s.getOpts().TCPKeepAlive
+	if period <= 0 {
+		return
+	}
+	if tc, ok := conn.(*tls.Conn); ok {
+		conn = tc.NetConn()
+	}
+	tc, ok := conn.(*net.TCPConn)
+	if !ok {
+		return
+	}
+	err := tc.SetKeepAlive(true)
+	if err == nil {
+		err = tc.SetKeepAlivePeriod(period)
+	}
+	if err != nil {
+		s.Debugf("Error setting TCP keepalive on %s: %v", conn.RemoteAddr(), err)
+	}
+}
+
 // This function sets the server's info Host/Port based on server Options.
 // Note that this function may be called during config reload, this is why
 // Host/Port may be reset to original Options if the ClientAdvertise option
@@ -3003,7 +3181,7 @@ func (s *Server) StartMonitoring() error {
 	if opts.HTTPPort != 0 {
 		err = s.startMonitoring(false)
 	} else if opts.HTTPSPort != 0 {
-		if opts.TLSConfig == nil {
+		if opts.HTTPTLSConfig == nil && opts.TLSConfig == nil {
 			return fmt.Errorf("TLS cert and key required for HTTPS")
 		}
 		err = s.startMonitoring(true)
@@ -3027,6 +3205,7 @@ const (
 	HealthzPath      = "/healthz"
 	IPQueuesPath     = "/ipqueuesz"
 	RaftzPath        = "/raftz"
+	ConfigzPath      = "/configz"
 	ExpvarzPath      = "/debug/vars"
 )
 
@@ -3060,10 +3239,19 @@ func (cl *captureHTTPServerLog) Write(p []byte) (int, error) {
 // used for a specific client. We don't care which client, we always use
 // the same TLS configuration.
 func (s *Server) getMonitoringTLSConfig(_ *tls.ClientHelloInfo) (*tls.Config, error) {
-	opts := s.getOpts()
+	return monitoringTLSConfig(s.getOpts()), nil
+}
+
+// Returns a copy of the TLS configuration of the monitoring endpoint,
+// which is the client one unless configured in the http block. Client
+// certificates are only verified when the http block asks for it.
+func monitoringTLSConfig(opts *Options) *tls.Config {
+	if opts.HTTPTLSConfig != nil {
+		return opts.HTTPTLSConfig.Clone()
+	}
 	tc := opts.TLSConfig.Clone()
 	tc.ClientAuth = tls.NoClientCert
-	return tc, nil
+	return tc
 }
 
 // Start the monitoring server
@@ -3091,10 +3279,14 @@ func (s *Server) startMonitoring(secure bool) error {
 			port = 0
 		}
 		hp = net.JoinHostPort(opts.HTTPHost, strconv.Itoa(port))
-		config := opts.TLSConfig.Clone()
+		var config *tls.Config
 		if !s.ocspPeerVerify {
+			config = monitoringTLSConfig(opts)
 			config.GetConfigForClient = s.getMonitoringTLSConfig
-			config.ClientAuth = tls.NoClientCert
+		} else if opts.HTTPTLSConfig != nil {
+			config = opts.HTTPTLSConfig.Clone()
+		} else {
+			config = opts.TLSConfig.Clone()
 		}
 		httpListener, err = tls.Listen("tcp", hp, config)
 
@@ -3146,6 +3338,8 @@ func (s *Server) startMonitoring(secure bool) error {
 	mux.HandleFunc(s.basePath(IPQueuesPath), s.HandleIPQueuesz)
 	// Raftz
 	mux.HandleFunc(s.basePath(RaftzPath), s.HandleRaftz)
+	// Configz
+	mux.HandleFunc(s.basePath(ConfigzPath), s.HandleConfigz)
 	// Expvarz
 	mux.Handle(s.basePath(ExpvarzPath), expvar.Handler())
 
@@ -3235,11 +3429,7 @@ func (s *Server) createClientEx(conn net.Conn, inProcess bool) *client {
 	opts := s.getOpts()
 
 	maxPay := int32(opts.MaxPayload)
-	maxSubs := int32(opts.MaxSubs)
-	// For system, maxSubs of 0 means unlimited, so re-adjust here.
-	if maxSubs == 0 {
-		maxSubs = -1
-	}
+	maxSubs := maxSubsLimit(opts.MaxSubs)
 	now := time.Now()
 
 	c := &client{
@@ -3363,6 +3553,7 @@ func (s *Server) createClientEx(conn net.Conn, inProcess bool) *client {
 		return nil
 	}
 	s.clients[c.cid] = c
+	s.checkMaxConnSoftLimitLocked(opts)
 
 	s.mu.Unlock()
 
@@ -3554,7 +3745,7 @@ func (s *Server) createClientEx(conn net.Conn, inProcess bool) *client {
 func (s *Server) saveClosedClient(c *client, nc net.Conn, subs map[string]*subscription, reason ClosedState) {
 	now := time.Now()
 
-	s.accountDisconnectEvent(c, now, reason.String())
+	s.accountDisconnectEvent(c, now, reason.String(), subs)
 
 	c.mu.Lock()
 
@@ -3717,6 +3908,7 @@ func (s *Server) removeClient(c *client) {
 
 		s.mu.Lock()
 		delete(s.clients, cid)
+		s.checkMaxConnSoftLimitLocked(s.getOpts())
 		if updateProtoInfoCount {
 			s.cproto--
 		}
@@ -4419,6 +4611,32 @@ func (s *Server) LameDuckShutdown() {
 	s.lameDuckMode()
 }
 
+// Checks the number of client connections against the max connections soft limit.
+// When reached, a warning is logged, an advisory sent and, if configured, the
+// server enters lame duck mode. This is done once until going back below the limit.
+// Server lock should be held.
+func (s *Server) checkMaxConnSoftLimitLocked(opts *Options) {
+	limit := opts.MaxConnSoftLimit
+	if limit <= 0 {
+		return
+	}
+	n := len(s.clients)
+	if n < limit {
+		s.maxConnSoftHit = false
+		return
+	}
+	if s.maxConnSoftHit {
+		return
+	}
+	s.maxConnSoftHit = true
+	ldm := opts.LameDuckOnMaxConnSoftLimit && !s.ldm
+	s.Warnf("Client connections (%d) reached the max connections soft limit (%d), max connections is %d", n, limit, opts.MaxConn)
+	s.sendMaxConnSoftLimitEventLocked(n, limit, opts.MaxConn, ldm)
+	if ldm {
+		go s.lameDuckMode()
+	}
+}
+
 // This function will close the client listener then close the clients
 // at some interval to avoid a reconnect storm.
 // We will also transfer any raft leaders and shutdown JetStream.
@@ -4565,6 +4783,38 @@ func (s *Server) sendLDMToRoutes() {
 	s.routeInfo.LameDuckMode = false
 }
 
+// Send an INFO update with the new server tags to routes, gateways and
+// leafnodes so that they do not have to reconnect to learn about them.
+func (s *Server) sendAsyncTagsInfo(tags jwt.TagList) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.routeListener != nil {
+		s.routeInfo.Tags = copyStrings(tags)
+		infoJSON := generateInfoJSON(&s.routeInfo)
+		// Send to all routes, including pooled ones, since each keeps track of the remote tags.
+		s.forEachRoute(func(r *client) {
+			r.mu.Lock()
+			r.enqueueProto(infoJSON)
+			r.mu.Unlock()
+		})
+	}
+	if s.gateway.enabled {
+		s.gateway.Lock()
+		if s.gateway.info != nil {
+			s.gateway.info.Tags = copyStrings(tags)
+			s.gateway.generateInfoJSON()
+		}
+		s.gateway.Unlock()
+		s.sendAsyncGatewayInfo()
+	}
+	if s.leafNodeListener != nil {
+		s.leafNodeInfo.Tags = copyStrings(tags)
+		s.generateLeafNodeInfoJSON()
+		s.sendAsyncLeafNodeInfo()
+	}
+}
+
 // Send an INFO update to clients with the indication that this server is in
 // LDM mode and with only URLs of other nodes.
 // Server lock is held on entry.
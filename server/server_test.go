@@ -356,6 +356,62 @@ func TestTLSMinVersionConfig(t *testing.T) {
 	}
 }
 
+func TestTLSGlobalMinVersionConfig(t *testing.T) {
+	tmpl := `
+		listen: "127.0.0.1:-1"
+		tls_min_version: %s
+		tls {
+			cert_file: 	"../test/configs/certs/server-cert.pem"
+			key_file:  	"../test/configs/certs/server-key.pem"
+		}
+		leafnodes {
+			listen: "127.0.0.1:-1"
+			tls {
+				cert_file: 	"../test/configs/certs/server-cert.pem"
+				key_file:  	"../test/configs/certs/server-key.pem"
+				min_version: 	"1.3"
+			}
+		}
+		websocket {
+			listen: "127.0.0.1:-1"
+			tls {
+				cert_file: 	"../test/configs/certs/server-cert.pem"
+				key_file:  	"../test/configs/certs/server-key.pem"
+				min_version: 	"1.2"
+			}
+		}
+	`
+	// The floor applies to blocks without min_version, and blocks can raise it.
+	o, err := ProcessConfigFile(createConfFile(t, []byte(fmt.Sprintf(tmpl, `"1.2"`))))
+	require_NoError(t, err)
+	require_Equal(t, o.TLSMinVersion, tls.VersionTLS12)
+	require_Equal(t, o.TLSConfig.MinVersion, tls.VersionTLS12)
+	require_Equal(t, o.LeafNode.TLSConfig.MinVersion, tls.VersionTLS13)
+	require_Equal(t, o.Websocket.TLSConfig.MinVersion, tls.VersionTLS12)
+
+	// But not lower it.
+	o, err = ProcessConfigFile(createConfFile(t, []byte(fmt.Sprintf(tmpl, `"1.3"`))))
+	require_NoError(t, err)
+	require_Equal(t, o.TLSConfig.MinVersion, tls.VersionTLS13)
+	require_Equal(t, o.LeafNode.TLSConfig.MinVersion, tls.VersionTLS13)
+	require_Equal(t, o.Websocket.TLSConfig.MinVersion, tls.VersionTLS13)
+
+	s := RunServer(o)
+	defer s.Shutdown()
+	nc, err := nats.Connect(fmt.Sprintf("tls://localhost:%d", o.Port),
+		nats.RootCAs("../test/configs/certs/ca.pem"), nats.Secure(&tls.Config{MaxVersion: tls.VersionTLS12}))
+	if err == nil {
+		nc.Close()
+	}
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "protocol version not supported")
+
+	// Must be at least TLS 1.2.
+	_, err = ProcessConfigFile(createConfFile(t, []byte(fmt.Sprintf(tmpl, `"1.1"`))))
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "error parsing tls_min_version: unsupported TLS version: TLS 1.1")
+}
+
 func TestTLSCipher(t *testing.T) {
 	require_Equal(t, tls.CipherSuiteName(0x0005), "TLS_RSA_WITH_RC4_128_SHA")
 	require_Equal(t, tls.CipherSuiteName(0x000a), "TLS_RSA_WITH_3DES_EDE_CBC_SHA")
@@ -2526,3 +2582,144 @@ func TestBuildinfoFormatRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestServerAccountsRequired(t *testing.T) {
+	conf := createConfFile(t, []byte(`
+		listen: 127.0.0.1:-1
+		accounts: {
+			A: { users: [ {user: a, password: pwd} ] }
+			B: { users: [ {user: b, password: pwd} ] }
+		}
+		accounts_required: ["A", "MISSING1", "B", "MISSING2"]
+	`))
+	opts, err := ProcessConfigFile(conf)
+	require_NoError(t, err)
+	opts.NoLog, opts.NoSigs = true, true
+	s, err := NewServer(opts)
+	require_NoError(t, err)
+	defer s.Shutdown()
+	l := &captureFatalLogger{fatalCh: make(chan string, 1)}
+	s.SetLogger(l, false, false)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.Start()
+	}()
+	select {
+	case e := <-l.fatalCh:
+		require_Contains(t, e, "accounts could not be resolved: MISSING1, MISSING2")
+	case <-time.After(2 * time.Second):
+		t.Fatal("Should have reported a fatal error")
+	}
+	wg.Wait()
+	s.Shutdown()
+
+	// All required accounts present is fine.
+	conf = createConfFile(t, []byte(`
+		listen: 127.0.0.1:-1
+		accounts: {
+			A: { users: [ {user: a, password: pwd} ] }
+		}
+		accounts_required: ["A", "$G"]
+	`))
+	s, _ = RunServerWithConfig(conf)
+	defer s.Shutdown()
+	require_True(t, s.Running())
+}
+
+func TestServerTCPKeepAlive(t *testing.T) {
+	conf := createConfFile(t, []byte(`
+		listen: 127.0.0.1:-1
+		tcp_keepalive: "30s"
+	`))
+	s, opts := RunServerWithConfig(conf)
+	defer s.Shutdown()
+	require_Equal(t, opts.TCPKeepAlive, 30*time.Second)
+
+	nc := natsConnect(t, s.ClientURL())
+	defer nc.Close()
+	require_NoError(t, nc.Flush())
+
+	conf = createConfFile(t, []byte(`tcp_keepalive: "-1s"`))
+	_, err := ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "tcp_keepalive can not be negative")
+}
+
+func TestServerMaxConnSoftLimit(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxConn = 2
+	opts.MaxConnSoftLimit = 2
+	_, err := NewServer(opts)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "max_connections_soft (2) should be strictly lower than max_connections (2)")
+
+	for _, ldm := range []bool{false, true} {
+		t.Run(fmt.Sprintf("lame duck %v", ldm), func(t *testing.T) {
+			conf := createConfFile(t, []byte(fmt.Sprintf(`
+				listen: 127.0.0.1:-1
+				max_connections: 10
+				max_connections_soft: 3
+				max_connections_soft_lame_duck: %v
+				accounts { SYS { users [{user: sys, password: pwd}] } }
+				system_account: SYS
+				no_auth_user: sys
+			`, ldm)))
+			s, _ := RunServerWithConfig(conf)
+			defer s.Shutdown()
+
+			nc := natsConnect(t, s.ClientURL())
+			defer nc.Close()
+			sub := natsSubSync(t, nc, fmt.Sprintf(maxConnSoftLimitEventSubj, s.ID()))
+			require_NoError(t, nc.Flush())
+
+			checkEvent := func() {
+				t.Helper()
+				msg := natsNexMsg(t, sub, 2*time.Second)
+				var e MaxConnSoftLimitEventMsg
+				require_NoError(t, json.Unmarshal(msg.Data, &e))
+				require_Equal(t, e.Type, MaxConnSoftLimitEventMsgType)
+				require_Equal(t, e.Connections, 3)
+				require_Equal(t, e.SoftLimit, 3)
+				require_Equal(t, e.MaxConnections, 10)
+				require_Equal(t, e.LameDuck, ldm)
+			}
+
+			nc2 := natsConnect(t, s.ClientURL())
+			defer nc2.Close()
+			nc3 := natsConnect(t, s.ClientURL())
+			defer nc3.Close()
+			checkEvent()
+
+			if ldm {
+				checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+					if !s.isLameDuckMode() {
+						return errors.New("server not in lame duck mode")
+					}
+					return nil
+				})
+				return
+			}
+			require_False(t, s.isLameDuckMode())
+
+			// Once back below the soft limit, reaching it again is notified again.
+			nc3.Close()
+			checkFor(t, 2*time.Second, 50*time.Millisecond, func() error {
+				if n := s.NumClients(); n != 2 {
+					return fmt.Errorf("expected 2 clients, got %d", n)
+				}
+				return nil
+			})
+			nc3 = natsConnect(t, s.ClientURL())
+			defer nc3.Close()
+			checkEvent()
+		})
+	}
+
+	conf := createConfFile(t, []byte(`max_connections_soft: -1`))
+	_, err = ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "max_connections_soft can not be negative")
+}
@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -168,6 +169,11 @@ func (cfg *StreamConfig) clone() *StreamConfig {
 type StreamConsumerLimits struct {
 	InactiveThreshold time.Duration `json:"inactive_threshold,omitempty"`
 	MaxAckPending     int           `json:"max_ack_pending,omitempty"`
+	// DefaultMaxDeliver is used for consumers that do not set MaxDeliver themselves.
+	DefaultMaxDeliver int `json:"default_max_deliver,omitempty"`
+	// NamePattern is a regular expression that names of consumers created on the stream
+	// need to match. Internal sourcing and mirroring consumers are exempt.
+	NamePattern string `json:"name_pattern,omitempty"`
 }
 
 // SubjectTransformConfig is for applying a subject transform (to matching messages) before doing anything else when a new message is received
@@ -351,6 +357,11 @@ type StreamInfo struct {
 	Alternates []StreamAlternate   `json:"alternates,omitempty"`
 	// TimeStamp indicates when the info was gathered
 	TimeStamp time.Time `json:"ts"`
+	// DedupEntries is the number of message ids tracked for duplicate detection.
+	DedupEntries int `json:"dedup_entries,omitempty"`
+	// MaxBufferedMsgs and MaxBufferedSize are the effective limits of the inbound message buffer.
+	MaxBufferedMsgs int   `json:"max_buffered_msgs,omitempty"`
+	MaxBufferedSize int64 `json:"max_buffered_size,omitempty"`
 }
 
 // streamInfoClusterResponse is a response used in a cluster to communicate the stream info
@@ -505,6 +516,7 @@ type stream struct {
 	ddarr     []*ddentry              // The dedupe array.
 	ddindex   int                     // The dedupe index.
 	ddtmr     *time.Timer             // The dedupe timer.
+	ddmax     int                     // The maximum number of dedupe entries, 0 means no limit.
 	qch       chan struct{}           // The quit channel.
 	mqch      chan struct{}           // The monitor's quit channel.
 	active    bool                    // Indicates that there are active internal subscriptions (for the subject filters)
@@ -682,6 +694,13 @@ const (
 	JSUpToSequence = "Nats-UpTo-Sequence"
 )
 
+// Headers for messages sent to a consumer's dead letter subject,
+// in addition to the stream, subject and sequence of the original message.
+const (
+	JSDeadLetterConsumer   = "Nats-Dead-Letter-Consumer"
+	JSDeadLetterDeliveries = "Nats-Dead-Letter-Deliveries"
+)
+
 // Rollups, can be subject only or all messages.
 const (
 	JSMsgRollupSubject = "sub"
@@ -777,6 +796,9 @@ func (a *Account) addStreamWithAssignment(config *StreamConfig, fsConfig *FileSt
 
 	// Note that isClustered will be false during recovery, even if we're part of a cluster. It shouldn't be used then.
 	js, isClustered := jsa.jetStreamAndClustered()
+	a.mu.RLock()
+	accMlen, accMsz := a.jsMaxBufferedMsgs, a.jsMaxBufferedSize
+	a.mu.RUnlock()
 	jsa.mu.Lock()
 	if mset, ok := jsa.streams[cfg.Name]; ok {
 		jsa.mu.Unlock()
@@ -890,9 +912,15 @@ func (a *Account) addStreamWithAssignment(config *StreamConfig, fsConfig *FileSt
 	c := s.createInternalJetStreamClient()
 	ic := s.createInternalJetStreamClient()
 
-	// Work out the stream ingest limits.
+	// Work out the stream ingest limits, the account may override the server ones.
 	mlen := s.opts.StreamMaxBufferedMsgs
 	msz := uint64(s.opts.StreamMaxBufferedSize)
+	if accMlen > 0 {
+		mlen = accMlen
+	}
+	if accMsz > 0 {
+		msz = uint64(accMsz)
+	}
 	if mlen == 0 {
 		mlen = streamDefaultMaxQueueMsgs
 	}
@@ -925,6 +953,7 @@ func (a *Account) addStreamWithAssignment(config *StreamConfig, fsConfig *FileSt
 		uch:     make(chan struct{}, 4),
 		sch:     make(chan struct{}, 1),
 		created: time.Now().UTC(),
+		ddmax:   s.getOpts().JetStreamLimits.MaxDuplicateEntries,
 	}
 
 	// Add created timestamp used for the store, must match that of the stream assignment if it exists.
@@ -1069,6 +1098,9 @@ func (a *Account) addStreamWithAssignment(config *StreamConfig, fsConfig *FileSt
 		}
 	}
 
+	// Eagerly load the stream's state if configured to do so.
+	mset.js.preloadStream(mset, a.Name, cfg.Name)
+
 	// Register with our account last.
 	jsa.mu.Lock()
 	jsa.streams[cfg.Name] = mset
@@ -1764,6 +1796,15 @@ func (s *Server) checkStreamCfg(config *StreamConfig, acc *Account, pedantic boo
 		return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("roll-ups require the purge permission"))
 	}
 
+	if cfg.ConsumerLimits.DefaultMaxDeliver < -1 {
+		return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("consumer limits default max deliver must be -1 or positive"))
+	}
+	if cfg.ConsumerLimits.NamePattern != _EMPTY_ {
+		if _, err := regexp.Compile(cfg.ConsumerLimits.NamePattern); err != nil {
+			return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("consumer limits name pattern is invalid: %v", err))
+		}
+	}
+
 	// Counter is not compatible with some settings.
 	if cfg.AllowMsgCounter {
 		if cfg.Discard == DiscardNew {
@@ -2136,6 +2177,10 @@ func (s *Server) checkStreamCfg(config *StreamConfig, acc *Account, pedantic boo
 		if cfg.Mirror != nil {
 			return StreamConfig{}, NewJSMirrorWithSubjectsError()
 		}
+		if lim.MaxStreamSubjects > 0 && len(cfg.Subjects) > lim.MaxStreamSubjects {
+			return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("stream has %d subjects, exceeding the server limit of %d",
+				len(cfg.Subjects), lim.MaxStreamSubjects))
+		}
 
 		// Check for literal duplication of subject interest in config
 		// and no overlap with any JS or SYS API subject space.
@@ -2256,6 +2301,38 @@ func (s *Server) checkStreamCfg(config *StreamConfig, acc *Account, pedantic boo
 	if cfg.Placement != nil && cfg.Placement.Preferred != _EMPTY_ {
 		return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("preferred server not permitted in placement"))
 	}
+	// Servers pinned in placement need to be distinct and able to host all replicas.
+	if cfg.Placement != nil && len(cfg.Placement.Servers) > 0 {
+		names := make(map[string]struct{}, len(cfg.Placement.Servers))
+		for _, name := range cfg.Placement.Servers {
+			if name == _EMPTY_ {
+				return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("placement servers can not be empty"))
+			}
+			if _, ok := names[name]; ok {
+				return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("duplicate placement server %q", name))
+			}
+			names[name] = struct{}{}
+		}
+		if len(names) < cfg.Replicas {
+			return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("placement servers (%d) less than replicas (%d)", len(names), cfg.Replicas))
+		}
+		// Replicas of a stream can not span clusters, nor be placed outside of the placement cluster.
+		var cluster string
+		for _, name := range cfg.Placement.Servers {
+			cn := s.clusterNameForServer(name)
+			if cn == _EMPTY_ {
+				continue
+			}
+			if cfg.Placement.Cluster != _EMPTY_ && cn != cfg.Placement.Cluster {
+				return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("placement server %q not in placement cluster %q", name, cfg.Placement.Cluster))
+			}
+			if cluster == _EMPTY_ {
+				cluster = cn
+			} else if cn != cluster {
+				return StreamConfig{}, NewJSStreamInvalidConfigError(fmt.Errorf("placement servers span clusters %q and %q", cluster, cn))
+			}
+		}
+	}
 
 	return cfg, nil
 }
@@ -5251,6 +5328,12 @@ func (mset *stream) storeUpdates(md, bd int64, seq uint64, subj string) {
 	}
 }
 
+// bufferedLimits returns the effective limits of the inbound message buffer.
+// These are set on creation, so no lock is needed.
+func (mset *stream) bufferedLimits() (int, int64) {
+	return mset.msgs.mlen, int64(mset.msgs.msz)
+}
+
 // NumMsgIds returns the number of message ids being tracked for duplicate suppression.
 func (mset *stream) numMsgIds() int {
 	mset.ddMu.Lock()
@@ -5338,6 +5421,19 @@ func (mset *stream) storeMsgIdLocked(dde *ddentry) {
 	if mset.ddtmr == nil {
 		mset.ddtmr = time.AfterFunc(mset.cfg.Duplicates, mset.purgeMsgIds)
 	}
+	// Evict the oldest entries if over the limit, even if still in the window.
+	if mset.ddmax > 0 && len(mset.ddmap) > mset.ddmax {
+		for len(mset.ddmap) > mset.ddmax && mset.ddindex < len(mset.ddarr) {
+			delete(mset.ddmap, mset.ddarr[mset.ddindex].id)
+			mset.ddarr[mset.ddindex] = nil
+			mset.ddindex++
+		}
+		// Check if we should garbage collect here if we are 1/3 total size.
+		if cap(mset.ddarr) > 3*(len(mset.ddarr)-mset.ddindex) {
+			mset.ddarr = append([]*ddentry(nil), mset.ddarr[mset.ddindex:]...)
+			mset.ddindex = 0
+		}
+	}
 }
 
 // Fast lookup of msgId.
@@ -6866,6 +6962,17 @@ func (mset *stream) processJetStreamMsgWithBatch(subject, reply string, hdr, msg
 		return NewJSInsufficientResourcesError()
 	}
 
+	// Reject writes to memory storage while the system is low on free memory.
+	if !isClustered && js.memoryLow(stype) {
+		if canRespond {
+			resp.PubAck = &PubAck{Stream: name}
+			resp.Error = NewJSMemoryLowError()
+			response, _ = json.Marshal(resp)
+			outq.sendMsg(reply, response)
+		}
+		return NewJSMemoryLowError()
+	}
+
 	var noInterest bool
 
 	// If we are interest based retention and have no consumers then we can skip.
@@ -7219,6 +7326,11 @@ func (mset *stream) processJetStreamAtomicBatchMsg(batchId, subject, reply strin
 		return respondError(NewJSInsufficientResourcesError())
 	}
 
+	// Reject writes to memory storage while the system is low on free memory.
+	if js.memoryLow(stype) {
+		return respondError(NewJSMemoryLowError())
+	}
+
 	// Check here pre-emptively if we have exceeded our account limits.
 	if exceeded, err := jsa.wouldExceedLimits(stype, tierName, r, subject, hdr, msg); exceeded {
 		if err == nil {
@@ -7656,6 +7768,11 @@ func (mset *stream) processJetStreamFastBatchMsg(batch *FastBatch, subject, repl
 		return respondError(NewJSInsufficientResourcesError())
 	}
 
+	// Reject writes to memory storage while the system is low on free memory.
+	if js.memoryLow(stype) {
+		return respondError(NewJSMemoryLowError())
+	}
+
 	// Check here pre-emptively if we have exceeded our account limits.
 	if exceeded, err := jsa.wouldExceedLimits(st, tierName, r, csubject, hdr, msg); exceeded {
 		if err == nil {
@@ -7999,7 +8116,7 @@ func (mset *stream) signalConsumers(subj string, seq uint64) {
 		return
 	}
 	csl.Match(subj, func(o *consumer) {
-		o.processStreamSignal(seq)
+		o.processStreamSignal(subj, seq)
 	})
 }
 
@@ -8130,6 +8247,78 @@ func (mset *stream) nameLocked(needLock bool) string {
 	return mset.cfg.Name
 }
 
+// jsOutSender delivers queued messages through an internal client, reusing its scratch buffers.
+type jsOutSender struct {
+	c *client
+
+	// Raw scratch buffer.
+	// This should be rarely used now so can be smaller.
+	r [1024]byte
+
+	// To optimize for not converting a string to a []byte slice.
+	subj  [256]byte
+	dsubj [256]byte
+	rply  [256]byte
+	szb   [10]byte
+	hdb   [10]byte
+}
+
+// Delivers the messages and flushes the clients.
+func (sndr *jsOutSender) send(pms []*jsPubMsg) {
+	c := sndr.c
+	for _, pm := range pms {
+		c.pa.subject = append(sndr.dsubj[:0], pm.dsubj...)
+		c.pa.deliver = append(sndr.subj[:0], pm.subj...)
+		c.pa.size = len(pm.msg) + len(pm.hdr)
+		c.pa.szb = append(sndr.szb[:0], strconv.Itoa(c.pa.size)...)
+		if len(pm.reply) > 0 {
+			c.pa.reply = append(sndr.rply[:0], pm.reply...)
+		} else {
+			c.pa.reply = nil
+		}
+
+		// If we have an underlying buf that is the wire contents for hdr + msg, else construct on the fly.
+		var msg []byte
+		if len(pm.buf) > 0 {
+			msg = pm.buf
+		} else {
+			if len(pm.hdr) > 0 {
+				msg = pm.hdr
+				if len(pm.msg) > 0 {
+					msg = sndr.r[:0]
+					msg = append(msg, pm.hdr...)
+					msg = append(msg, pm.msg...)
+				}
+			} else if len(pm.msg) > 0 {
+				// We own this now from a low level buffer perspective so can use directly here.
+				msg = pm.msg
+			}
+		}
+
+		if len(pm.hdr) > 0 {
+			c.pa.hdr = len(pm.hdr)
+			c.pa.hdb = []byte(strconv.Itoa(c.pa.hdr))
+			c.pa.hdb = append(sndr.hdb[:0], strconv.Itoa(c.pa.hdr)...)
+		} else {
+			c.pa.hdr = -1
+			c.pa.hdb = nil
+		}
+
+		msg = append(msg, _CRLF_...)
+
+		didDeliver, _ := c.processInboundClientMsg(msg)
+		c.pa.szb, c.pa.subject, c.pa.deliver = nil, nil, nil
+
+		// Check to see if this is a delivery for a consumer and
+		// we failed to deliver the message. If so alert the consumer.
+		if pm.o != nil && pm.seq > 0 && !didDeliver {
+			pm.o.didNotDeliver(pm.seq, pm.dsubj)
+		}
+		pm.returnToPool()
+	}
+	c.flushClients(0)
+}
+
 func (mset *stream) internalLoop() {
 	mset.mu.RLock()
 	setGoRoutineLabels(pprofLabels{
@@ -8152,75 +8341,13 @@ func (mset *stream) internalLoop() {
 	}
 	mset.mu.RUnlock()
 
-	// Raw scratch buffer.
-	// This should be rarely used now so can be smaller.
-	var _r [1024]byte
-
-	// To optimize for not converting a string to a []byte slice.
-	var (
-		subj  [256]byte
-		dsubj [256]byte
-		rply  [256]byte
-		szb   [10]byte
-		hdb   [10]byte
-	)
+	sender := &jsOutSender{c: c}
 
 	for {
 		select {
 		case <-outq.ch:
 			pms := outq.pop()
-			for _, pm := range pms {
-				c.pa.subject = append(dsubj[:0], pm.dsubj...)
-				c.pa.deliver = append(subj[:0], pm.subj...)
-				c.pa.size = len(pm.msg) + len(pm.hdr)
-				c.pa.szb = append(szb[:0], strconv.Itoa(c.pa.size)...)
-				if len(pm.reply) > 0 {
-					c.pa.reply = append(rply[:0], pm.reply...)
-				} else {
-					c.pa.reply = nil
-				}
-
-				// If we have an underlying buf that is the wire contents for hdr + msg, else construct on the fly.
-				var msg []byte
-				if len(pm.buf) > 0 {
-					msg = pm.buf
-				} else {
-					if len(pm.hdr) > 0 {
-						msg = pm.hdr
-						if len(pm.msg) > 0 {
-							msg = _r[:0]
-							msg = append(msg, pm.hdr...)
-							msg = append(msg, pm.msg...)
-						}
-					} else if len(pm.msg) > 0 {
-						// We own this now from a low level buffer perspective so can use directly here.
-						msg = pm.msg
-					}
-				}
-
-				if len(pm.hdr) > 0 {
-					c.pa.hdr = len(pm.hdr)
-					c.pa.hdb = []byte(strconv.Itoa(c.pa.hdr))
-					c.pa.hdb = append(hdb[:0], strconv.Itoa(c.pa.hdr)...)
-				} else {
-					c.pa.hdr = -1
-					c.pa.hdb = nil
-				}
-
-				msg = append(msg, _CRLF_...)
-
-				didDeliver, _ := c.processInboundClientMsg(msg)
-				c.pa.szb, c.pa.subject, c.pa.deliver = nil, nil, nil
-
-				// Check to see if this is a delivery for a consumer and
-				// we failed to deliver the message. If so alert the consumer.
-				if pm.o != nil && pm.seq > 0 && !didDeliver {
-					pm.o.didNotDeliver(pm.seq, pm.dsubj)
-				}
-				pm.returnToPool()
-			}
-			// TODO: Move in the for-loop?
-			c.flushClients(0)
+			sender.send(pms)
 			outq.recycle(&pms)
 		case <-msgs.ch:
 			// This can possibly change now so needs to be checked here.
@@ -869,7 +869,14 @@ func (s *Server) wsUpgrade(w http.ResponseWriter, r *http.Request) (*wsUpgradeRe
 		return nil, wsReturnHTTPError(w, r, http.StatusForbidden, fmt.Sprintf("origin not allowed: %v", err))
 	}
 	// Point 8.
-	// We don't have protocols, so ignore.
+	// Subprotocols, only if configured (and not for MQTT that has its own).
+	var subproto string
+	if kind != MQTT && len(opts.Websocket.Subprotocols) > 0 {
+		var offered bool
+		if subproto, offered = wsSelectSubprotocol(r.Header, opts.Websocket.Subprotocols); offered && subproto == _EMPTY_ {
+			return nil, wsReturnHTTPError(w, r, http.StatusBadRequest, "unsupported websocket subprotocol")
+		}
+	}
 	// Point 9.
 	// Extensions, only support for compression at the moment
 	compress := opts.Websocket.Compression
@@ -911,6 +918,10 @@ func (s *Server) wsUpgrade(w http.ResponseWriter, r *http.Request) (*wsUpgradeRe
 	}
 	if kind == MQTT {
 		p = append(p, wsMQTTSecProto...)
+	} else if subproto != _EMPTY_ {
+		p = append(p, wsSecProto+": "...)
+		p = append(p, subproto...)
+		p = append(p, _CRLF_...)
 	}
 	if s.websocket.rawHeaders != _EMPTY_ {
 		p = append(p, s.websocket.rawHeaders...)
@@ -983,6 +994,29 @@ func wsHeaderContains(header http.Header, name string, value string) bool {
 	return false
 }
 
+// Returns the first subprotocol offered by the client that is part of the
+// accepted list, and whether or not the client offered any subprotocol.
+// If the client offered subprotocols but none are accepted, the returned
+// subprotocol is empty.
+func wsSelectSubprotocol(header http.Header, accepted []string) (string, bool) {
+	var offered bool
+	for _, s := range header[wsSecProto] {
+		for _, t := range strings.Split(s, ",") {
+			t = strings.Trim(t, " \t")
+			if t == _EMPTY_ {
+				continue
+			}
+			offered = true
+			for _, a := range accepted {
+				if t == a {
+					return t, true
+				}
+			}
+		}
+	}
+	return _EMPTY_, offered
+}
+
 func wsPMCExtensionSupport(header http.Header, checkPMCOnly bool) (bool, bool) {
 	for _, extensionList := range header["Sec-Websocket-Extensions"] {
 		extensions := strings.Split(extensionList, ",")
@@ -1133,6 +1167,10 @@ func validateWebsocketOptions(o *Options) error {
 	if wo.TLSConfig == nil && !wo.NoTLS {
 		return errors.New("websocket requires TLS configuration")
 	}
+	// If an explicit origin policy is required, make sure there is one.
+	if wo.RequireOriginConfig && !wo.SameOrigin && len(wo.AllowedOrigins) == 0 {
+		return errors.New("websocket: require_origin_config is set but neither same_origin nor allowed_origins is configured")
+	}
 	// Make sure that allowed origins, if specified, can be parsed.
 	for _, ao := range wo.AllowedOrigins {
 		u, err := url.ParseRequestURI(ao)
@@ -1175,6 +1213,12 @@ func validateWebsocketOptions(o *Options) error {
 		return fmt.Errorf("websocket: %v", err)
 	}
 
+	for _, p := range wo.Subprotocols {
+		if strings.TrimSpace(p) == _EMPTY_ {
+			return errors.New("websocket: subprotocols cannot contain empty values")
+		}
+	}
+
 	// Check for invalid headers here.
 	for key := range wo.Headers {
 		k := strings.ToLower(key)
@@ -1350,6 +1394,11 @@ func (s *Server) startWebsocketServer() {
 		Handler:     mux,
 		ReadTimeout: o.HandshakeTimeout,
 		ErrorLog:    log.New(&captureHTTPServerLog{s, "websocket: "}, _EMPTY_, 0),
+		ConnState: func(conn net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				s.setTCPKeepAlive(conn)
+			}
+		},
 	}
 	s.websocket.mu.Lock()
 	s.websocket.server = hs
@@ -1390,10 +1439,7 @@ func (s *Server) createWSClient(conn net.Conn, ws *websocket) *client {
 	opts := s.getOpts()
 
 	maxPay := int32(opts.MaxPayload)
-	maxSubs := int32(opts.MaxSubs)
-	if maxSubs == 0 {
-		maxSubs = -1
-	}
+	maxSubs := maxSubsLimit(opts.MaxSubs)
 	now := time.Now().UTC()
 
 	c := &client{srv: s, nc: conn, opts: defaultOpts, mpay: maxPay, msubs: maxSubs, start: now, last: now, ws: ws}
@@ -1446,6 +1492,7 @@ func (s *Server) createWSClient(conn net.Conn, ws *websocket) *client {
 		return nil
 	}
 	s.clients[c.cid] = c
+	s.checkMaxConnSoftLimitLocked(opts)
 	s.mu.Unlock()
 
 	c.mu.Lock()
@@ -1630,6 +1630,12 @@ func TestWSParseOptions(t *testing.T) {
 			}
 			return nil
 		}, ""},
+		{"require origin config", `websocket { require_origin_config: true }`, func(wo *WebsocketOpts) error {
+			if !wo.RequireOriginConfig {
+				return fmt.Errorf("expected require_origin_config==true, got %v", wo.RequireOriginConfig)
+			}
+			return nil
+		}, ""},
 		{"allowed origins one only", `websocket { allowed_origins: "https://host.com/" }`, func(wo *WebsocketOpts) error {
 			expected := []string{"https://host.com/"}
 			if !reflect.DeepEqual(wo.AllowedOrigins, expected) {
@@ -1792,6 +1798,23 @@ func TestWSValidateOptions(t *testing.T) {
 	}{
 		{"websocket disabled", func() *Options { return nwso.Clone() }, ""},
 		{"no tls", func() *Options { o := wso.Clone(); o.Websocket.TLSConfig = nil; return o }, "requires TLS configuration"},
+		{"require origin config without origin policy", func() *Options {
+			o := wso.Clone()
+			o.Websocket.RequireOriginConfig = true
+			return o
+		}, "neither same_origin nor allowed_origins is configured"},
+		{"require origin config with same origin", func() *Options {
+			o := wso.Clone()
+			o.Websocket.RequireOriginConfig = true
+			o.Websocket.SameOrigin = true
+			return o
+		}, ""},
+		{"require origin config with allowed origins", func() *Options {
+			o := wso.Clone()
+			o.Websocket.RequireOriginConfig = true
+			o.Websocket.AllowedOrigins = []string{"https://host.com"}
+			return o
+		}, ""},
 		{"bad url in allowed list", func() *Options {
 			o := wso.Clone()
 			o.Websocket.AllowedOrigins = []string{"http://this:is:bad:url"}
@@ -4245,6 +4268,70 @@ func TestWSSetHeaderServer(t *testing.T) {
 	defer c.Close()
 }
 
+func TestWSSubprotocols(t *testing.T) {
+	o := testWSOptions()
+	o.Websocket.Subprotocols = []string{"nats", "nats.v2"}
+	s := RunServer(o)
+	defer s.Shutdown()
+
+	for _, test := range []struct {
+		name     string
+		offered  []string
+		expected string
+		err      bool
+	}{
+		{"none offered", nil, _EMPTY_, false},
+		{"single offered", []string{"nats.v2"}, "nats.v2", false},
+		{"first acceptable", []string{"other, nats.v2", "nats"}, "nats.v2", false},
+		{"not acceptable", []string{"other"}, _EMPTY_, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			opts := testWSClientOptions{
+				host:                 o.Websocket.Host,
+				port:                 o.Websocket.Port,
+				extraResponseHeaders: map[string]string{wsSecProto: test.expected},
+			}
+			if len(test.offered) > 0 {
+				opts.extraHeaders = map[string][]string{wsSecProto: test.offered}
+			}
+			c, _, _, err := testNewWSClientWithError(t, opts)
+			if test.err {
+				if err == nil || !strings.Contains(err.Error(), "400") {
+					c.Close()
+					t.Fatalf("Expected upgrade to be rejected, got %v", err)
+				}
+				return
+			}
+			require_NoError(t, err)
+			c.Close()
+		})
+	}
+
+	// Without configured subprotocols, the offered ones are ignored.
+	o2 := testWSOptions()
+	s2 := RunServer(o2)
+	defer s2.Shutdown()
+	c, _, _ := testNewWSClient(t, testWSClientOptions{
+		host:                 o2.Websocket.Host,
+		port:                 o2.Websocket.Port,
+		extraHeaders:         map[string][]string{wsSecProto: {"other"}},
+		extraResponseHeaders: map[string]string{wsSecProto: _EMPTY_},
+	})
+	c.Close()
+
+	// Empty subprotocols are rejected.
+	conf := createConfFile(t, []byte(`
+		websocket {
+			port: -1
+			no_tls: true
+			subprotocols: ["nats", ""]
+		}
+	`))
+	_, err := ProcessConfigFile(conf)
+	require_Error(t, err)
+	require_Contains(t, err.Error(), "cannot contain empty values")
+}
+
 func TestWSJWTWithAllowedConnectionTypes(t *testing.T) {
 	o := testWSOptions()
 	setupAddTrusted(o)
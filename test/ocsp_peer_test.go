@@ -435,6 +435,210 @@ func TestOCSPPeerRevokedClient(t *testing.T) {
 	}
 }
 
+// TestOCSPPeerRevokedWebsocketClient is test of websocket clients presenting a revoked and a good certificate
+// to a websocket listener with OCSP peer check enabled
+func TestOCSPPeerRevokedWebsocketClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rootCAResponder := NewOCSPResponderRootCA(t)
+	rootCAResponderURL := fmt.Sprintf("http://%s", rootCAResponder.Addr)
+	defer rootCAResponder.Shutdown(ctx)
+	SetOCSPStatus(t, rootCAResponderURL, "configs/certs/ocsp_peer/mini-ca/intermediate1/intermediate1_cert.pem", ocsp.Good)
+	SetOCSPStatus(t, rootCAResponderURL, "configs/certs/ocsp_peer/mini-ca/intermediate2/intermediate2_cert.pem", ocsp.Good)
+
+	intermediateCA1Responder := NewOCSPResponderIntermediateCA1(t)
+	intermediateCA1ResponderURL := fmt.Sprintf("http://%s", intermediateCA1Responder.Addr)
+	defer intermediateCA1Responder.Shutdown(ctx)
+	SetOCSPStatus(t, intermediateCA1ResponderURL, "configs/certs/ocsp_peer/mini-ca/client1/UserA1_cert.pem", ocsp.Revoked)
+
+	intermediateCA2Responder := NewOCSPResponderIntermediateCA2(t)
+	intermediateCA2ResponderURL := fmt.Sprintf("http://%s", intermediateCA2Responder.Addr)
+	defer intermediateCA2Responder.Shutdown(ctx)
+	SetOCSPStatus(t, intermediateCA2ResponderURL, "configs/certs/ocsp_peer/mini-ca/client2/UserB1_cert.pem", ocsp.Good)
+
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		port: -1
+		websocket: {
+			port: -1
+			tls: {
+				cert_file: "configs/certs/ocsp_peer/mini-ca/server1/TestServer1_bundle.pem"
+				key_file: "configs/certs/ocsp_peer/mini-ca/server1/private/TestServer1_keypair.pem"
+				ca_file: "configs/certs/ocsp_peer/mini-ca/root/root_cert.pem"
+				timeout: 5
+				verify: true
+				# Turn on CA OCSP check so the revoked client should NOT be able to connect
+				ocsp_peer: true
+			}
+		}
+		# Keep the response cache out of the source tree
+		ocsp_cache: {
+			type: local
+			local_store: %q
+		}
+	`, t.TempDir())))
+	s, opts := RunServerWithConfig(conf)
+	defer s.Shutdown()
+	wsURL := fmt.Sprintf("wss://localhost:%d", opts.Websocket.Port)
+
+	for _, test := range []struct {
+		name     string
+		cert     string
+		key      string
+		rejected bool
+	}{
+		{
+			"client revoked by intermediate CA 1",
+			"./configs/certs/ocsp_peer/mini-ca/client1/UserA1_bundle.pem",
+			"./configs/certs/ocsp_peer/mini-ca/client1/private/UserA1_keypair.pem",
+			true,
+		},
+		{
+			"client good by intermediate CA 2",
+			"./configs/certs/ocsp_peer/mini-ca/client2/UserB1_bundle.pem",
+			"./configs/certs/ocsp_peer/mini-ca/client2/private/UserB1_keypair.pem",
+			false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			nc, err := nats.Connect(wsURL,
+				nats.ClientCert(test.cert, test.key),
+				nats.RootCAs("./configs/certs/ocsp_peer/mini-ca/root/root_cert.pem"),
+				nats.ErrorHandler(noOpErrHandler),
+			)
+			if test.rejected {
+				if err == nil {
+					nc.Close()
+					t.Fatalf("Expected error on connect")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected to connect, got %v", err)
+			}
+			nc.Close()
+		})
+	}
+
+	// OCSP peer check requires mTLS on the websocket listener as well.
+	conf = createConfFile(t, []byte(`
+		port: -1
+		websocket: {
+			port: -1
+			tls: {
+				cert_file: "configs/certs/ocsp_peer/mini-ca/server1/TestServer1_bundle.pem"
+				key_file: "configs/certs/ocsp_peer/mini-ca/server1/private/TestServer1_keypair.pem"
+				ca_file: "configs/certs/ocsp_peer/mini-ca/root/root_cert.pem"
+				ocsp_peer: true
+			}
+		}
+	`))
+	o, err := server.ProcessConfigFile(conf)
+	if err != nil {
+		t.Fatalf("Error processing config: %v", err)
+	}
+	o.NoLog, o.NoSigs = true, true
+	if _, err = server.NewServer(o); err == nil {
+		t.Fatalf("Expected error creating server without mTLS on the websocket listener")
+	}
+}
+
+func TestOCSPPeerRevokedMonitoringClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rootCAResponder := NewOCSPResponderRootCA(t)
+	rootCAResponderURL := fmt.Sprintf("http://%s", rootCAResponder.Addr)
+	defer rootCAResponder.Shutdown(ctx)
+	SetOCSPStatus(t, rootCAResponderURL, "configs/certs/ocsp_peer/mini-ca/intermediate1/intermediate1_cert.pem", ocsp.Good)
+	SetOCSPStatus(t, rootCAResponderURL, "configs/certs/ocsp_peer/mini-ca/intermediate2/intermediate2_cert.pem", ocsp.Good)
+
+	intermediateCA1Responder := NewOCSPResponderIntermediateCA1(t)
+	intermediateCA1ResponderURL := fmt.Sprintf("http://%s", intermediateCA1Responder.Addr)
+	defer intermediateCA1Responder.Shutdown(ctx)
+	SetOCSPStatus(t, intermediateCA1ResponderURL, "configs/certs/ocsp_peer/mini-ca/client1/UserA1_cert.pem", ocsp.Revoked)
+
+	intermediateCA2Responder := NewOCSPResponderIntermediateCA2(t)
+	intermediateCA2ResponderURL := fmt.Sprintf("http://%s", intermediateCA2Responder.Addr)
+	defer intermediateCA2Responder.Shutdown(ctx)
+	SetOCSPStatus(t, intermediateCA2ResponderURL, "configs/certs/ocsp_peer/mini-ca/client2/UserB1_cert.pem", ocsp.Good)
+
+	conf := createConfFile(t, []byte(fmt.Sprintf(`
+		port: -1
+		http: {
+			https_listen: 127.0.0.1:-1
+			tls: {
+				cert_file: "configs/certs/ocsp_peer/mini-ca/server1/TestServer1_bundle.pem"
+				key_file: "configs/certs/ocsp_peer/mini-ca/server1/private/TestServer1_keypair.pem"
+				ca_file: "configs/certs/ocsp_peer/mini-ca/root/root_cert.pem"
+				timeout: 5
+				verify: true
+				# Turn on CA OCSP check so the revoked client should NOT be able to connect
+				ocsp_peer: true
+			}
+		}
+		# Keep the response cache out of the source tree
+		ocsp_cache: {
+			type: local
+			local_store: %q
+		}
+	`, t.TempDir())))
+	s, _ := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	caCert, err := os.ReadFile("./configs/certs/ocsp_peer/mini-ca/root/root_cert.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCertPool := x509.NewCertPool()
+	caCertPool.AppendCertsFromPEM(caCert)
+
+	for _, test := range []struct {
+		name     string
+		cert     string
+		key      string
+		rejected bool
+	}{
+		{
+			"client revoked by intermediate CA 1",
+			"./configs/certs/ocsp_peer/mini-ca/client1/UserA1_bundle.pem",
+			"./configs/certs/ocsp_peer/mini-ca/client1/private/UserA1_keypair.pem",
+			true,
+		},
+		{
+			"client good by intermediate CA 2",
+			"./configs/certs/ocsp_peer/mini-ca/client2/UserB1_bundle.pem",
+			"./configs/certs/ocsp_peer/mini-ca/client2/private/UserB1_keypair.pem",
+			false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cert, err := tls.LoadX509KeyPair(test.cert, test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hc := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: caCertPool},
+				},
+			}
+			defer hc.CloseIdleConnections()
+			resp, err := hc.Get("https://" + s.MonitorAddr().String() + "/varz")
+			if test.rejected {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatalf("Expected monitoring request to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected monitoring request to succeed, got %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
 // TestOCSPPeerUnknownAndRevokedIntermediate test of NATS client that is OCSP good but either its intermediate is unknown or revoked
 func TestOCSPPeerUnknownAndRevokedIntermediate(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
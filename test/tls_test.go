@@ -194,6 +194,78 @@ func TestTLSClientCertificateCheckWithAllowedConnectionTypes(t *testing.T) {
 	}
 }
 
+func TestTLSClientCertificateAccountMapping(t *testing.T) {
+	template := `
+		listen: "localhost:-1"
+		tls {
+			cert_file: "./configs/certs/tlsauth/server.pem"
+			key_file: "./configs/certs/tlsauth/server-key.pem"
+			ca_file: "./configs/certs/tlsauth/ca.pem"
+			verify_and_map: true
+			account_mapping { from: "ou" }
+		}
+		authorization {
+			users = [{user: "CN=example.com,OU=CNCF"}]
+		}
+		accounts {
+			CNCF {}
+			%s
+		}
+	`
+	connect := func(o *server.Options, cert string) (*nats.Conn, error) {
+		t.Helper()
+		return nats.Connect(fmt.Sprintf("tls://%s:%d", o.Host, o.Port),
+			nats.ClientCert(fmt.Sprintf("./configs/certs/tlsauth/%s.pem", cert), fmt.Sprintf("./configs/certs/tlsauth/%s-key.pem", cert)),
+			nats.RootCAs("./configs/certs/tlsauth/ca.pem"))
+	}
+	checkAccount := func(s *server.Server, user, account string) {
+		t.Helper()
+		connz, err := s.Connz(&server.ConnzOptions{Username: true, User: user})
+		if err != nil {
+			t.Fatalf("Error getting connz: %v", err)
+		}
+		if len(connz.Conns) != 1 || connz.Conns[0].Account != account {
+			t.Fatalf("Expected user %q to be in account %q, got %+v", user, account, connz.Conns)
+		}
+	}
+
+	// A user defined outside of any account takes the account from the certificate,
+	// while a user explicitly defined in another account is rejected.
+	conf := createConfFile(t, []byte(fmt.Sprintf(template, `
+			NATS.io {}
+			OTHER { users = [{user: "CN=example.com,OU=NATS.io"}] }
+	`)))
+	s, o := RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, err := connect(o, "client2")
+	if err != nil {
+		t.Fatalf("Expected to connect, got %v", err)
+	}
+	defer nc.Close()
+	checkAccount(s, "CN=example.com,OU=CNCF", "CNCF")
+
+	if nc, err := connect(o, "client"); err == nil {
+		nc.Close()
+		t.Fatal("Expected connection to fail, it did not")
+	}
+	s.Shutdown()
+
+	// A user defined in the account derived from the certificate is accepted.
+	conf = createConfFile(t, []byte(fmt.Sprintf(template, `
+			NATS.io { users = [{user: "CN=example.com,OU=NATS.io"}] }
+	`)))
+	s, o = RunServerWithConfig(conf)
+	defer s.Shutdown()
+
+	nc, err = connect(o, "client")
+	if err != nil {
+		t.Fatalf("Expected to connect, got %v", err)
+	}
+	defer nc.Close()
+	checkAccount(s, "CN=example.com,OU=NATS.io", "NATS.io")
+}
+
 func TestTLSClientCertificateCNBasedAuth(t *testing.T) {
 	srv, opts := RunServerWithConfig("./configs/tls_cert_cn.conf")
 	defer srv.Shutdown()